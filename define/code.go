@@ -17,4 +17,7 @@ var (
 	WrongAccountOrPassword = codes.NewCode(101, "wrong account or password")
 	AccountExists          = codes.NewCode(102, "account exists")
 	IllegalOperation       = codes.NewCode(103, "illegal operation")
+	AlreadyOnline          = codes.NewCode(104, "account already online")
+	InsufficientBalance    = codes.NewCode(105, "insufficient balance")
+	IdempotencyConflict    = codes.NewCode(106, "idempotency key conflict")
 )
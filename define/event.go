@@ -0,0 +1,30 @@
+package define
+
+import (
+	"time"
+)
+
+// 玩家成长事件类型
+const (
+	PlayerEventLevelUp = 1 // 升级
+	PlayerEventPower   = 2 // 战斗力变化
+	PlayerEventWealth  = 3 // 财富变化
+)
+
+// PlayerEvent 玩家成长事件
+type PlayerEvent struct {
+	Type     int       `json:"type"`
+	PlayerID string    `json:"player_id"`
+	Nickname string    `json:"nickname"`
+	Avatar   string    `json:"avatar"`
+	Level    int       `json:"level"`
+	Power    int       `json:"power"` // 当前战斗力
+	Coin     int64     `json:"coin"`  // 当前金币
+	Time     time.Time `json:"time"`
+}
+
+// BattleEvent 战斗结算事件，每个参战玩家一条战斗记录
+type BattleEvent struct {
+	BattleID string         `json:"battle_id"`
+	Records  []BattleRecord `json:"records"`
+}
@@ -21,6 +21,57 @@ const (
 	TaskTypeActivity = 4 // 活动任务
 )
 
+// 排行榜类型常量
+const (
+	RankingTypeLevel   = 1 // 等级
+	RankingTypePower   = 2 // 战斗力
+	RankingTypeWealth  = 3 // 财富
+	RankingTypeKill    = 4 // 击杀
+	RankingTypeWinRate = 5 // 胜率
+)
+
+// 排行榜周期常量
+const (
+	RankingPeriodAll    = 0 // 总榜
+	RankingPeriodDaily  = 1 // 日榜
+	RankingPeriodWeekly = 2 // 周榜
+	RankingPeriodSeason = 3 // 赛季榜
+)
+
+// 货币类型常量
+const (
+	CurrencyTypeCoin    = 1 // 金币
+	CurrencyTypeDiamond = 2 // 钻石
+)
+
+// 钱包流水原因码
+const (
+	WalletReasonShopBuy      = 1 // 商城购买
+	WalletReasonMail         = 2 // 邮件附件
+	WalletReasonTaskReward   = 3 // 任务奖励
+	WalletReasonBattleReward = 4 // 战斗奖励
+	WalletReasonGM           = 5 // 后台操作
+	WalletReasonRefund       = 6 // 退款
+	WalletReasonShopRefresh  = 7 // 商店刷新
+)
+
+// 商店限购周期常量
+const (
+	PurchaseLimitLifetime = 0 // 永久
+	PurchaseLimitDaily    = 1 // 每日重置
+	PurchaseLimitWeekly   = 2 // 每周重置
+	PurchaseLimitEvent    = 3 // 活动期间，活动结束后重置
+)
+
+// 商店订单状态
+const (
+	ShopOrderPending   = 0 // 处理中
+	ShopOrderCompleted = 1 // 已完成
+	ShopOrderFailed    = 2 // 失败，未扣款或已退回
+	ShopOrderRefunding = 3 // 退款中，物品已回收
+	ShopOrderRefunded  = 4 // 已退款
+)
+
 // 房间状态常量
 const (
 	RoomStatusWaiting = 0 // 等待中
@@ -52,7 +103,9 @@ type Player struct {
 	Coin          int64     `bson:"coin" json:"coin"`
 	Diamond       int64     `bson:"diamond" json:"diamond"`
 	CreateTime    time.Time `bson:"create_time" json:"create_time"`
+	RegisterIP    string    `bson:"register_ip" json:"register_ip"`
 	LastLoginTime time.Time `bson:"last_login_time" json:"last_login_time"`
+	LastLoginIP   string    `bson:"last_login_ip" json:"last_login_ip"`
 	OnlineStatus  bool      `bson:"online_status" json:"online_status"`
 	CurrentRoomID string    `bson:"current_room_id" json:"current_room_id"`
 }
@@ -85,17 +138,34 @@ type Item struct {
 
 // Mail 邮件数据
 type Mail struct {
-	ID         string     `bson:"_id" json:"id"`
-	PlayerID   string     `bson:"player_id" json:"player_id"`
-	Title      string     `bson:"title" json:"title"`
-	Content    string     `bson:"content" json:"content"`
-	Items      []ItemInfo `bson:"items" json:"items"`
-	Coin       int64      `bson:"coin" json:"coin"`
-	Diamond    int64      `bson:"diamond" json:"diamond"`
-	IsRead     bool       `bson:"is_read" json:"is_read"`
-	IsClaimed  bool       `bson:"is_claimed" json:"is_claimed"`
-	SendTime   time.Time  `bson:"send_time" json:"send_time"`
-	ExpireTime time.Time  `bson:"expire_time" json:"expire_time"`
+	ID          string     `bson:"_id" json:"id"`
+	PlayerID    string     `bson:"player_id" json:"player_id"`
+	Title       string     `bson:"title" json:"title"`
+	Content     string     `bson:"content" json:"content"`
+	Items       []ItemInfo `bson:"items" json:"items"`
+	Coin        int64      `bson:"coin" json:"coin"`
+	Diamond     int64      `bson:"diamond" json:"diamond"`
+	IsRead      bool       `bson:"is_read" json:"is_read"`
+	IsClaimed   bool       `bson:"is_claimed" json:"is_claimed"`
+	SendTime    time.Time  `bson:"send_time" json:"send_time"`
+	ExpireTime  time.Time  `bson:"expire_time" json:"expire_time"`
+	BroadcastID string     `bson:"broadcast_id,omitempty" json:"broadcast_id,omitempty"` // 来源全服邮件ID，个人邮件为空
+}
+
+// BroadcastMail 全服邮件，只存储一份，玩家拉取邮件时按条件生成个人邮件
+type BroadcastMail struct {
+	ID             string     `bson:"_id" json:"id"`
+	Title          string     `bson:"title" json:"title"`
+	Content        string     `bson:"content" json:"content"`
+	Items          []ItemInfo `bson:"items" json:"items"`
+	Coin           int64      `bson:"coin" json:"coin"`
+	Diamond        int64      `bson:"diamond" json:"diamond"`
+	MinLevel       int        `bson:"min_level" json:"min_level"`             // 最低等级，0表示不限
+	MaxLevel       int        `bson:"max_level" json:"max_level"`             // 最高等级，0表示不限
+	RegisterAfter  time.Time  `bson:"register_after" json:"register_after"`   // 注册时间不早于，零值表示不限
+	RegisterBefore time.Time  `bson:"register_before" json:"register_before"` // 注册时间不晚于，零值表示不限
+	SendTime       time.Time  `bson:"send_time" json:"send_time"`
+	ExpireTime     time.Time  `bson:"expire_time" json:"expire_time"`
 }
 
 // ItemInfo 邮件附件物品信息
@@ -181,6 +251,67 @@ type Ranking struct {
 	UpdateTime time.Time `bson:"update_time" json:"update_time"`
 }
 
+// RankingArchive 周期排行榜结算快照
+type RankingArchive struct {
+	ID          string               `bson:"_id" json:"id"` // 排行榜类型:周期:周期ID
+	RankingType int32                `bson:"ranking_type" json:"ranking_type"`
+	Period      int32                `bson:"period" json:"period"`
+	PeriodID    string               `bson:"period_id" json:"period_id"`
+	Total       int64                `bson:"total" json:"total"` // 结算时上榜总人数
+	Items       []RankingArchiveItem `bson:"items" json:"items"`
+	StartTime   time.Time            `bson:"start_time" json:"start_time"`
+	EndTime     time.Time            `bson:"end_time" json:"end_time"`
+	ArchiveTime time.Time            `bson:"archive_time" json:"archive_time"`
+}
+
+// RankingArchiveItem 排行榜结算快照项
+type RankingArchiveItem struct {
+	Rank     int    `bson:"rank" json:"rank"`
+	PlayerID string `bson:"player_id" json:"player_id"`
+	Nickname string `bson:"nickname" json:"nickname"`
+	Avatar   string `bson:"avatar" json:"avatar"`
+	Level    int    `bson:"level" json:"level"`
+	Score    int64  `bson:"score" json:"score"`
+}
+
+// WalletTransaction 钱包流水，只追加不修改；指定幂等键时以幂等键作为流水ID
+type WalletTransaction struct {
+	ID             string    `bson:"_id" json:"id"`
+	PlayerID       string    `bson:"player_id" json:"player_id"`
+	CurrencyType   int32     `bson:"currency_type" json:"currency_type"`
+	Amount         int64     `bson:"amount" json:"amount"`   // 变化数量，扣除为负数
+	Balance        int64     `bson:"balance" json:"balance"` // 变化后余额
+	Reason         int32     `bson:"reason" json:"reason"`
+	IdempotencyKey string    `bson:"idempotency_key,omitempty" json:"idempotency_key,omitempty"`
+	Remark         string    `bson:"remark" json:"remark"`
+	CreateTime     time.Time `bson:"create_time" json:"create_time"`
+}
+
+// ShopOrder 商店购买订单；指定幂等键时订单ID由玩家ID与幂等键组成
+type ShopOrder struct {
+	ID             string     `bson:"_id" json:"id"`
+	PlayerID       string     `bson:"player_id" json:"player_id"`
+	IdempotencyKey string     `bson:"idempotency_key,omitempty" json:"idempotency_key,omitempty"`
+	ShopType       int        `bson:"shop_type" json:"shop_type"`
+	ItemID         int        `bson:"item_id" json:"item_id"`
+	Count          int        `bson:"count" json:"count"`
+	TotalPrice     int64      `bson:"total_price" json:"total_price"` // 实付金额
+	CurrencyType   int        `bson:"currency_type" json:"currency_type"`
+	Items          []ItemInfo `bson:"items" json:"items"` // 发放的物品，含首购奖励
+	Coin           int64      `bson:"coin" json:"coin"`   // 发放的金币
+	Diamond        int64      `bson:"diamond" json:"diamond"`
+	FirstBonus     bool       `bson:"first_bonus" json:"first_bonus"`
+	CreditPending  bool       `bson:"credit_pending,omitempty" json:"-"`    // 发放的货币尚未计入钱包，按原幂等键补发
+	LimitID        string     `bson:"limit_id,omitempty" json:"-"`          // 限购记录ID，退款时退回限购数量
+	Attempt        int        `bson:"attempt" json:"attempt"`               // 失败后重试的次数，区分每次扣款的幂等键
+	RefundAttempt  int        `bson:"refund_attempt" json:"refund_attempt"` // 退款撤销后重试的次数，区分每次回收货币的幂等键
+	Status         int        `bson:"status" json:"status"`
+	Remark         string     `bson:"remark,omitempty" json:"remark,omitempty"` // 失败或退款原因
+	Operator       string     `bson:"operator,omitempty" json:"operator,omitempty"`
+	CreateTime     time.Time  `bson:"create_time" json:"create_time"`
+	UpdateTime     time.Time  `bson:"update_time" json:"update_time"`
+}
+
 // Position 位置信息
 type Position struct {
 	X float64 `json:"x"`
@@ -0,0 +1,20 @@
+package define
+
+// 客户端请求路由
+const (
+	RouteAuth int32 = 100 // 网关连接鉴权
+)
+
+// 推送消息路由
+const (
+	RouteQueueAdmitted int32 = 1001 // 排队放行通知
+	RouteKicked        int32 = 1002 // 踢下线通知
+	RouteNewMail       int32 = 1003 // 新邮件通知
+	RouteQueueRejected int32 = 1004 // 排队放行失败通知
+)
+
+// 踢下线原因
+const (
+	KickReasonDuplicateLogin int32 = 1 // 账号在其他设备登录
+	KickReasonAdmin          int32 = 2 // 管理员踢出
+)
@@ -64,6 +64,7 @@ func initAPP(proxy *mesh.Proxy) {
 	// 创建所有服务实例
 	services := []Service{
 		server.NewLoginServer(proxy),
+		server.NewWalletServer(proxy),
 		//server.NewRankingServer(proxy),
 		//server.NewShopServer(proxy),
 		//server.NewTaskServer(proxy),
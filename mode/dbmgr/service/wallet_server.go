@@ -0,0 +1,357 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"ghserver/define"
+	pb "ghserver/proto/pb"
+	"ghserver/utils/mongodb"
+
+	"github.com/dobyte/due/v2/cluster/mesh"
+	"github.com/dobyte/due/v2/codes"
+	"github.com/dobyte/due/v2/etc"
+	"github.com/dobyte/due/v2/log"
+	"github.com/dobyte/due/v2/utils/xuuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	playerCollection            = "player"             // 玩家集合
+	walletTransactionCollection = "wallet_transaction" // 钱包流水集合
+	defaultTransactionPageSize  = 20                   // 默认每页流水数量
+	maxTransactionPageSize      = 100                  // 最大每页流水数量
+)
+
+var (
+	ErrInvalidCurrency     = errors.New("货币类型错误")
+	ErrInvalidAmount       = errors.New("数量必须大于0")
+	ErrInsufficientBalance = errors.New("余额不足")
+	ErrIdempotencyConflict = errors.New("幂等键已被其他操作使用")
+	ErrWalletNotFound      = errors.New("玩家不存在")
+)
+
+// 货币类型对应的玩家字段
+var currencyFields = map[int32]string{
+	define.CurrencyTypeCoin:    "coin",
+	define.CurrencyTypeDiamond: "diamond",
+}
+
+// WalletServer 钱包服务，其他服务通过该服务变更玩家货币
+type WalletServer struct {
+	pb.UnimplementedWalletServiceServer
+	proxy         *mesh.Proxy
+	walletManager *WalletManager
+}
+
+func NewWalletServer(proxy *mesh.Proxy) *WalletServer {
+	return &WalletServer{
+		proxy: proxy,
+	}
+}
+
+func (s *WalletServer) Init() {
+	// 创建钱包管理器
+	walletManager, err := NewWalletManager(etc.Get("etc.mongo.default.database", "game").String())
+	if err != nil {
+		log.Fatalf("create wallet manager failed: %v", err)
+	}
+	s.walletManager = walletManager
+
+	s.proxy.AddServiceProvider("wallet", &pb.WalletService_ServiceDesc, s)
+}
+
+func (s *WalletServer) Close() error {
+	return nil
+}
+
+// GetBalance 查询余额
+func (s *WalletServer) GetBalance(ctx context.Context, req *pb.GetBalanceRequest) (*pb.GetBalanceResponse, error) {
+	log.Debugf("Get balance request: player_id=%s", req.PlayerId)
+
+	coin, diamond, err := s.walletManager.GetBalance(req.PlayerId)
+	if err != nil {
+		code, message := walletError("get balance", req.PlayerId, err)
+		return &pb.GetBalanceResponse{
+			Code:    code,
+			Message: message,
+		}, nil
+	}
+
+	return &pb.GetBalanceResponse{
+		Code:    int32(codes.OK.Code()),
+		Message: "查询余额成功",
+		Coin:    coin,
+		Diamond: diamond,
+	}, nil
+}
+
+// Credit 增加货币
+func (s *WalletServer) Credit(ctx context.Context, req *pb.WalletChangeRequest) (*pb.WalletChangeResponse, error) {
+	log.Debugf("Credit request: player_id=%s, currency_type=%d, amount=%d, reason=%d, idempotency_key=%s",
+		req.PlayerId, req.CurrencyType, req.Amount, req.Reason, req.IdempotencyKey)
+
+	return s.change(req, req.Amount), nil
+}
+
+// Debit 扣除货币，余额不足时失败且不产生流水
+func (s *WalletServer) Debit(ctx context.Context, req *pb.WalletChangeRequest) (*pb.WalletChangeResponse, error) {
+	log.Debugf("Debit request: player_id=%s, currency_type=%d, amount=%d, reason=%d, idempotency_key=%s",
+		req.PlayerId, req.CurrencyType, req.Amount, req.Reason, req.IdempotencyKey)
+
+	return s.change(req, -req.Amount), nil
+}
+
+// GetTransactions 查询流水
+func (s *WalletServer) GetTransactions(ctx context.Context, req *pb.GetTransactionsRequest) (*pb.GetTransactionsResponse, error) {
+	log.Debugf("Get transactions request: player_id=%s, currency_type=%d, page=%d, page_size=%d",
+		req.PlayerId, req.CurrencyType, req.Page, req.PageSize)
+
+	transactions, total, err := s.walletManager.GetTransactions(req.PlayerId, req.CurrencyType, int(req.Page), int(req.PageSize))
+	if err != nil {
+		code, message := walletError("get transactions", req.PlayerId, err)
+		return &pb.GetTransactionsResponse{
+			Code:    code,
+			Message: message,
+		}, nil
+	}
+
+	items := make([]*pb.WalletTransaction, len(transactions))
+	for i, transaction := range transactions {
+		items[i] = &pb.WalletTransaction{
+			Id:           transaction.ID,
+			CurrencyType: transaction.CurrencyType,
+			Amount:       transaction.Amount,
+			Balance:      transaction.Balance,
+			Reason:       transaction.Reason,
+			Remark:       transaction.Remark,
+			CreateTime:   transaction.CreateTime.Unix(),
+		}
+	}
+
+	return &pb.GetTransactionsResponse{
+		Code:         int32(codes.OK.Code()),
+		Message:      "查询流水成功",
+		Transactions: items,
+		Total:        int32(total),
+	}, nil
+}
+
+func (s *WalletServer) change(req *pb.WalletChangeRequest, amount int64) *pb.WalletChangeResponse {
+	if req.Amount <= 0 {
+		return &pb.WalletChangeResponse{
+			Code:    int32(codes.InvalidArgument.Code()),
+			Message: ErrInvalidAmount.Error(),
+		}
+	}
+
+	transaction, duplicated, err := s.walletManager.Change(req.PlayerId, req.CurrencyType, amount, req.Reason, req.IdempotencyKey, req.Remark)
+	if err != nil {
+		code, message := walletError("change balance", req.PlayerId, err)
+		return &pb.WalletChangeResponse{
+			Code:    code,
+			Message: message,
+		}
+	}
+
+	return &pb.WalletChangeResponse{
+		Code:          int32(codes.OK.Code()),
+		Message:       "操作成功",
+		TransactionId: transaction.ID,
+		Balance:       transaction.Balance,
+		Duplicated:    duplicated,
+	}
+}
+
+// 转换钱包错误为响应码
+func walletError(action, playerID string, err error) (int32, string) {
+	switch {
+	case errors.Is(err, ErrInvalidCurrency), errors.Is(err, ErrInvalidAmount):
+		return int32(codes.InvalidArgument.Code()), err.Error()
+	case errors.Is(err, ErrWalletNotFound):
+		return int32(define.NotFoundUser.Code()), err.Error()
+	case errors.Is(err, ErrInsufficientBalance):
+		return int32(define.InsufficientBalance.Code()), err.Error()
+	case errors.Is(err, ErrIdempotencyConflict):
+		return int32(define.IdempotencyConflict.Code()), err.Error()
+	default:
+		log.Errorf("%s failed: player_id=%s, err=%v", action, playerID, err)
+		return int32(codes.InternalError.Code()), "钱包操作失败"
+	}
+}
+
+// WalletManager 钱包管理器，余额保存在玩家数据中，每次变更追加一条流水
+type WalletManager struct {
+	players      *mongodb.MongoDBClient
+	transactions *mongodb.MongoDBClient
+}
+
+func NewWalletManager(database string) (*WalletManager, error) {
+	players, err := mongodb.NewMongoDBClient(database, playerCollection)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := mongodb.NewMongoDBClient(database, walletTransactionCollection)
+	if err != nil {
+		return nil, err
+	}
+
+	// 按玩家查询流水
+	if err = transactions.EnsureIndex("idx_player_id_create_time", bson.D{{Key: "player_id", Value: 1}, {Key: "create_time", Value: -1}}, false); err != nil {
+		return nil, err
+	}
+
+	return &WalletManager{
+		players:      players,
+		transactions: transactions,
+	}, nil
+}
+
+// GetBalance 查询金币和钻石余额
+func (m *WalletManager) GetBalance(playerID string) (coin, diamond int64, err error) {
+	player := &define.Player{}
+	if err = m.players.FindOne(bson.M{"_id": playerID}, player); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return 0, 0, ErrWalletNotFound
+		}
+		return 0, 0, err
+	}
+
+	return player.Coin, player.Diamond, nil
+}
+
+// Change 变更余额，amount为负数表示扣除：在事务中以余额为条件原子$inc并追加流水，余额不足时返回ErrInsufficientBalance；
+// 指定幂等键时以幂等键作为流水ID，重复请求返回首次的流水且不再变更余额
+func (m *WalletManager) Change(playerID string, currencyType int32, amount int64, reason int32, idempotencyKey, remark string) (*define.WalletTransaction, bool, error) {
+	field, ok := currencyFields[currencyType]
+	if !ok {
+		return nil, false, ErrInvalidCurrency
+	}
+
+	if amount == 0 {
+		return nil, false, ErrInvalidAmount
+	}
+
+	transaction := &define.WalletTransaction{
+		ID:             idempotencyKey,
+		PlayerID:       playerID,
+		CurrencyType:   currencyType,
+		Amount:         amount,
+		Reason:         reason,
+		IdempotencyKey: idempotencyKey,
+		Remark:         remark,
+		CreateTime:     time.Now(),
+	}
+
+	if transaction.ID == "" {
+		transaction.ID = xuuid.UUID()
+	}
+
+	var existing *define.WalletTransaction
+	err := m.transactions.WithTransaction(func(ctx mongo.SessionContext) error {
+		existing = nil
+
+		if idempotencyKey != "" {
+			found := &define.WalletTransaction{}
+			err := m.transactions.GetCollection().FindOne(ctx, bson.M{"_id": idempotencyKey}).Decode(found)
+			if err == nil {
+				existing = found
+				return nil
+			}
+			if !errors.Is(err, mongo.ErrNoDocuments) {
+				return err
+			}
+		}
+
+		// 扣除时要求余额足够
+		filter := bson.M{"_id": playerID}
+		if amount < 0 {
+			filter[field] = bson.M{"$gte": -amount}
+		}
+
+		player := &define.Player{}
+		err := m.players.GetCollection().FindOneAndUpdate(ctx, filter, bson.M{"$inc": bson.M{field: amount}},
+			options.FindOneAndUpdate().SetReturnDocument(options.After).SetProjection(bson.M{field: 1})).Decode(player)
+		if err != nil {
+			if !errors.Is(err, mongo.ErrNoDocuments) {
+				return err
+			}
+
+			count, err := m.players.GetCollection().CountDocuments(ctx, bson.M{"_id": playerID})
+			if err != nil {
+				return err
+			}
+
+			if count == 0 {
+				return ErrWalletNotFound
+			}
+
+			return ErrInsufficientBalance
+		}
+
+		if currencyType == define.CurrencyTypeCoin {
+			transaction.Balance = player.Coin
+		} else {
+			transaction.Balance = player.Diamond
+		}
+
+		_, err = m.transactions.GetCollection().InsertOne(ctx, transaction)
+		return err
+	})
+	if err != nil {
+		// 并发的相同幂等键请求已先提交
+		if idempotencyKey == "" || !mongo.IsDuplicateKeyError(err) {
+			return nil, false, err
+		}
+
+		existing = &define.WalletTransaction{}
+		if err = m.transactions.FindOne(bson.M{"_id": idempotencyKey}, existing); err != nil {
+			return nil, false, err
+		}
+	}
+
+	if existing == nil {
+		return transaction, false, nil
+	}
+
+	if existing.PlayerID != playerID || existing.CurrencyType != currencyType || existing.Amount != amount {
+		return nil, false, ErrIdempotencyConflict
+	}
+
+	return existing, true, nil
+}
+
+// GetTransactions 分页查询流水，按时间倒序；currencyType为0时查询全部货币
+func (m *WalletManager) GetTransactions(playerID string, currencyType int32, page, pageSize int) ([]*define.WalletTransaction, int64, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if pageSize <= 0 {
+		pageSize = defaultTransactionPageSize
+	} else if pageSize > maxTransactionPageSize {
+		pageSize = maxTransactionPageSize
+	}
+
+	filter := bson.M{"player_id": playerID}
+	if currencyType != 0 {
+		filter["currency_type"] = currencyType
+	}
+
+	total, err := m.transactions.CountDocuments(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	transactions := make([]*define.WalletTransaction, 0, pageSize)
+	err = m.transactions.FindSort(filter, &transactions, bson.D{{Key: "create_time", Value: -1}}, int64(pageSize), int64((page-1)*pageSize))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return transactions, total, nil
+}
@@ -3,16 +3,30 @@ package main
 import (
 	"context"
 	server "ghserver/mode/lobby/service"
+	pb "ghserver/proto/pb"
+	"ghserver/utils/session"
+	"ghserver/utils/token"
 
 	"github.com/dobyte/due/locate/redis/v2"
 	"github.com/dobyte/due/registry/etcd/v2"
 	"github.com/dobyte/due/transport/grpc/v2"
 	"github.com/dobyte/due/v2"
 	"github.com/dobyte/due/v2/cluster/node"
+	"github.com/dobyte/due/v2/config"
+	"github.com/dobyte/due/v2/config/file"
 	"github.com/dobyte/due/v2/log"
 	ggrpc "google.golang.org/grpc"
+	gcodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// 仅供服务端及管理后台调用的接口，调用方不携带玩家令牌
+var serverMethods = map[string]bool{
+	pb.MailService_SendMail_FullMethodName:          true,
+	pb.MailService_SendBroadcastMail_FullMethodName: true,
+	pb.ShopService_RefundShopOrder_FullMethodName:   true,
+}
+
 func main() {
 	// 创建容器
 	container := due.NewContainer()
@@ -21,13 +35,18 @@ func main() {
 	// 创建服务发现
 	registry := etcd.NewRegistry()
 	// 创建RPC传输器
-	transporter := grpc.NewTransporter(grpc.WithClientDialOptions(ggrpc.WithChainUnaryInterceptor(clientInterceptor)))
+	transporter := grpc.NewTransporter(
+		grpc.WithClientDialOptions(ggrpc.WithChainUnaryInterceptor(clientInterceptor)),
+		grpc.WithServerOptions(ggrpc.ChainUnaryInterceptor(serverInterceptor)),
+	)
 	// 创建节点组件
 	component := node.NewNode(
 		node.WithLocator(locator),
 		node.WithRegistry(registry),
 		node.WithTransporter(transporter),
 	)
+	// 设置配置中心，加载可热更新的配置表
+	config.SetConfigurator(config.NewConfigurator(config.WithSources(file.NewSource())))
 	// 初始化应用
 	initAPP(component.Proxy())
 	// 添加节点组件
@@ -43,6 +62,31 @@ func clientInterceptor(ctx context.Context, method string, req, reply any, cc *g
 	return invoker(ctx, method, req, reply, cc, opts...)
 }
 
+// 服务端拦截器，玩家调用大厅接口时须携带登录令牌，且只能操作令牌所属玩家的数据；
+// 校验通过后续期登录会话，避免活跃玩家因会话过期被视为离线。服务端及管理后台的调用不续期
+func serverInterceptor(ctx context.Context, req any, info *ggrpc.UnaryServerInfo, handler ggrpc.UnaryHandler) (any, error) {
+	if serverMethods[info.FullMethod] {
+		return handler(ctx, req)
+	}
+
+	claims, err := token.Instance().Verify(token.FromIncomingContext(ctx))
+	if err != nil {
+		log.Debugf("verify token failed: method=%s, err=%v", info.FullMethod, err)
+		return nil, status.Error(gcodes.Unauthenticated, "token无效，请重新登录")
+	}
+
+	if r, ok := req.(interface{ GetPlayerId() string }); ok && r.GetPlayerId() != claims.PlayerID {
+		log.Warnf("player id mismatch: method=%s, player_id=%s, token_player_id=%s", info.FullMethod, r.GetPlayerId(), claims.PlayerID)
+		return nil, status.Error(gcodes.PermissionDenied, "无权操作其他玩家的数据")
+	}
+
+	if _, err = session.Instance().Touch(claims.PlayerID); err != nil {
+		log.Warnf("touch session failed: player_id=%s, err=%v", claims.PlayerID, err)
+	}
+
+	return handler(ctx, req)
+}
+
 // 服务接口，所有服务都需要实现这个接口
 type Service interface {
 	Init()
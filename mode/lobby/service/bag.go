@@ -0,0 +1,36 @@
+package server
+
+import (
+	"time"
+
+	"ghserver/define"
+	"ghserver/utils/mongodb"
+
+	"github.com/dobyte/due/v2/utils/xconv"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// 发放物品，同一物品堆叠到背包中已有的物品上，不存在时新建
+func stackItems(ctx mongo.SessionContext, items *mongodb.MongoDBClient, playerID string, grants []define.ItemInfo, now time.Time) error {
+	for _, item := range grants {
+		_, err := items.GetCollection().UpdateOne(ctx,
+			bson.M{"_id": bagItemID(playerID, item.ItemID)},
+			bson.M{
+				"$inc":         bson.M{"count": item.Count},
+				"$setOnInsert": bson.M{"player_id": playerID, "item_id": item.ItemID, "create_time": now},
+			},
+			options.Update().SetUpsert(true))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// 背包物品ID，同一玩家的同一物品只有一个堆叠
+func bagItemID(playerID string, itemID int) string {
+	return playerID + ":" + xconv.String(itemID)
+}
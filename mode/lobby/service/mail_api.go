@@ -5,11 +5,49 @@ import (
 	"errors"
 	"time"
 
+	"ghserver/define"
 	"ghserver/proto/pb"
+	"ghserver/utils/mongodb"
 
+	"github.com/dobyte/due/v2/cluster"
 	"github.com/dobyte/due/v2/cluster/node"
 	"github.com/dobyte/due/v2/codes"
+	"github.com/dobyte/due/v2/etc"
 	"github.com/dobyte/due/v2/log"
+	"github.com/dobyte/due/v2/session"
+	"github.com/dobyte/due/v2/utils/xconv"
+	"github.com/dobyte/due/v2/utils/xuuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	mailCollection          = "mail"              // 邮件集合
+	broadcastMailCollection = "broadcast_mail"    // 全服邮件集合
+	mailSyncCollection      = "mail_sync"         // 玩家全服邮件同步记录集合
+	playerCollection        = "player"            // 玩家集合
+	itemCollection          = "item"              // 背包物品集合
+	defaultPageSize         = 20                  // 默认每页数量
+	maxPageSize             = 100                 // 最大每页数量
+	maxMailReceivers        = 1000                // 单次发送邮件的最大收件人数
+	defaultMailExpire       = 30 * 24 * time.Hour // 默认邮件有效期
+)
+
+// 附件类型
+const (
+	AttachmentTypeItem    = 1 // 物品
+	AttachmentTypeCoin    = 2 // 金币
+	AttachmentTypeDiamond = 3 // 钻石
+)
+
+var (
+	ErrMailNotFound      = errors.New("邮件不存在")
+	ErrMailExpired       = errors.New("邮件已过期")
+	ErrAttachmentClaimed = errors.New("附件已领取")
+	ErrNoAttachment      = errors.New("邮件没有附件")
+	ErrInvalidMail       = errors.New("邮件参数错误")
+	ErrPlayerNotFound    = errors.New("玩家不存在")
 )
 
 // MailServer 邮箱服务
@@ -21,12 +59,18 @@ type MailServer struct {
 
 func NewMailServer(proxy *node.Proxy) *MailServer {
 	return &MailServer{
-		proxy:       proxy,
-		mailManager: NewMailManager(),
+		proxy: proxy,
 	}
 }
 
 func (s *MailServer) Init() {
+	// 创建邮件管理器
+	mailManager, err := NewMailManager(etc.Get("etc.mongo.default.database", "game").String(), NewWalletClient(s.proxy))
+	if err != nil {
+		log.Fatalf("create mail manager failed: %v", err)
+	}
+	s.mailManager = mailManager
+
 	s.proxy.AddServiceProvider("mail", &pb.MailService_ServiceDesc, s)
 }
 
@@ -39,7 +83,14 @@ func (s *MailServer) GetMailList(ctx context.Context, req *pb.GetMailListRequest
 	log.Debugf("Get mail list request: player_id=%s, page=%d, page_size=%d", req.PlayerId, req.Page, req.PageSize)
 
 	// 获取邮件列表
-	mails, total := s.mailManager.GetMailList(req.PlayerId, int(req.Page), int(req.PageSize))
+	mails, total, err := s.mailManager.GetMailList(req.PlayerId, int(req.Page), int(req.PageSize))
+	if err != nil {
+		log.Errorf("get mail list failed: player_id=%s, err=%v", req.PlayerId, err)
+		return &pb.GetMailListResponse{
+			Code:    int32(codes.InternalError.Code()),
+			Message: "获取邮件列表失败",
+		}, nil
+	}
 
 	// 转换为响应格式
 	mailBriefs := make([]*pb.MailBrief, len(mails))
@@ -47,10 +98,10 @@ func (s *MailServer) GetMailList(ctx context.Context, req *pb.GetMailListRequest
 		mailBriefs[i] = &pb.MailBrief{
 			MailId:        mail.ID,
 			Title:         mail.Title,
-			SendTime:      mail.SendTime,
-			HasAttachment: len(mail.Attachments) > 0,
+			SendTime:      mail.SendTime.Unix(),
+			HasAttachment: hasAttachment(mail),
 			IsRead:        mail.IsRead,
-			IsReceived:    mail.IsReceived,
+			IsReceived:    mail.IsClaimed,
 		}
 	}
 
@@ -65,27 +116,21 @@ func (s *MailServer) GetMailList(ctx context.Context, req *pb.GetMailListRequest
 func (s *MailServer) GetMailDetail(ctx context.Context, req *pb.GetMailDetailRequest) (*pb.GetMailDetailResponse, error) {
 	log.Debugf("Get mail detail request: player_id=%s, mail_id=%s", req.PlayerId, req.MailId)
 
-	// 获取邮件详情
-	mail, err := s.mailManager.GetMailDetail(req.PlayerId, req.MailId)
+	// 获取邮件详情并标记为已读
+	mail, err := s.mailManager.ReadMail(req.PlayerId, req.MailId)
 	if err != nil {
-		return &pb.GetMailDetailResponse{
-			Code:    int32(codes.InvalidArgument.Code()),
+		if errors.Is(err, ErrMailNotFound) {
+			return &pb.GetMailDetailResponse{
+				Code:    int32(codes.InvalidArgument.Code()),
 				Message: err.Error(),
-			},
-			nil
-	}
-
-	// 标记为已读
-	s.mailManager.MarkAsRead(req.PlayerId, req.MailId)
-
-	// 转换附件
-	attachments := make([]*pb.MailAttachment, len(mail.Attachments))
-	for i, attach := range mail.Attachments {
-		attachments[i] = &pb.MailAttachment{
-			Type:   int32(attach.Type),
-			ItemId: int32(attach.ItemID),
-			Count:  int32(attach.Count),
+			}, nil
 		}
+
+		log.Errorf("get mail detail failed: player_id=%s, mail_id=%s, err=%v", req.PlayerId, req.MailId, err)
+		return &pb.GetMailDetailResponse{
+			Code:    int32(codes.InternalError.Code()),
+			Message: "获取邮件详情失败",
+		}, nil
 	}
 
 	return &pb.GetMailDetailResponse{
@@ -95,11 +140,11 @@ func (s *MailServer) GetMailDetail(ctx context.Context, req *pb.GetMailDetailReq
 			Id:          mail.ID,
 			Title:       mail.Title,
 			Content:     mail.Content,
-			SendTime:    mail.SendTime,
-			ExpireTime:  mail.ExpireTime,
+			SendTime:    mail.SendTime.Unix(),
+			ExpireTime:  mail.ExpireTime.Unix(),
 			IsRead:      mail.IsRead,
-			IsReceived:  mail.IsReceived,
-			Attachments: attachments,
+			IsReceived:  mail.IsClaimed,
+			Attachments: toMailAttachments(mail),
 		},
 	}, nil
 }
@@ -108,29 +153,73 @@ func (s *MailServer) ReceiveMailAttachment(ctx context.Context, req *pb.ReceiveM
 	log.Debugf("Receive mail attachment request: player_id=%s, mail_id=%s", req.PlayerId, req.MailId)
 
 	// 领取附件
-	attachments, err := s.mailManager.ReceiveAttachment(req.PlayerId, req.MailId)
+	mail, err := s.mailManager.ReceiveAttachment(req.PlayerId, req.MailId)
 	if err != nil {
-		return &pb.ReceiveMailAttachmentResponse{
-			Code:    int32(codes.InvalidArgument.Code()),
+		switch {
+		case errors.Is(err, ErrMailNotFound), errors.Is(err, ErrMailExpired),
+			errors.Is(err, ErrAttachmentClaimed), errors.Is(err, ErrNoAttachment), errors.Is(err, ErrPlayerNotFound):
+			return &pb.ReceiveMailAttachmentResponse{
+				Code:    int32(codes.InvalidArgument.Code()),
 				Message: err.Error(),
-			},
-			nil
+			}, nil
+		}
+
+		log.Errorf("receive mail attachment failed: player_id=%s, mail_id=%s, err=%v", req.PlayerId, req.MailId, err)
+		return &pb.ReceiveMailAttachmentResponse{
+			Code:    int32(codes.InternalError.Code()),
+			Message: "领取附件失败",
+		}, nil
 	}
 
-	// 转换附件格式
-	mailAttachments := make([]*pb.MailAttachment, len(attachments))
-	for i, attach := range attachments {
-		mailAttachments[i] = &pb.MailAttachment{
-			Type:   int32(attach.Type),
-			ItemId: int32(attach.ItemID),
-			Count:  int32(attach.Count),
+	return &pb.ReceiveMailAttachmentResponse{
+		Code:        int32(codes.OK.Code()),
+		Message:     "领取附件成功",
+		Attachments: toMailAttachments(mail),
+	}, nil
+}
+
+func (s *MailServer) ReceiveAllMailAttachments(ctx context.Context, req *pb.ReceiveAllMailAttachmentsRequest) (*pb.ReceiveAllMailAttachmentsResponse, error) {
+	log.Debugf("Receive all mail attachments request: player_id=%s", req.PlayerId)
+
+	// 一键领取附件
+	mails, err := s.mailManager.ReceiveAllAttachments(req.PlayerId)
+	if err != nil {
+		if errors.Is(err, ErrPlayerNotFound) {
+			return &pb.ReceiveAllMailAttachmentsResponse{
+				Code:    int32(codes.InvalidArgument.Code()),
+				Message: err.Error(),
+			}, nil
+		}
+
+		log.Errorf("receive all mail attachments failed: player_id=%s, err=%v", req.PlayerId, err)
+		if len(mails) == 0 {
+			return &pb.ReceiveAllMailAttachmentsResponse{
+				Code:    int32(codes.InternalError.Code()),
+				Message: "领取附件失败",
+			}, nil
 		}
+
+		// 部分邮件已领取并入账，需要告知客户端
+		return &pb.ReceiveAllMailAttachmentsResponse{
+			Code:        int32(codes.InternalError.Code()),
+			Message:     "部分附件领取失败",
+			MailIds:     mailIDsOf(mails),
+			Attachments: toMailAttachments(mergeMails(mails)),
+		}, nil
 	}
 
-	return &pb.ReceiveMailAttachmentResponse{
+	if len(mails) == 0 {
+		return &pb.ReceiveAllMailAttachmentsResponse{
+			Code:    int32(codes.InvalidArgument.Code()),
+			Message: "没有可领取的附件",
+		}, nil
+	}
+
+	return &pb.ReceiveAllMailAttachmentsResponse{
 		Code:        int32(codes.OK.Code()),
 		Message:     "领取附件成功",
-		Attachments: mailAttachments,
+		MailIds:     mailIDsOf(mails),
+		Attachments: toMailAttachments(mergeMails(mails)),
 	}, nil
 }
 
@@ -140,11 +229,11 @@ func (s *MailServer) DeleteMail(ctx context.Context, req *pb.DeleteMailRequest)
 	// 删除邮件
 	err := s.mailManager.DeleteMail(req.PlayerId, req.MailIds)
 	if err != nil {
+		log.Errorf("delete mail failed: player_id=%s, err=%v", req.PlayerId, err)
 		return &pb.CommonResponse{
-			Code:    int32(codes.InvalidArgument.Code()),
-				Message: err.Error(),
-			},
-			nil
+			Code:    int32(codes.InternalError.Code()),
+			Message: "删除邮件失败",
+		}, nil
 	}
 
 	return &pb.CommonResponse{
@@ -153,164 +242,623 @@ func (s *MailServer) DeleteMail(ctx context.Context, req *pb.DeleteMailRequest)
 	}, nil
 }
 
-// Mail 邮件结构
-type Mail struct {
-	ID          string
-	Title       string
-	Content     string
-	SendTime    int64
-	ExpireTime  int64
-	IsRead      bool
-	IsReceived  bool
-	Attachments []MailAttachment
+func (s *MailServer) SendMail(ctx context.Context, req *pb.SendMailRequest) (*pb.SendMailResponse, error) {
+	log.Debugf("Send mail request: player_ids=%v, title=%s", req.PlayerIds, req.Title)
+
+	if len(req.PlayerIds) == 0 || len(req.PlayerIds) > maxMailReceivers {
+		return &pb.SendMailResponse{
+			Code:    int32(codes.InvalidArgument.Code()),
+			Message: "收件人数量错误",
+		}, nil
+	}
+
+	mail, err := newMail(req.Title, req.Content, req.Attachments, req.ExpireTime)
+	if err != nil {
+		return &pb.SendMailResponse{
+			Code:    int32(codes.InvalidArgument.Code()),
+			Message: err.Error(),
+		}, nil
+	}
+
+	// 发送邮件
+	mailIDs, err := s.mailManager.SendMail(req.PlayerIds, mail, req.IdempotencyKey)
+	if err != nil {
+		log.Errorf("send mail failed: title=%s, err=%v", req.Title, err)
+		return &pb.SendMailResponse{
+			Code:    int32(codes.InternalError.Code()),
+			Message: "发送邮件失败",
+		}, nil
+	}
+
+	log.Infof("Mail %s sent to %d players", req.Title, len(mailIDs))
+
+	s.notifyPlayers(req.PlayerIds, mail.Title)
+
+	return &pb.SendMailResponse{
+		Code:    int32(codes.OK.Code()),
+		Message: "发送邮件成功",
+		MailIds: mailIDs,
+	}, nil
+}
+
+func (s *MailServer) SendBroadcastMail(ctx context.Context, req *pb.SendBroadcastMailRequest) (*pb.SendMailResponse, error) {
+	log.Debugf("Send broadcast mail request: title=%s, level=[%d,%d]", req.Title, req.MinLevel, req.MaxLevel)
+
+	if req.MaxLevel > 0 && req.MinLevel > req.MaxLevel {
+		return &pb.SendMailResponse{
+			Code:    int32(codes.InvalidArgument.Code()),
+			Message: "等级条件错误",
+		}, nil
+	}
+
+	mail, err := newMail(req.Title, req.Content, req.Attachments, req.ExpireTime)
+	if err != nil {
+		return &pb.SendMailResponse{
+			Code:    int32(codes.InvalidArgument.Code()),
+			Message: err.Error(),
+		}, nil
+	}
+
+	broadcast := &define.BroadcastMail{
+		ID:         xuuid.UUID(),
+		Title:      mail.Title,
+		Content:    mail.Content,
+		Items:      mail.Items,
+		Coin:       mail.Coin,
+		Diamond:    mail.Diamond,
+		MinLevel:   int(req.MinLevel),
+		MaxLevel:   int(req.MaxLevel),
+		SendTime:   mail.SendTime,
+		ExpireTime: mail.ExpireTime,
+	}
+	if req.RegisterAfter > 0 {
+		broadcast.RegisterAfter = time.Unix(req.RegisterAfter, 0)
+	}
+	if req.RegisterBefore > 0 {
+		broadcast.RegisterBefore = time.Unix(req.RegisterBefore, 0)
+	}
+
+	// 发送全服邮件
+	if err = s.mailManager.SendBroadcast(broadcast); err != nil {
+		log.Errorf("send broadcast mail failed: title=%s, err=%v", req.Title, err)
+		return &pb.SendMailResponse{
+			Code:    int32(codes.InternalError.Code()),
+			Message: "发送全服邮件失败",
+		}, nil
+	}
+
+	log.Infof("Broadcast mail %s sent, broadcast_id=%s", req.Title, broadcast.ID)
+
+	s.notifyAll(broadcast.Title)
+
+	return &pb.SendMailResponse{
+		Code:    int32(codes.OK.Code()),
+		Message: "发送全服邮件成功",
+		MailIds: []string{broadcast.ID},
+	}, nil
 }
 
-// MailAttachment 邮件附件
-type MailAttachment struct {
-	Type   int
-	ItemID int
-	Count  int
+func (s *MailServer) GetMailBadge(ctx context.Context, req *pb.GetMailBadgeRequest) (*pb.GetMailBadgeResponse, error) {
+	log.Debugf("Get mail badge request: player_id=%s", req.PlayerId)
+
+	// 统计未读及未领取邮件
+	unread, unclaimed, err := s.mailManager.CountBadge(req.PlayerId)
+	if err != nil {
+		log.Errorf("get mail badge failed: player_id=%s, err=%v", req.PlayerId, err)
+		return &pb.GetMailBadgeResponse{
+			Code:    int32(codes.InternalError.Code()),
+			Message: "获取邮件红点失败",
+		}, nil
+	}
+
+	return &pb.GetMailBadgeResponse{
+		Code:      int32(codes.OK.Code()),
+		Message:   "获取邮件红点成功",
+		Unread:    int32(unread),
+		Unclaimed: int32(unclaimed),
+	}, nil
 }
 
-// MailManager 邮件管理器
-type MailManager struct {
-	mails map[string]map[string]*Mail // player_id -> mail_id -> Mail
+// 通知在线收件人有新邮件，不在线的玩家由网关忽略
+func (s *MailServer) notifyPlayers(playerIDs []string, title string) {
+	uids := make([]int64, 0, len(playerIDs))
+	for _, playerID := range playerIDs {
+		if uid := xconv.Int64(playerID); uid > 0 {
+			uids = append(uids, uid)
+		}
+	}
+
+	if len(uids) == 0 {
+		return
+	}
+
+	err := s.proxy.Multicast(context.Background(), &cluster.MulticastArgs{
+		Kind:    session.User,
+		Targets: uids,
+		Message: &cluster.Message{
+			Route: define.RouteNewMail,
+			Data:  &pb.NewMailNotify{Title: title},
+		},
+	})
+	if err != nil {
+		log.Warnf("push new mail notify failed: title=%s, err=%v", title, err)
+	}
 }
 
-func NewMailManager() *MailManager {
-	return &MailManager{
-		mails: make(map[string]map[string]*Mail),
+// 通知所有在线玩家有新的全服邮件，是否满足收件条件在玩家拉取时判定
+func (s *MailServer) notifyAll(title string) {
+	err := s.proxy.Broadcast(context.Background(), &cluster.BroadcastArgs{
+		Kind: session.User,
+		Message: &cluster.Message{
+			Route: define.RouteNewMail,
+			Data:  &pb.NewMailNotify{Title: title},
+		},
+	})
+	if err != nil {
+		log.Warnf("broadcast new mail notify failed: title=%s, err=%v", title, err)
 	}
 }
 
-func (m *MailManager) GetMailList(playerID string, page, pageSize int) ([]*Mail, int) {
-	playerMails, exists := m.mails[playerID]
-	if !exists {
-		// 为新玩家生成一些示例邮件
-		m.generateExampleMails(playerID)
-		playerMails = m.mails[playerID]
+// 根据请求参数创建邮件模板
+func newMail(title, content string, attachments []*pb.MailAttachment, expireTime int64) (*define.Mail, error) {
+	if title == "" {
+		return nil, ErrInvalidMail
+	}
+
+	now := time.Now()
+	mail := &define.Mail{
+		Title:      title,
+		Content:    content,
+		Items:      make([]define.ItemInfo, 0, len(attachments)),
+		SendTime:   now,
+		ExpireTime: now.Add(defaultMailExpire),
 	}
 
-	// 过滤过期邮件
-	validMails := make([]*Mail, 0)
-	now := time.Now().Unix()
-	for _, mail := range playerMails {
-		if mail.ExpireTime > now {
-			validMails = append(validMails, mail)
+	if expireTime > 0 {
+		mail.ExpireTime = time.Unix(expireTime, 0)
+		if !mail.ExpireTime.After(now) {
+			return nil, ErrMailExpired
 		}
 	}
 
-	// 分页
-	start := (page - 1) * pageSize
-	end := start + pageSize
-	if start > len(validMails) {
-		return []*Mail{}, len(validMails)
+	for _, attachment := range attachments {
+		if attachment.Count <= 0 {
+			return nil, ErrInvalidMail
+		}
+
+		switch attachment.Type {
+		case AttachmentTypeItem:
+			if attachment.ItemId <= 0 {
+				return nil, ErrInvalidMail
+			}
+			mail.Items = append(mail.Items, define.ItemInfo{ItemID: int(attachment.ItemId), Count: int(attachment.Count)})
+		case AttachmentTypeCoin:
+			mail.Coin += int64(attachment.Count)
+		case AttachmentTypeDiamond:
+			mail.Diamond += int64(attachment.Count)
+		default:
+			return nil, ErrInvalidMail
+		}
+	}
+
+	return mail, nil
+}
+
+// 邮件是否带有附件
+func hasAttachment(mail *define.Mail) bool {
+	return len(mail.Items) > 0 || mail.Coin > 0 || mail.Diamond > 0
+}
+
+// 合并多封邮件的附件，相同物品累加数量
+func mergeMails(mails []*define.Mail) *define.Mail {
+	merged := &define.Mail{Items: make([]define.ItemInfo, 0)}
+	index := make(map[int]int)
+	for _, mail := range mails {
+		for _, item := range mail.Items {
+			if i, ok := index[item.ItemID]; ok {
+				merged.Items[i].Count += item.Count
+				continue
+			}
+			index[item.ItemID] = len(merged.Items)
+			merged.Items = append(merged.Items, item)
+		}
+		merged.Coin += mail.Coin
+		merged.Diamond += mail.Diamond
 	}
-	if end > len(validMails) {
-		end = len(validMails)
+
+	return merged
+}
+
+// 提取邮件ID
+func mailIDsOf(mails []*define.Mail) []string {
+	mailIDs := make([]string, len(mails))
+	for i, mail := range mails {
+		mailIDs[i] = mail.ID
 	}
 
-	return validMails[start:end], len(validMails)
+	return mailIDs
 }
 
-func (m *MailManager) GetMailDetail(playerID, mailID string) (*Mail, error) {
-	playerMails, exists := m.mails[playerID]
-	if !exists {
-		return nil, errors.New("玩家不存在")
+// 转换邮件附件
+func toMailAttachments(mail *define.Mail) []*pb.MailAttachment {
+	attachments := make([]*pb.MailAttachment, 0, len(mail.Items)+2)
+	for _, item := range mail.Items {
+		attachments = append(attachments, &pb.MailAttachment{
+			Type:   AttachmentTypeItem,
+			ItemId: int32(item.ItemID),
+			Count:  int32(item.Count),
+		})
 	}
 
-	mail, exists := playerMails[mailID]
-	if !exists {
-		return nil, errors.New("邮件不存在")
+	if mail.Coin > 0 {
+		attachments = append(attachments, &pb.MailAttachment{
+			Type:  AttachmentTypeCoin,
+			Count: int32(mail.Coin),
+		})
 	}
 
-	if mail.ExpireTime < time.Now().Unix() {
-		return nil, errors.New("邮件已过期")
+	if mail.Diamond > 0 {
+		attachments = append(attachments, &pb.MailAttachment{
+			Type:  AttachmentTypeDiamond,
+			Count: int32(mail.Diamond),
+		})
 	}
 
-	return mail, nil
+	return attachments
+}
+
+// MailManager 邮件管理器
+type MailManager struct {
+	mails       *mongodb.MongoDBClient
+	broadcasts  *mongodb.MongoDBClient
+	syncs       *mongodb.MongoDBClient
+	players     *mongodb.MongoDBClient
+	attachments Attachments
+	wallet      Wallet
+}
+
+func NewMailManager(database string, wallet Wallet) (*MailManager, error) {
+	mails, err := mongodb.NewMongoDBClient(database, mailCollection)
+	if err != nil {
+		return nil, err
+	}
+
+	broadcasts, err := mongodb.NewMongoDBClient(database, broadcastMailCollection)
+	if err != nil {
+		return nil, err
+	}
+
+	syncs, err := mongodb.NewMongoDBClient(database, mailSyncCollection)
+	if err != nil {
+		return nil, err
+	}
+
+	players, err := mongodb.NewMongoDBClient(database, playerCollection)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := mongodb.NewMongoDBClient(database, itemCollection)
+	if err != nil {
+		return nil, err
+	}
+
+	// 按玩家分页查询的索引
+	if err = mails.EnsureIndex("idx_player_send_time", bson.D{{Key: "player_id", Value: 1}, {Key: "send_time", Value: -1}}, false); err != nil {
+		return nil, err
+	}
+
+	// 过期邮件自动清理
+	if err = mails.EnsureTTLIndex("ttl_expire_time", "expire_time", 0); err != nil {
+		return nil, err
+	}
+
+	if err = broadcasts.EnsureTTLIndex("ttl_expire_time", "expire_time", 0); err != nil {
+		return nil, err
+	}
+
+	return &MailManager{
+		mails:       mails,
+		broadcasts:  broadcasts,
+		syncs:       syncs,
+		players:     players,
+		attachments: NewAttachmentStore(mails, items),
+		wallet:      wallet,
+	}, nil
 }
 
-func (m *MailManager) MarkAsRead(playerID, mailID string) {
-	if playerMails, exists := m.mails[playerID]; exists {
-		if mail, exists := playerMails[mailID]; exists {
-			mail.IsRead = true
+// GetMailList 按发送时间倒序分页获取未过期的邮件
+func (m *MailManager) GetMailList(playerID string, page, pageSize int) ([]*define.Mail, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	// 生成尚未收取的全服邮件
+	if err := m.syncBroadcasts(playerID); err != nil {
+		return nil, 0, err
+	}
+
+	filter := validFilter(playerID)
+
+	total, err := m.mails.CountDocuments(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	mails := make([]*define.Mail, 0, pageSize)
+	sort := bson.D{{Key: "send_time", Value: -1}, {Key: "_id", Value: -1}}
+	if err = m.mails.FindSort(filter, &mails, sort, int64(pageSize), int64((page-1)*pageSize)); err != nil {
+		return nil, 0, err
+	}
+
+	return mails, total, nil
+}
+
+// ReadMail 获取邮件详情并标记为已读
+func (m *MailManager) ReadMail(playerID, mailID string) (*define.Mail, error) {
+	filter := validFilter(playerID)
+	filter["_id"] = mailID
+
+	mail := &define.Mail{}
+	err := m.mails.FindOneAndUpdate(filter, bson.M{"$set": bson.M{"is_read": true}}, mail,
+		options.FindOneAndUpdate().SetReturnDocument(options.After))
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrMailNotFound
 		}
+		return nil, err
+	}
+
+	return mail, nil
+}
+
+// CountBadge 统计未读邮件及未领取附件的邮件数量
+func (m *MailManager) CountBadge(playerID string) (unread, unclaimed int64, err error) {
+	// 生成尚未收取的全服邮件
+	if err = m.syncBroadcasts(playerID); err != nil {
+		return 0, 0, err
+	}
+
+	filter := validFilter(playerID)
+	filter["is_read"] = false
+	if unread, err = m.mails.CountDocuments(filter); err != nil {
+		return 0, 0, err
+	}
+
+	filter = validFilter(playerID)
+	filter["is_claimed"] = false
+	filter["$or"] = attachmentFilter()
+	if unclaimed, err = m.mails.CountDocuments(filter); err != nil {
+		return 0, 0, err
 	}
+
+	return unread, unclaimed, nil
 }
 
-func (m *MailManager) ReceiveAttachment(playerID, mailID string) ([]MailAttachment, error) {
-	mail, err := m.GetMailDetail(playerID, mailID)
+// ReceiveAttachment 领取附件：先以邮件ID为幂等键将货币计入钱包，再标记邮件已领取并发放物品，
+// 并发或重试领取同一封邮件时只有一个请求成功，货币也只发放一次
+func (m *MailManager) ReceiveAttachment(playerID, mailID string) (*define.Mail, error) {
+	mail, err := m.attachments.Find(playerID, mailID)
 	if err != nil {
 		return nil, err
 	}
 
-	if mail.IsReceived {
-		return nil, errors.New("附件已领取")
+	if err = m.credit(playerID, mail); err != nil {
+		return nil, err
+	}
+
+	return m.attachments.Claim(playerID, mailID)
+}
+
+// ReceiveAllAttachments 一键领取附件，每封邮件单独领取，返回领取成功的邮件；
+// 中途失败时同时返回已领取的邮件和错误
+func (m *MailManager) ReceiveAllAttachments(playerID string) ([]*define.Mail, error) {
+	filter := validFilter(playerID)
+	filter["is_claimed"] = false
+	filter["$or"] = attachmentFilter()
+
+	mails := make([]*define.Mail, 0)
+	if err := m.mails.FindSort(filter, &mails, bson.D{{Key: "send_time", Value: 1}}, maxPageSize, 0); err != nil {
+		return nil, err
+	}
+
+	claimed := make([]*define.Mail, 0, len(mails))
+	for _, mail := range mails {
+		received, err := m.ReceiveAttachment(playerID, mail.ID)
+		if err != nil {
+			// 已被其他请求领取或邮件刚好过期
+			if errors.Is(err, ErrAttachmentClaimed) || errors.Is(err, ErrMailExpired) || errors.Is(err, ErrMailNotFound) {
+				continue
+			}
+			if len(claimed) > 0 {
+				log.Warnf("receive all mail attachments interrupted: player_id=%s, claimed=%d", playerID, len(claimed))
+			}
+			return claimed, err
+		}
+		claimed = append(claimed, received)
 	}
 
-	if len(mail.Attachments) == 0 {
-		return nil, errors.New("邮件没有附件")
+	return claimed, nil
+}
+
+// 通过钱包发放邮件中的金币和钻石，幂等键由邮件ID生成
+func (m *MailManager) credit(playerID string, mail *define.Mail) error {
+	if mail.Coin > 0 {
+		if _, err := m.wallet.Credit(playerID, define.CurrencyTypeCoin, mail.Coin, define.WalletReasonMail,
+			"mail:"+mail.ID+":coin", mail.Title); err != nil {
+			return err
+		}
 	}
 
-	// 标记为已领取
-	mail.IsReceived = true
+	if mail.Diamond > 0 {
+		if _, err := m.wallet.Credit(playerID, define.CurrencyTypeDiamond, mail.Diamond, define.WalletReasonMail,
+			"mail:"+mail.ID+":diamond", mail.Title); err != nil {
+			return err
+		}
+	}
 
-	return mail.Attachments, nil
+	return nil
 }
 
+// DeleteMail 删除邮件
 func (m *MailManager) DeleteMail(playerID string, mailIDs []string) error {
-	playerMails, exists := m.mails[playerID]
-	if !exists {
-		return errors.New("玩家不存在")
+	if len(mailIDs) == 0 {
+		return nil
+	}
+
+	_, err := m.mails.DeleteMany(bson.M{"player_id": playerID, "_id": bson.M{"$in": mailIDs}})
+	return err
+}
+
+// SendMail 按模板向多个玩家发送个人邮件；指定幂等键时重复发送只保留一封
+func (m *MailManager) SendMail(playerIDs []string, template *define.Mail, idempotencyKey string) ([]string, error) {
+	mailIDs := make([]string, 0, len(playerIDs))
+	documents := make([]interface{}, 0, len(playerIDs))
+	for _, playerID := range playerIDs {
+		mail := *template
+		mail.PlayerID = playerID
+		if idempotencyKey != "" {
+			mail.ID = idempotencyKey + ":" + playerID
+		} else {
+			mail.ID = xuuid.UUID()
+		}
+		mailIDs = append(mailIDs, mail.ID)
+		documents = append(documents, &mail)
 	}
 
-	for _, mailID := range mailIDs {
-		delete(playerMails, mailID)
+	if idempotencyKey != "" {
+		if err := m.mails.InsertManyIgnoreDuplicate(documents); err != nil {
+			return nil, err
+		}
+		return mailIDs, nil
 	}
 
-	return nil
+	if _, err := m.mails.InsertMany(documents); err != nil {
+		return nil, err
+	}
+
+	return mailIDs, nil
 }
 
-func (m *MailManager) generateExampleMails(playerID string) {
-	now := time.Now().Unix()
-	weekLater := now + 7*24*3600
-
-	m.mails[playerID] = map[string]*Mail{
-		"1": {
-			ID:         "1",
-			Title:      "欢迎加入游戏",
-			Content:    "亲爱的玩家，欢迎加入我们的游戏！这里是你的新手礼包，请查收。",
-			SendTime:   now,
-			ExpireTime: weekLater,
-			IsRead:     false,
-			IsReceived: false,
-			Attachments: []MailAttachment{
-				{Type: 1, ItemID: 1001, Count: 10}, // 金币
-				{Type: 2, ItemID: 2001, Count: 1},  // 装备
-			},
-		},
-		"2": {
-			ID:         "2",
-			Title:      "每日签到奖励",
-			Content:    "恭喜你完成每日签到，这是你的奖励。",
-			SendTime:   now - 86400,
-			ExpireTime: weekLater,
-			IsRead:     false,
-			IsReceived: false,
-			Attachments: []MailAttachment{
-				{Type: 1, ItemID: 1002, Count: 5}, // 钻石
-			},
-		},
-		"3": {
-			ID:          "3",
-			Title:       "系统通知",
-			Content:     "系统将于明天凌晨2点进行维护，请提前下线。",
-			SendTime:    now - 172800,
-			ExpireTime:  now + 86400,
-			IsRead:      false,
-			IsReceived:  true,
-			Attachments: []MailAttachment{},
-		},
+// SendBroadcast 发送全服邮件，只存储一份，玩家拉取邮件时再生成个人邮件
+func (m *MailManager) SendBroadcast(broadcast *define.BroadcastMail) error {
+	_, err := m.broadcasts.InsertOne(broadcast)
+	return err
+}
+
+// 为玩家生成尚未收取的全服邮件；收件条件在玩家发送后首次拉取邮件时判定，每封全服邮件只判定一次
+func (m *MailManager) syncBroadcasts(playerID string) error {
+	now := time.Now()
+
+	broadcasts := make([]*define.BroadcastMail, 0)
+	if err := m.broadcasts.Find(bson.M{"expire_time": bson.M{"$gt": now}}, &broadcasts, 0, 0); err != nil {
+		return err
+	}
+
+	if len(broadcasts) == 0 {
+		return nil
+	}
+
+	record := struct {
+		BroadcastIDs []string `bson:"broadcast_ids"`
+	}{}
+	if err := m.syncs.FindOne(bson.M{"_id": playerID}, &record); err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return err
+	}
+
+	received := make(map[string]bool, len(record.BroadcastIDs))
+	for _, id := range record.BroadcastIDs {
+		received[id] = true
 	}
+
+	pending := make([]*define.BroadcastMail, 0)
+	activeIDs := make([]string, 0, len(broadcasts))
+	for _, broadcast := range broadcasts {
+		activeIDs = append(activeIDs, broadcast.ID)
+		if !received[broadcast.ID] {
+			pending = append(pending, broadcast)
+		}
+	}
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	player := &define.Player{}
+	if err := m.players.FindOne(bson.M{"_id": playerID}, player); err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return err
+	}
+
+	for _, broadcast := range pending {
+		if !matchBroadcast(broadcast, player) {
+			continue
+		}
+
+		// 个人邮件ID由全服邮件ID和玩家ID确定，并发生成时只会成功一次
+		_, err := m.mails.InsertOne(&define.Mail{
+			ID:          broadcast.ID + ":" + playerID,
+			PlayerID:    playerID,
+			Title:       broadcast.Title,
+			Content:     broadcast.Content,
+			Items:       broadcast.Items,
+			Coin:        broadcast.Coin,
+			Diamond:     broadcast.Diamond,
+			SendTime:    broadcast.SendTime,
+			ExpireTime:  broadcast.ExpireTime,
+			BroadcastID: broadcast.ID,
+		})
+		if err != nil && !mongo.IsDuplicateKeyError(err) {
+			return err
+		}
+	}
+
+	// 记录已判定的全服邮件，同时清除已过期的记录
+	return m.syncs.UpdateOne(bson.M{"_id": playerID}, bson.M{"$set": bson.M{"broadcast_ids": activeIDs}},
+		options.Update().SetUpsert(true))
+}
+
+// 玩家是否满足全服邮件的收件条件，玩家数据不存在时只判定无条件的全服邮件；
+// 全服邮件只发给发送时已注册的玩家，之后注册的玩家不会收到
+func matchBroadcast(broadcast *define.BroadcastMail, player *define.Player) bool {
+	if player.CreateTime.After(broadcast.SendTime) {
+		return false
+	}
+
+	if broadcast.MinLevel > 0 && player.Level < broadcast.MinLevel {
+		return false
+	}
+
+	if broadcast.MaxLevel > 0 && player.Level > broadcast.MaxLevel {
+		return false
+	}
+
+	if !broadcast.RegisterAfter.IsZero() && player.CreateTime.Before(broadcast.RegisterAfter) {
+		return false
+	}
+
+	if !broadcast.RegisterBefore.IsZero() && player.CreateTime.After(broadcast.RegisterBefore) {
+		return false
+	}
+
+	return true
+}
+
+// 带有附件的查询条件
+func attachmentFilter() bson.A {
+	return bson.A{
+		bson.M{"items.0": bson.M{"$exists": true}},
+		bson.M{"coin": bson.M{"$gt": 0}},
+		bson.M{"diamond": bson.M{"$gt": 0}},
+	}
+}
+
+// 未过期邮件的查询条件，TTL索引的清理存在延迟，查询时需过滤
+func validFilter(playerID string) bson.M {
+	return bson.M{"player_id": playerID, "expire_time": bson.M{"$gt": time.Now()}}
 }
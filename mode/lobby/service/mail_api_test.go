@@ -0,0 +1,185 @@
+package server
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"ghserver/define"
+)
+
+func TestMailManagerConcurrentReceiveAttachment(t *testing.T) {
+	const (
+		playerID = "300000"
+		mailID   = "mail-1"
+		coin     = 500
+		count    = 3
+		workers  = 32
+	)
+
+	now := time.Now()
+	attachments := newFakeAttachments(&define.Mail{
+		ID:         mailID,
+		PlayerID:   playerID,
+		Title:      "test",
+		Items:      []define.ItemInfo{{ItemID: 1001, Count: count}},
+		Coin:       coin,
+		SendTime:   now,
+		ExpireTime: now.Add(time.Hour),
+	})
+	wallet := newFakeWallet(0)
+	m := &MailManager{attachments: attachments, wallet: wallet}
+
+	var (
+		wg        sync.WaitGroup
+		succeeded atomic.Int32
+	)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_, err := m.ReceiveAttachment(playerID, mailID)
+			switch {
+			case err == nil:
+				succeeded.Add(1)
+			case errors.Is(err, ErrAttachmentClaimed):
+			default:
+				t.Errorf("receive attachment failed: %v", err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if n := succeeded.Load(); n != 1 {
+		t.Errorf("succeeded receives = %d, want 1", n)
+	}
+
+	if credited, _ := wallet.totals(); credited != coin {
+		t.Errorf("credited coin = %d, want %d", credited, coin)
+	}
+
+	if n := attachments.count(playerID, 1001); n != count {
+		t.Errorf("bag item count = %d, want %d", n, count)
+	}
+}
+
+func TestMatchBroadcast(t *testing.T) {
+	sendTime := time.Date(2026, 6, 1, 12, 0, 0, 0, time.Local)
+	broadcast := &define.BroadcastMail{ID: "broadcast-1", SendTime: sendTime}
+	ranged := &define.BroadcastMail{
+		ID:             "broadcast-2",
+		MinLevel:       10,
+		MaxLevel:       20,
+		RegisterAfter:  sendTime.Add(-48 * time.Hour),
+		RegisterBefore: sendTime.Add(24 * time.Hour),
+		SendTime:       sendTime,
+	}
+
+	cases := []struct {
+		name      string
+		broadcast *define.BroadcastMail
+		player    *define.Player
+		want      bool
+	}{
+		{"发送前注册", broadcast, &define.Player{CreateTime: sendTime.Add(-time.Hour)}, true},
+		{"发送时注册", broadcast, &define.Player{CreateTime: sendTime}, true},
+		{"发送后注册", broadcast, &define.Player{CreateTime: sendTime.Add(time.Second)}, false},
+		{"玩家数据不存在", broadcast, &define.Player{}, true},
+		{"满足条件", ranged, &define.Player{Level: 15, CreateTime: sendTime.Add(-time.Hour)}, true},
+		{"注册时间截止晚于发送时间", ranged, &define.Player{Level: 15, CreateTime: sendTime.Add(time.Hour)}, false},
+		{"注册过早", ranged, &define.Player{Level: 15, CreateTime: sendTime.Add(-72 * time.Hour)}, false},
+		{"等级过低", ranged, &define.Player{Level: 9, CreateTime: sendTime.Add(-time.Hour)}, false},
+		{"等级过高", ranged, &define.Player{Level: 21, CreateTime: sendTime.Add(-time.Hour)}, false},
+	}
+
+	for _, c := range cases {
+		if got := matchBroadcast(c.broadcast, c.player); got != c.want {
+			t.Errorf("%s: matchBroadcast = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+// 测试邮件附件，邮件及背包保存在内存中
+type fakeAttachments struct {
+	mutex sync.Mutex
+	mails map[string]*define.Mail
+	bag   map[string]int // 背包物品ID -> 数量
+}
+
+func newFakeAttachments(mails ...*define.Mail) *fakeAttachments {
+	a := &fakeAttachments{
+		mails: make(map[string]*define.Mail, len(mails)),
+		bag:   make(map[string]int),
+	}
+
+	for _, mail := range mails {
+		a.mails[mail.ID] = mail
+	}
+
+	return a
+}
+
+func (a *fakeAttachments) Find(playerID, mailID string) (*define.Mail, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	mail, err := a.claimable(playerID, mailID)
+	if err != nil {
+		return nil, err
+	}
+
+	clone := *mail
+	return &clone, nil
+}
+
+func (a *fakeAttachments) Claim(playerID, mailID string) (*define.Mail, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	mail, ok := a.mails[mailID]
+	if !ok || mail.PlayerID != playerID {
+		return nil, ErrMailNotFound
+	}
+
+	if mail.IsClaimed {
+		return nil, ErrAttachmentClaimed
+	}
+
+	mail.IsClaimed, mail.IsRead = true, true
+	for _, item := range mail.Items {
+		a.bag[bagItemID(playerID, item.ItemID)] += item.Count
+	}
+
+	clone := *mail
+	return &clone, nil
+}
+
+// 查询可领取的邮件，无法领取时返回原因
+func (a *fakeAttachments) claimable(playerID, mailID string) (*define.Mail, error) {
+	mail, ok := a.mails[mailID]
+	switch {
+	case !ok || mail.PlayerID != playerID:
+		return nil, ErrMailNotFound
+	case !mail.ExpireTime.After(time.Now()):
+		return nil, ErrMailExpired
+	case !hasAttachment(mail):
+		return nil, ErrNoAttachment
+	case mail.IsClaimed:
+		return nil, ErrAttachmentClaimed
+	}
+
+	return mail, nil
+}
+
+// 背包中物品的数量
+func (a *fakeAttachments) count(playerID string, itemID int) int {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return a.bag[bagItemID(playerID, itemID)]
+}
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"errors"
+	"time"
+
+	"ghserver/define"
+	"ghserver/utils/mongodb"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Attachments 邮件附件，查询可领取的附件并在领取时发放物品
+type Attachments interface {
+	// Find 查询可领取附件的邮件，无法领取时返回原因
+	Find(playerID, mailID string) (*define.Mail, error)
+	// Claim 标记邮件已领取并发放附件物品，并发领取同一封邮件时只有一个成功，其余返回ErrAttachmentClaimed
+	Claim(playerID, mailID string) (*define.Mail, error)
+}
+
+// AttachmentStore 邮件附件存储，标记领取与物品堆叠到背包在同一事务内完成
+type AttachmentStore struct {
+	mails *mongodb.MongoDBClient
+	items *mongodb.MongoDBClient
+}
+
+func NewAttachmentStore(mails, items *mongodb.MongoDBClient) *AttachmentStore {
+	return &AttachmentStore{mails: mails, items: items}
+}
+
+// Find 查询可领取附件的邮件，无法领取时返回原因
+func (s *AttachmentStore) Find(playerID, mailID string) (*define.Mail, error) {
+	filter := validFilter(playerID)
+	filter["_id"] = mailID
+	filter["is_claimed"] = false
+	filter["$or"] = attachmentFilter()
+
+	mail := &define.Mail{}
+	if err := s.mails.FindOne(filter, mail); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, s.claimError(playerID, mailID)
+		}
+		return nil, err
+	}
+
+	return mail, nil
+}
+
+// Claim 标记邮件已领取并发放附件物品，货币已先行发放，标记领取时不再校验有效期
+func (s *AttachmentStore) Claim(playerID, mailID string) (*define.Mail, error) {
+	mail := &define.Mail{}
+	err := s.mails.WithTransaction(func(ctx mongo.SessionContext) error {
+		update := bson.M{"$set": bson.M{"is_claimed": true, "is_read": true}}
+		err := s.mails.GetCollection().FindOneAndUpdate(ctx,
+			bson.M{"_id": mailID, "player_id": playerID, "is_claimed": false}, update).Decode(mail)
+		if err != nil {
+			return err
+		}
+
+		// 发放附件物品，按物品ID堆叠到背包
+		return stackItems(ctx, s.items, playerID, mail.Items, time.Now())
+	})
+	if err == nil {
+		return mail, nil
+	}
+
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+
+	return nil, s.claimError(playerID, mailID)
+}
+
+// 查询邮件无法领取的原因
+func (s *AttachmentStore) claimError(playerID, mailID string) error {
+	mail := &define.Mail{}
+	if err := s.mails.FindOne(bson.M{"_id": mailID, "player_id": playerID}, mail); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return ErrMailNotFound
+		}
+		return err
+	}
+
+	switch {
+	case !mail.ExpireTime.After(time.Now()):
+		return ErrMailExpired
+	case !hasAttachment(mail):
+		return ErrNoAttachment
+	default:
+		return ErrAttachmentClaimed
+	}
+}
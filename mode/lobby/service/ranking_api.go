@@ -2,95 +2,382 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
+	"math/bits"
 	"sort"
-	"sync"
 	"time"
 
+	"ghserver/define"
 	"ghserver/proto/pb"
+	RPC "ghserver/proto/rpc_ctl"
+	"ghserver/utils/mongodb"
+	"ghserver/utils/redis"
 
 	"github.com/dobyte/due/v2/cluster/node"
 	"github.com/dobyte/due/v2/codes"
+	"github.com/dobyte/due/v2/etc"
 	"github.com/dobyte/due/v2/log"
+	"github.com/dobyte/due/v2/utils/xconv"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
+const (
+	rankingArchiveCollection = "ranking_archive" // 排行榜结算快照集合
+	rankingRewardCollection  = "ranking_reward"  // 排行榜奖励发放记录集合
+	defaultArchiveSize       = 1000              // 默认结算快照人数
+	defaultAroundSize        = 5                 // 默认附近排名人数
+	maxAroundSize            = 50                // 最大附近排名人数
+	maxGroupSize             = 500               // 指定范围排名的最大人数
+)
+
+// 复合分数编码：整数部分为分数，启用等级作为次要排序键时为 分数<<10|等级；
+// 小数部分由达成时间编码，越早达成小数部分越大，在float64精度范围内截断以免进位影响整数部分。
+// 有序集合按复合分数降序排列，复合分数相同时按玩家ID逆序排列，内存排序与之保持一致
+const (
+	scoreLevelBits = 10                         // 等级占用的位数
+	scoreMaxLevel  = 1<<scoreLevelBits - 1      // 可编码的最大等级
+	scoreTimeSpan  = 100 * 365 * 24 * 3600      // 达成时间编码范围（秒）
+	scoreTimeBase  = 1577836800                 // 达成时间编码起点，2020-01-01 00:00:00 UTC
+	scoreMaxValue  = 1<<(52-scoreLevelBits) - 1 // 可编码的最大分数
+)
+
+// 更新分数：复合分数整数部分未变化时保留原达成时间
+// KEYS[1] 排行榜
+// ARGV[1] 玩家ID ARGV[2] 复合分数 ARGV[3] 复合分数整数部分 ARGV[4] 有效期(毫秒)，0表示不过期
+const updateScoreScript = `
+local cur = redis.call('ZSCORE', KEYS[1], ARGV[1])
+if not cur or math.floor(tonumber(cur)) ~= tonumber(ARGV[3]) then
+	redis.call('ZADD', KEYS[1], ARGV[2], ARGV[1])
+end
+if tonumber(ARGV[4]) > 0 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[4])
+end
+return 1
+`
+
+// 合并玩家信息：昵称、头像为空或等级为0时保留已记录的值
+// KEYS[1] 玩家信息
+// ARGV[1] 玩家ID ARGV[2] 玩家信息JSON
+const mergePlayerScript = `
+local player = cjson.decode(ARGV[2])
+local cur = redis.call('HGET', KEYS[1], ARGV[1])
+if cur then
+	local ok, old = pcall(cjson.decode, cur)
+	if ok and type(old) == 'table' then
+		if player.nickname == '' then player.nickname = old.nickname end
+		if player.avatar == '' then player.avatar = old.avatar end
+		if player.level == 0 then player.level = old.level end
+	end
+end
+redis.call('HSET', KEYS[1], ARGV[1], cjson.encode(player))
+return 1
+`
+
+// 批量查询分数：只返回已上榜的成员及分数
+// KEYS[1] 排行榜 ARGV 玩家ID列表
+const groupScoreScript = `
+local result = {}
+for _, member in ipairs(ARGV) do
+	local score = redis.call('ZSCORE', KEYS[1], member)
+	if score then
+		table.insert(result, member)
+		table.insert(result, score)
+	end
+end
+return result
+`
+
+var (
+	ErrRankingTypeNotFound   = errors.New("排行榜类型不存在")
+	ErrRankingPeriodNotFound = errors.New("排行榜周期不存在")
+	ErrNotRanked             = errors.New("玩家未上榜")
+)
+
+// 所有排行榜类型
+var rankingTypes = []int32{
+	define.RankingTypeLevel,
+	define.RankingTypePower,
+	define.RankingTypeWealth,
+	define.RankingTypeKill,
+	define.RankingTypeWinRate,
+}
+
+// 排行榜类型名称
+var rankingTypeNames = map[int32]string{
+	define.RankingTypeLevel:   "等级",
+	define.RankingTypePower:   "战斗力",
+	define.RankingTypeWealth:  "财富",
+	define.RankingTypeKill:    "击杀",
+	define.RankingTypeWinRate: "胜率",
+}
+
+// 需要定期结算的排行榜周期
+var rankingPeriods = []int32{
+	define.RankingPeriodDaily,
+	define.RankingPeriodWeekly,
+	define.RankingPeriodSeason,
+}
+
 // RankingServer 排行榜服务
 type RankingServer struct {
 	pb.UnimplementedRankingServiceServer
-	proxy          *node.Proxy
-	rankingManager *RankingManager
+	proxy           *node.Proxy
+	rankingManager  *RankingManager
+	rankingConsumer *RankingConsumer
 }
 
 func NewRankingServer(proxy *node.Proxy) *RankingServer {
 	return &RankingServer{
-		proxy:          proxy,
-		rankingManager: NewRankingManager(),
+		proxy: proxy,
 	}
 }
 
 func (s *RankingServer) Init() {
+	// 创建排行榜管理器
+	rankingManager, err := NewRankingManager(etc.Get("etc.mongo.default.database", "game").String())
+	if err != nil {
+		log.Fatalf("create ranking manager failed: %v", err)
+	}
+	s.rankingManager = rankingManager
+	s.rankingManager.SetMailSender(s.sendMail)
+	s.rankingManager.Serve()
+
+	// 根据战斗及玩家成长事件更新排行榜
+	if etc.Get("etc.kafka.default.enable", false).Bool() {
+		rankingConsumer, err := NewRankingConsumer(s.rankingManager, etc.Get("etc.mongo.default.database", "game").String())
+		if err != nil {
+			log.Fatalf("create ranking consumer failed: %v", err)
+		}
+		s.rankingConsumer = rankingConsumer
+		s.rankingConsumer.Start()
+	}
+
 	s.proxy.AddServiceProvider("ranking", &pb.RankingService_ServiceDesc, s)
 }
 
 func (s *RankingServer) Close() error {
-	// 清理资源
+	// 停止事件消费及排行榜结算
+	if s.rankingConsumer != nil {
+		s.rankingConsumer.Stop()
+	}
+	s.rankingManager.Stop()
 	return nil
 }
 
 func (s *RankingServer) GetRankingList(ctx context.Context, req *pb.GetRankingListRequest) (*pb.GetRankingListResponse, error) {
-	log.Debugf("Get ranking list request: ranking_type=%d, page=%d, page_size=%d", req.RankingType, req.Page, req.PageSize)
+	log.Debugf("Get ranking list request: ranking_type=%d, period=%d, period_id=%s, page=%d, page_size=%d",
+		req.RankingType, req.Period, req.PeriodId, req.Page, req.PageSize)
+
+	// 未指定周期ID时查询当前周期
+	periodID := req.PeriodId
+	if periodID == "" {
+		current, err := s.rankingManager.CurrentPeriodID(req.Period)
+		if err != nil {
+			return &pb.GetRankingListResponse{
+				Code:    int32(codes.InvalidArgument.Code()),
+				Message: err.Error(),
+			}, nil
+		}
+		periodID = current
+	}
 
 	// 获取排行榜列表
-	rankings, total := s.rankingManager.GetRankingList(int32(req.RankingType), int(req.Page), int(req.PageSize))
+	rankings, total, err := s.rankingManager.GetRankingList(req.RankingType, req.Period, periodID, int(req.Page), int(req.PageSize))
+	if err != nil {
+		if errors.Is(err, ErrRankingTypeNotFound) || errors.Is(err, ErrRankingPeriodNotFound) {
+			return &pb.GetRankingListResponse{
+				Code:    int32(codes.InvalidArgument.Code()),
+				Message: err.Error(),
+			}, nil
+		}
+
+		log.Errorf("get ranking list failed: ranking_type=%d, period=%d, period_id=%s, err=%v", req.RankingType, req.Period, periodID, err)
+		return &pb.GetRankingListResponse{
+			Code:    int32(codes.InternalError.Code()),
+			Message: "获取排行榜失败",
+		}, nil
+	}
 
 	// 转换排行榜项
 	rankingItems := make([]*pb.RankingItem, len(rankings))
 	for i, rank := range rankings {
-		rankingItems[i] = &pb.RankingItem{
-			PlayerId: rank.PlayerID,
-			Nickname: rank.Nickname,
-			Rank:     int32(rank.Rank),
-			Score:    int32(rank.Score),
-			Level:    int32(rank.Level),
-			Avatar:   rank.Avatar,
+		rankingItems[i] = toPBRankingItem(rank)
+	}
+
+	// 玩家自己的排名，未上榜时为空
+	var playerItem *pb.RankingItem
+	if req.PlayerId != "" {
+		rank, _, err := s.rankingManager.GetPlayerRank(req.PlayerId, req.RankingType, req.Period, periodID)
+		switch {
+		case err == nil:
+			playerItem = toPBRankingItem(rank)
+		case !errors.Is(err, ErrNotRanked):
+			log.Warnf("get player rank failed: player_id=%s, ranking_type=%d, err=%v", req.PlayerId, req.RankingType, err)
 		}
 	}
 
 	return &pb.GetRankingListResponse{
-		Code:    int32(codes.OK.Code()),
-		Message: "获取排行榜成功",
-		Items:   rankingItems,
-		Total:   int32(total),
+		Code:       int32(codes.OK.Code()),
+		Message:    "获取排行榜成功",
+		Items:      rankingItems,
+		Total:      int32(total),
+		PlayerItem: playerItem,
+		PeriodId:   periodID,
 	}, nil
 }
 
 func (s *RankingServer) GetPlayerRank(ctx context.Context, req *pb.GetPlayerRankRequest) (*pb.GetPlayerRankResponse, error) {
-	log.Debugf("Get player rank request: player_id=%s, ranking_type=%d", req.PlayerId, req.RankingType)
+	log.Debugf("Get player rank request: player_id=%s, ranking_type=%d, period=%d", req.PlayerId, req.RankingType, req.Period)
 
-	// 获取玩家排名
-	rank, err := s.rankingManager.GetPlayerRank(req.PlayerId, int32(req.RankingType))
+	periodID, err := s.rankingManager.CurrentPeriodID(req.Period)
 	if err != nil {
 		return &pb.GetPlayerRankResponse{
+			Code:    int32(codes.InvalidArgument.Code()),
+			Message: err.Error(),
+		}, nil
+	}
+
+	// 获取玩家排名
+	rank, total, err := s.rankingManager.GetPlayerRank(req.PlayerId, req.RankingType, req.Period, periodID)
+	if err != nil {
+		if errors.Is(err, ErrRankingTypeNotFound) || errors.Is(err, ErrRankingPeriodNotFound) || errors.Is(err, ErrNotRanked) {
+			return &pb.GetPlayerRankResponse{
 				Code:    int32(codes.InvalidArgument.Code()),
 				Message: err.Error(),
-			},
-			nil
+			}, nil
+		}
+
+		log.Errorf("get player rank failed: player_id=%s, ranking_type=%d, err=%v", req.PlayerId, req.RankingType, err)
+		return &pb.GetPlayerRankResponse{
+			Code:    int32(codes.InternalError.Code()),
+			Message: "获取玩家排名失败",
+		}, nil
 	}
 
 	return &pb.GetPlayerRankResponse{
-		Code:    int32(codes.OK.Code()),
-		Message: "获取玩家排名成功",
-		Item: &pb.RankingItem{
-			PlayerId: rank.PlayerID,
-			Nickname: rank.Nickname,
-			Rank:     int32(rank.Rank),
-			Score:    int32(rank.Score),
-			Level:    int32(rank.Level),
-			Avatar:   rank.Avatar,
-		},
+		Code:         int32(codes.OK.Code()),
+		Message:      "获取玩家排名成功",
+		Item:         toPBRankingItem(rank),
+		TotalPlayers: int32(total),
+	}, nil
+}
+
+func (s *RankingServer) GetAroundRanking(ctx context.Context, req *pb.GetAroundRankingRequest) (*pb.GetRankingListResponse, error) {
+	log.Debugf("Get around ranking request: player_id=%s, ranking_type=%d, period=%d, above=%d, below=%d",
+		req.PlayerId, req.RankingType, req.Period, req.Above, req.Below)
+
+	// 获取玩家附近的排名
+	rankings, playerItem, total, err := s.rankingManager.GetAroundRanking(req.PlayerId, req.RankingType, req.Period, int(req.Above), int(req.Below))
+	if err != nil {
+		if errors.Is(err, ErrRankingTypeNotFound) || errors.Is(err, ErrRankingPeriodNotFound) || errors.Is(err, ErrNotRanked) {
+			return &pb.GetRankingListResponse{
+				Code:    int32(codes.InvalidArgument.Code()),
+				Message: err.Error(),
+			}, nil
+		}
+
+		log.Errorf("get around ranking failed: player_id=%s, ranking_type=%d, err=%v", req.PlayerId, req.RankingType, err)
+		return &pb.GetRankingListResponse{
+			Code:    int32(codes.InternalError.Code()),
+			Message: "获取排行榜失败",
+		}, nil
+	}
+
+	rankingItems := make([]*pb.RankingItem, len(rankings))
+	for i, rank := range rankings {
+		rankingItems[i] = toPBRankingItem(rank)
+	}
+
+	return &pb.GetRankingListResponse{
+		Code:       int32(codes.OK.Code()),
+		Message:    "获取排行榜成功",
+		Items:      rankingItems,
+		Total:      int32(total),
+		PlayerItem: toPBRankingItem(playerItem),
+	}, nil
+}
+
+func (s *RankingServer) GetGroupRanking(ctx context.Context, req *pb.GetGroupRankingRequest) (*pb.GetRankingListResponse, error) {
+	log.Debugf("Get group ranking request: player_id=%s, ranking_type=%d, period=%d, players=%d",
+		req.PlayerId, req.RankingType, req.Period, len(req.PlayerIds))
+
+	if len(req.PlayerIds) > maxGroupSize {
+		return &pb.GetRankingListResponse{
+			Code:    int32(codes.InvalidArgument.Code()),
+			Message: "玩家数量过多",
+		}, nil
+	}
+
+	// 获取指定范围内的排名
+	rankings, err := s.rankingManager.GetGroupRanking(req.PlayerId, req.RankingType, req.Period, req.PlayerIds)
+	if err != nil {
+		if errors.Is(err, ErrRankingTypeNotFound) || errors.Is(err, ErrRankingPeriodNotFound) {
+			return &pb.GetRankingListResponse{
+				Code:    int32(codes.InvalidArgument.Code()),
+				Message: err.Error(),
+			}, nil
+		}
+
+		log.Errorf("get group ranking failed: player_id=%s, ranking_type=%d, err=%v", req.PlayerId, req.RankingType, err)
+		return &pb.GetRankingListResponse{
+			Code:    int32(codes.InternalError.Code()),
+			Message: "获取排行榜失败",
+		}, nil
+	}
+
+	var playerItem *pb.RankingItem
+	rankingItems := make([]*pb.RankingItem, len(rankings))
+	for i, rank := range rankings {
+		rankingItems[i] = toPBRankingItem(rank)
+		if rank.PlayerID == req.PlayerId {
+			playerItem = rankingItems[i]
+		}
+	}
+
+	return &pb.GetRankingListResponse{
+		Code:       int32(codes.OK.Code()),
+		Message:    "获取排行榜成功",
+		Items:      rankingItems,
+		Total:      int32(len(rankingItems)),
+		PlayerItem: playerItem,
 	}, nil
 }
 
+// 通过邮件服务发送邮件
+func (s *RankingServer) sendMail(req *pb.SendMailRequest) error {
+	client, err := RPC.NewRpcClient(s.proxy.NewMeshClient, RPC.ServiceTypeMail)
+	if err != nil {
+		return err
+	}
+
+	reply, err := client.(pb.MailServiceClient).SendMail(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	if reply.Code != int32(codes.OK.Code()) {
+		return fmt.Errorf("send mail failed: code=%d, message=%s", reply.Code, reply.Message)
+	}
+
+	return nil
+}
+
+// 转换排行榜项
+func toPBRankingItem(item *RankingItem) *pb.RankingItem {
+	return &pb.RankingItem{
+		PlayerId: item.PlayerID,
+		Nickname: item.Nickname,
+		Rank:     int32(item.Rank),
+		Score:    int32(item.Score),
+		Level:    int32(item.Level),
+		Avatar:   item.Avatar,
+	}
+}
+
 // RankingItem 排行榜项
 type RankingItem struct {
 	PlayerID   string
@@ -100,247 +387,841 @@ type RankingItem struct {
 	Level      int
 	Avatar     string
 	UpdateTime int64
+	Composite  float64 // 复合分数，用于排序
 }
 
-// RankingList 排行榜列表
-type RankingList struct {
-	Type    int32
-	Items   []*RankingItem
-	Players map[string]*RankingItem // 快速查找玩家排名
-	mutex   sync.RWMutex
+// 排行榜玩家信息，所有类型的排行榜共用
+type rankingPlayer struct {
+	Nickname   string `json:"nickname"`
+	Avatar     string `json:"avatar"`
+	Level      int    `json:"level"`
+	UpdateTime int64  `json:"update_time"`
 }
 
-// RankingManager 排行榜管理器
+// RankingReward 赛季榜名次奖励
+type RankingReward struct {
+	RankingType int32             `json:"rankingType"` // 排行榜类型，0表示所有类型
+	MinRank     int               `json:"minRank"`     // 起始名次
+	MaxRank     int               `json:"maxRank"`     // 结束名次
+	Items       []define.ItemInfo `json:"items"`       // 奖励物品
+	Coin        int32             `json:"coin"`        // 奖励金币
+	Diamond     int32             `json:"diamond"`     // 奖励钻石
+}
+
+// MailSender 邮件发送函数
+type MailSender func(req *pb.SendMailRequest) error
+
+// RankingManager 排行榜管理器，分数存储在Redis有序集合中，玩家信息存储在哈希中，集群内共享；
+// 日榜、周榜、赛季榜按周期分别存储，周期结束后结算快照存入MongoDB
 type RankingManager struct {
-	rankings map[int32]*RankingList
-	mutex    sync.RWMutex
+	client         *redis.RedisClient
+	archives       *mongodb.MongoDBClient
+	prefix         string
+	archiveSize    int64
+	retention      time.Duration
+	interval       time.Duration
+	seasonStart    time.Time
+	seasonDuration time.Duration
+	rewardRecords  *mongodb.MongoDBClient
+	rewards        []RankingReward
+	secondaryLevel map[int32]bool // 以等级作为次要排序键的排行榜类型
+	sendMail       MailSender
+	done           chan struct{}
 }
 
-func NewRankingManager() *RankingManager {
-	manager := &RankingManager{
-		rankings: make(map[int32]*RankingList),
+func NewRankingManager(database string) (*RankingManager, error) {
+	archives, err := mongodb.NewMongoDBClient(database, rankingArchiveCollection)
+	if err != nil {
+		return nil, err
+	}
+
+	rewardRecords, err := mongodb.NewMongoDBClient(database, rankingRewardCollection)
+	if err != nil {
+		return nil, err
+	}
+
+	rewards := make([]RankingReward, 0)
+	if err = etc.Get("etc.ranking.rewards").Scan(&rewards); err != nil {
+		return nil, fmt.Errorf("invalid ranking rewards: %v", err)
+	}
+
+	secondaryLevel := make([]int32, 0)
+	if err = etc.Get("etc.ranking.secondaryLevel").Scan(&secondaryLevel); err != nil {
+		return nil, fmt.Errorf("invalid ranking secondary level: %v", err)
+	}
+
+	seasonStart, err := time.ParseInLocation("2006-01-02", etc.Get("etc.ranking.season.start", "2026-01-01").String(), time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid season start: %v", err)
+	}
+
+	m := &RankingManager{
+		client:         redis.Instance(etc.Get("etc.ranking.redis", "default").String()),
+		archives:       archives,
+		prefix:         etc.Get("etc.ranking.prefix", "ranking").String(),
+		archiveSize:    etc.Get("etc.ranking.archiveSize", defaultArchiveSize).Int64(),
+		retention:      etc.Get("etc.ranking.retention", "7d").Duration(),
+		interval:       etc.Get("etc.ranking.rolloverInterval", "1m").Duration(),
+		seasonStart:    seasonStart,
+		seasonDuration: etc.Get("etc.ranking.season.duration", "28d").Duration(),
+		rewardRecords:  rewardRecords,
+		rewards:        rewards,
+		secondaryLevel: make(map[int32]bool, len(secondaryLevel)),
+		done:           make(chan struct{}),
+	}
+
+	for _, rankingType := range secondaryLevel {
+		m.secondaryLevel[rankingType] = true
+	}
+
+	if m.archiveSize <= 0 {
+		m.archiveSize = defaultArchiveSize
 	}
 
-	// 初始化各类型排行榜
-	manager.initRankings()
+	if m.interval <= 0 {
+		m.interval = time.Minute
+	}
+
+	if m.seasonDuration <= 0 {
+		return nil, errors.New("invalid season duration")
+	}
+
+	return m, nil
+}
 
-	return manager
+// SetMailSender 设置发放赛季奖励使用的邮件发送函数
+func (m *RankingManager) SetMailSender(sendMail MailSender) {
+	m.sendMail = sendMail
 }
 
-func (m *RankingManager) GetRankingList(rankingType int32, page, pageSize int) ([]*RankingItem, int) {
-	m.mutex.RLock()
-	rankingList, exists := m.rankings[rankingType]
-	m.mutex.RUnlock()
+// CurrentPeriodID 获取当前周期ID，总榜为空
+func (m *RankingManager) CurrentPeriodID(period int32) (string, error) {
+	periodID, _, _, ok := m.periodOf(period, time.Now())
+	if !ok {
+		return "", ErrRankingPeriodNotFound
+	}
+
+	return periodID, nil
+}
 
-	if !exists {
-		return []*RankingItem{}, 0
+func (m *RankingManager) GetRankingList(rankingType, period int32, periodID string, page, pageSize int) ([]*RankingItem, int64, error) {
+	if !isValidRankingType(rankingType) {
+		return nil, 0, ErrRankingTypeNotFound
 	}
 
-	rankingList.mutex.RLock()
-	defer rankingList.mutex.RUnlock()
+	current, err := m.CurrentPeriodID(period)
+	if err != nil {
+		return nil, 0, err
+	}
 
-	// 分页
-	total := len(rankingList.Items)
-	start := (page - 1) * pageSize
-	end := start + pageSize
-	if start > total {
-		start = total
+	if page < 1 {
+		page = 1
 	}
-	if end > total {
-		end = total
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	// 历史周期优先查询结算快照，尚未结算时仍从Redis读取
+	if periodID != current {
+		items, total, err := m.getArchivedList(rankingType, period, periodID, page, pageSize)
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			return items, total, err
+		}
+	}
+
+	key := m.scoreKey(rankingType, period, periodID)
+
+	total, err := m.client.ZCard(key)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	start := int64((page - 1) * pageSize)
+	if start >= total {
+		return []*RankingItem{}, total, nil
+	}
+
+	members, err := m.client.ZRevRangeWithScores(key, start, start+int64(pageSize)-1)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	items := make([]*RankingItem, len(members))
+	for i, member := range members {
+		items[i] = &RankingItem{
+			PlayerID:  xconv.String(member.Member),
+			Rank:      int(start) + i + 1,
+			Score:     m.decodeScore(rankingType, member.Score),
+			Composite: member.Score,
+		}
 	}
 
-	// 获取分页数据
-	result := make([]*RankingItem, 0)
-	for i := start; i < end && i < total; i++ {
-		result = append(result, rankingList.Items[i])
+	if err = m.fillPlayers(items); err != nil {
+		return nil, 0, err
 	}
 
-	return result, total
+	return items, total, nil
 }
 
-func (m *RankingManager) GetPlayerRank(playerID string, rankingType int32) (*RankingItem, error) {
-	m.mutex.RLock()
-	rankingList, exists := m.rankings[rankingType]
-	m.mutex.RUnlock()
+// GetPlayerRank 获取玩家在指定周期的排名及上榜总人数，历史周期从结算快照中查询
+func (m *RankingManager) GetPlayerRank(playerID string, rankingType, period int32, periodID string) (*RankingItem, int64, error) {
+	if !isValidRankingType(rankingType) {
+		return nil, 0, ErrRankingTypeNotFound
+	}
+
+	current, err := m.CurrentPeriodID(period)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if periodID != current {
+		item, total, err := m.getArchivedRank(playerID, rankingType, period, periodID)
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			return item, total, err
+		}
+	}
+
+	key := m.scoreKey(rankingType, period, periodID)
+
+	rank, err := m.client.ZRevRank(key, playerID)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, 0, ErrNotRanked
+		}
+		return nil, 0, err
+	}
 
-	if !exists {
-		return nil, errors.New("排行榜类型不存在")
+	score, err := m.client.ZScore(key, playerID)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, 0, ErrNotRanked
+		}
+		return nil, 0, err
+	}
+
+	total, err := m.client.ZCard(key)
+	if err != nil {
+		return nil, 0, err
 	}
 
-	rankingList.mutex.RLock()
-	defer rankingList.mutex.RUnlock()
+	item := &RankingItem{
+		PlayerID:  playerID,
+		Rank:      int(rank) + 1,
+		Score:     m.decodeScore(rankingType, score),
+		Composite: score,
+	}
 
-	item, exists := rankingList.Players[playerID]
-	if !exists {
-		return nil, errors.New("玩家未上榜")
+	if err = m.fillPlayers([]*RankingItem{item}); err != nil {
+		return nil, 0, err
 	}
 
-	return item, nil
+	return item, total, nil
 }
 
-// UpdatePlayerScore 更新玩家分数并重新排名
-func (m *RankingManager) UpdatePlayerScore(playerID string, nickname string, avatar string, level int, score int, rankingType int32) {
-	m.mutex.Lock()
-	rankingList, exists := m.rankings[rankingType]
-	if !exists {
-		rankingList = &RankingList{
-			Type:    rankingType,
-			Items:   make([]*RankingItem, 0),
-			Players: make(map[string]*RankingItem),
+// GetAroundRanking 获取玩家在当前周期前后的排名，返回附近排名、玩家自己的排名及上榜总人数
+func (m *RankingManager) GetAroundRanking(playerID string, rankingType, period int32, above, below int) ([]*RankingItem, *RankingItem, int64, error) {
+	if !isValidRankingType(rankingType) {
+		return nil, nil, 0, ErrRankingTypeNotFound
+	}
+
+	periodID, err := m.CurrentPeriodID(period)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	above = clampAroundSize(above)
+	below = clampAroundSize(below)
+
+	key := m.scoreKey(rankingType, period, periodID)
+
+	rank, err := m.client.ZRevRank(key, playerID)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil, 0, ErrNotRanked
 		}
-		m.rankings[rankingType] = rankingList
+		return nil, nil, 0, err
 	}
-	m.mutex.Unlock()
 
-	rankingList.mutex.Lock()
-	defer rankingList.mutex.Unlock()
+	start := rank - int64(above)
+	if start < 0 {
+		start = 0
+	}
 
-	// 查找玩家是否已在排行榜中
-	item, exists := rankingList.Players[playerID]
-	if exists {
-		// 更新分数
-		item.Score = score
-		item.Nickname = nickname
-		item.Level = level
-		item.Avatar = avatar
-		item.UpdateTime = time.Now().Unix()
-	} else {
-		// 添加新玩家
-		item = &RankingItem{
-			PlayerID:   playerID,
-			Nickname:   nickname,
-			Score:      score,
-			Level:      level,
-			Avatar:     avatar,
-			UpdateTime: time.Now().Unix(),
+	members, err := m.client.ZRevRangeWithScores(key, start, rank+int64(below))
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	total, err := m.client.ZCard(key)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	var self *RankingItem
+	items := make([]*RankingItem, len(members))
+	for i, member := range members {
+		items[i] = &RankingItem{
+			PlayerID:  xconv.String(member.Member),
+			Rank:      int(start) + i + 1,
+			Score:     m.decodeScore(rankingType, member.Score),
+			Composite: member.Score,
+		}
+		if items[i].PlayerID == playerID {
+			self = items[i]
+		}
+	}
+
+	// 查询期间玩家排名发生变化，已不在查询范围内
+	if self == nil {
+		return nil, nil, 0, ErrNotRanked
+	}
+
+	if err = m.fillPlayers(items); err != nil {
+		return nil, nil, 0, err
+	}
+
+	return items, self, total, nil
+}
+
+// GetGroupRanking 获取指定玩家范围内的当前周期排名，排名为范围内的相对名次，未上榜的玩家不参与排名
+func (m *RankingManager) GetGroupRanking(playerID string, rankingType, period int32, playerIDs []string) ([]*RankingItem, error) {
+	if !isValidRankingType(rankingType) {
+		return nil, ErrRankingTypeNotFound
+	}
+
+	periodID, err := m.CurrentPeriodID(period)
+	if err != nil {
+		return nil, err
+	}
+
+	// 去重并加入玩家自己
+	members := make([]interface{}, 0, len(playerIDs)+1)
+	exists := make(map[string]bool, len(playerIDs)+1)
+	for _, id := range append([]string{playerID}, playerIDs...) {
+		if id == "" || exists[id] {
+			continue
 		}
-		rankingList.Items = append(rankingList.Items, item)
-		rankingList.Players[playerID] = item
+		exists[id] = true
+		members = append(members, id)
+	}
+
+	if len(members) == 0 {
+		return []*RankingItem{}, nil
 	}
 
-	// 重新排序
-	m.sortRankingList(rankingList)
+	reply, err := m.client.Eval(groupScoreScript, []string{m.scoreKey(rankingType, period, periodID)}, members...)
+	if err != nil {
+		return nil, err
+	}
+
+	values, _ := reply.([]interface{})
+	items := make([]*RankingItem, 0, len(values)/2)
+	for i := 0; i+1 < len(values); i += 2 {
+		composite := xconv.Float64(values[i+1])
+		items = append(items, &RankingItem{
+			PlayerID:  xconv.String(values[i]),
+			Score:     m.decodeScore(rankingType, composite),
+			Composite: composite,
+		})
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return rankingLess(items[i], items[j])
+	})
+
+	for i := range items {
+		items[i].Rank = i + 1
+	}
+
+	if err = m.fillPlayers(items); err != nil {
+		return nil, err
+	}
+
+	return items, nil
 }
 
-func (m *RankingManager) sortRankingList(rankingList *RankingList) {
-	// 根据分数降序排序
-	sort.Slice(rankingList.Items, func(i, j int) bool {
-		return rankingList.Items[i].Score > rankingList.Items[j].Score
+// UpdatePlayerScore 更新玩家在总榜及各当前周期榜的分数及玩家信息，昵称、头像为空或等级为0时保留已记录的值
+func (m *RankingManager) UpdatePlayerScore(playerID string, nickname string, avatar string, level int, score int, rankingType int32) error {
+	return m.UpdatePeriodScores(playerID, nickname, avatar, level, rankingType, func(int32, time.Time, time.Time) (int, bool, error) {
+		return score, true, nil
 	})
+}
+
+// PeriodScore 计算玩家在周期[start, end)内的分数，总榜的起止时间为零值；ok为false时不更新该周期榜
+type PeriodScore func(period int32, start, end time.Time) (score int, ok bool, err error)
+
+// UpdatePeriodScores 按周期分别计算并更新玩家在总榜及各当前周期榜的分数，同时合并玩家信息
+func (m *RankingManager) UpdatePeriodScores(playerID string, nickname string, avatar string, level int, rankingType int32, scoreOf PeriodScore) error {
+	if !isValidRankingType(rankingType) {
+		return ErrRankingTypeNotFound
+	}
+
+	now := time.Now()
+	data, err := json.Marshal(&rankingPlayer{
+		Nickname:   nickname,
+		Avatar:     avatar,
+		Level:      level,
+		UpdateTime: now.Unix(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err = m.client.Eval(mergePlayerScript, []string{m.playerKey()}, playerID, string(data)); err != nil {
+		return err
+	}
+
+	for _, period := range append([]int32{define.RankingPeriodAll}, rankingPeriods...) {
+		periodID, start, end, ok := m.periodOf(period, now)
+		if !ok {
+			continue
+		}
+
+		score, ok, err := scoreOf(period, start, end)
+		if err != nil {
+			return err
+		}
 
-	// 更新排名
-	for i := range rankingList.Items {
-		rankingList.Items[i].Rank = i + 1
+		if !ok {
+			continue
+		}
+
+		// 周期结束后保留一段时间用于结算，总榜不过期
+		var expire int64
+		if period != define.RankingPeriodAll {
+			expire = (end.Sub(now) + m.retention).Milliseconds()
+		}
+
+		composite, integer := m.encodeScore(rankingType, score, level, now)
+		_, err = m.client.Eval(updateScoreScript, []string{m.scoreKey(rankingType, period, periodID)},
+			playerID, composite, integer, expire)
+		if err != nil {
+			return err
+		}
 	}
+
+	return nil
 }
 
-func (m *RankingManager) initRankings() {
-	now := time.Now().Unix()
+// 编码复合分数，返回复合分数及其整数部分
+func (m *RankingManager) encodeScore(rankingType int32, score, level int, achieveTime time.Time) (float64, int64) {
+	integer := int64(min(max(score, 0), scoreMaxValue))
+	if m.secondaryLevel[rankingType] {
+		integer = integer<<scoreLevelBits | int64(min(max(level, 0), scoreMaxLevel))
+	}
+
+	elapsed := min(max(achieveTime.Unix()-scoreTimeBase, 0), scoreTimeSpan)
+	fraction := float64(scoreTimeSpan-elapsed) / float64(scoreTimeSpan+1)
 
-	// 初始化等级排行榜
-	levelRanking := &RankingList{
-		Type:    1,
-		Items:   make([]*RankingItem, 0),
-		Players: make(map[string]*RankingItem),
+	// 按整数部分占用的位数截断小数部分
+	precision := 52 - bits.Len64(uint64(integer))
+	if precision <= 0 {
+		return float64(integer), integer
 	}
+	scale := math.Ldexp(1, precision)
+
+	return float64(integer) + math.Floor(fraction*scale)/scale, integer
+}
 
-	// 添加示例数据
-	levelPlayers := []*RankingItem{
-		{PlayerID: "player101", Nickname: "战神", Level: 100, Score: 100, Avatar: "avatar1", UpdateTime: now},
-		{PlayerID: "player102", Nickname: "法神", Level: 98, Score: 98, Avatar: "avatar2", UpdateTime: now},
-		{PlayerID: "player103", Nickname: "道士", Level: 97, Score: 97, Avatar: "avatar3", UpdateTime: now},
-		{PlayerID: "player104", Nickname: "猎手", Level: 95, Score: 95, Avatar: "avatar4", UpdateTime: now},
-		{PlayerID: "player105", Nickname: "刺客", Level: 93, Score: 93, Avatar: "avatar5", UpdateTime: now},
-		{PlayerID: "player106", Nickname: "战士", Level: 92, Score: 92, Avatar: "avatar6", UpdateTime: now},
-		{PlayerID: "player107", Nickname: "法师", Level: 90, Score: 90, Avatar: "avatar7", UpdateTime: now},
-		{PlayerID: "player108", Nickname: "牧师", Level: 88, Score: 88, Avatar: "avatar8", UpdateTime: now},
-		{PlayerID: "player109", Nickname: "德鲁伊", Level: 85, Score: 85, Avatar: "avatar9", UpdateTime: now},
-		{PlayerID: "player110", Nickname: "盗贼", Level: 82, Score: 82, Avatar: "avatar10", UpdateTime: now},
+// 从复合分数中解码分数
+func (m *RankingManager) decodeScore(rankingType int32, composite float64) int {
+	integer := int64(math.Floor(composite))
+	if m.secondaryLevel[rankingType] {
+		integer >>= scoreLevelBits
 	}
 
-	for _, player := range levelPlayers {
-		levelRanking.Items = append(levelRanking.Items, player)
-		levelRanking.Players[player.PlayerID] = player
+	return int(integer)
+}
+
+// Serve 启动周期结算循环，集群内每个周期只结算一次
+func (m *RankingManager) Serve() {
+	go func() {
+		ticker := time.NewTicker(m.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-m.done:
+				return
+			case <-ticker.C:
+				m.rollover(time.Now())
+			}
+		}
+	}()
+}
+
+// Stop 停止周期结算循环
+func (m *RankingManager) Stop() {
+	select {
+	case <-m.done:
+	default:
+		close(m.done)
 	}
+}
+
+// 结算所有排行榜的上一周期
+func (m *RankingManager) rollover(now time.Time) {
+	for _, period := range rankingPeriods {
+		_, start, _, ok := m.periodOf(period, now)
+		if !ok {
+			continue
+		}
+
+		periodID, prevStart, prevEnd, ok := m.periodOf(period, start.Add(-time.Nanosecond))
+		if !ok {
+			continue
+		}
 
-	m.rankings[int32(1)] = levelRanking
-	m.sortRankingList(levelRanking)
+		for _, rankingType := range rankingTypes {
+			if err := m.archive(rankingType, period, periodID, prevStart, prevEnd); err != nil {
+				log.Errorf("archive ranking failed: ranking_type=%d, period=%d, period_id=%s, err=%v",
+					rankingType, period, periodID, err)
+				continue
+			}
 
-	// 初始化战力排行榜
-	powerRanking := &RankingList{
-		Type:    int32(2),
-		Items:   make([]*RankingItem, 0),
-		Players: make(map[string]*RankingItem),
+			if period != define.RankingPeriodSeason {
+				continue
+			}
+
+			if err := m.distribute(rankingType, periodID); err != nil {
+				log.Errorf("distribute ranking rewards failed: ranking_type=%d, period_id=%s, err=%v",
+					rankingType, periodID, err)
+			}
+		}
 	}
+}
 
-	// 添加示例数据
-	powerPlayers := []*RankingItem{
-		{PlayerID: "player201", Nickname: "无敌战神", Level: 95, Score: 25000, Avatar: "avatar11", UpdateTime: now},
-		{PlayerID: "player202", Nickname: "力量之王", Level: 94, Score: 24500, Avatar: "avatar12", UpdateTime: now},
-		{PlayerID: "player203", Nickname: "魔法大师", Level: 93, Score: 24000, Avatar: "avatar13", UpdateTime: now},
-		{PlayerID: "player204", Nickname: "敏捷猎手", Level: 92, Score: 23500, Avatar: "avatar14", UpdateTime: now},
-		{PlayerID: "player205", Nickname: "暗影刺客", Level: 91, Score: 23000, Avatar: "avatar15", UpdateTime: now},
-		{PlayerID: "player206", Nickname: "光明使者", Level: 90, Score: 22500, Avatar: "avatar16", UpdateTime: now},
-		{PlayerID: "player207", Nickname: "自然守护者", Level: 89, Score: 22000, Avatar: "avatar17", UpdateTime: now},
-		{PlayerID: "player208", Nickname: "元素使", Level: 88, Score: 21500, Avatar: "avatar18", UpdateTime: now},
-		{PlayerID: "player209", Nickname: "死亡骑士", Level: 87, Score: 21000, Avatar: "avatar19", UpdateTime: now},
-		{PlayerID: "player210", Nickname: "圣骑士", Level: 86, Score: 20500, Avatar: "avatar20", UpdateTime: now},
+// 将周期排行榜的最终排名存入结算快照，已结算时跳过
+func (m *RankingManager) archive(rankingType, period int32, periodID string, start, end time.Time) error {
+	id := archiveID(rankingType, period, periodID)
+
+	count, err := m.archives.CountDocuments(bson.M{"_id": id})
+	if err != nil || count > 0 {
+		return err
 	}
 
-	for _, player := range powerPlayers {
-		powerRanking.Items = append(powerRanking.Items, player)
-		powerRanking.Players[player.PlayerID] = player
+	ok, err := m.client.SetNX(fmt.Sprintf("%s:archive_lock:%s", m.prefix, id), 1, m.interval)
+	if err != nil || !ok {
+		return err
 	}
 
-	m.rankings[int32(2)] = powerRanking
-	m.sortRankingList(powerRanking)
+	key := m.scoreKey(rankingType, period, periodID)
 
-	// 初始化财富排行榜
-	wealthRanking := &RankingList{
-		Type:    int32(3),
-		Items:   make([]*RankingItem, 0),
-		Players: make(map[string]*RankingItem),
+	total, err := m.client.ZCard(key)
+	if err != nil || total == 0 {
+		return err
 	}
 
-	// 添加示例数据
-	wealthPlayers := []*RankingItem{
-		{PlayerID: "player301", Nickname: "富翁", Level: 85, Score: 500000, Avatar: "avatar21", UpdateTime: now},
-		{PlayerID: "player302", Nickname: "富豪", Level: 80, Score: 450000, Avatar: "avatar22", UpdateTime: now},
-		{PlayerID: "player303", Nickname: "财主", Level: 75, Score: 400000, Avatar: "avatar23", UpdateTime: now},
-		{PlayerID: "player304", Nickname: "商人", Level: 70, Score: 350000, Avatar: "avatar24", UpdateTime: now},
-		{PlayerID: "player305", Nickname: "理财师", Level: 65, Score: 300000, Avatar: "avatar25", UpdateTime: now},
+	members, err := m.client.ZRevRangeWithScores(key, 0, m.archiveSize-1)
+	if err != nil {
+		return err
+	}
+
+	rankings := make([]*RankingItem, len(members))
+	for i, member := range members {
+		rankings[i] = &RankingItem{
+			PlayerID:  xconv.String(member.Member),
+			Rank:      i + 1,
+			Score:     m.decodeScore(rankingType, member.Score),
+			Composite: member.Score,
+		}
 	}
 
-	for _, player := range wealthPlayers {
-		wealthRanking.Items = append(wealthRanking.Items, player)
-		wealthRanking.Players[player.PlayerID] = player
+	if err = m.fillPlayers(rankings); err != nil {
+		return err
 	}
 
-	m.rankings[int32(3)] = wealthRanking
-	m.sortRankingList(wealthRanking)
+	items := make([]define.RankingArchiveItem, len(rankings))
+	for i, rank := range rankings {
+		items[i] = define.RankingArchiveItem{
+			Rank:     rank.Rank,
+			PlayerID: rank.PlayerID,
+			Nickname: rank.Nickname,
+			Avatar:   rank.Avatar,
+			Level:    rank.Level,
+			Score:    int64(rank.Score),
+		}
+	}
 
-	// 初始化击杀排行榜
-	killRanking := &RankingList{
-		Type:    int32(4),
-		Items:   make([]*RankingItem, 0),
-		Players: make(map[string]*RankingItem),
+	_, err = m.archives.InsertOne(&define.RankingArchive{
+		ID:          id,
+		RankingType: rankingType,
+		Period:      period,
+		PeriodID:    periodID,
+		Total:       total,
+		Items:       items,
+		StartTime:   start,
+		EndTime:     end,
+		ArchiveTime: time.Now(),
+	})
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		return err
 	}
 
-	// 添加示例数据
-	killPlayers := []*RankingItem{
-		{PlayerID: "player401", Nickname: "杀人王", Level: 90, Score: 5000, Avatar: "avatar26", UpdateTime: now},
-		{PlayerID: "player402", Nickname: "刽子手", Level: 88, Score: 4500, Avatar: "avatar27", UpdateTime: now},
-		{PlayerID: "player403", Nickname: "狙击手", Level: 85, Score: 4000, Avatar: "avatar28", UpdateTime: now},
-		{PlayerID: "player404", Nickname: "终结者", Level: 82, Score: 3500, Avatar: "avatar29", UpdateTime: now},
-		{PlayerID: "player405", Nickname: "屠夫", Level: 80, Score: 3000, Avatar: "avatar30", UpdateTime: now},
+	log.Infof("Ranking archived: ranking_type=%d, period=%d, period_id=%s, total=%d", rankingType, period, periodID, total)
+
+	return nil
+}
+
+// 按结算快照通过邮件发放赛季奖励；邮件使用幂等键发送，中途失败后重新执行不会重复发放
+func (m *RankingManager) distribute(rankingType int32, periodID string) error {
+	if m.sendMail == nil || len(m.rewards) == 0 {
+		return nil
 	}
 
-	for _, player := range killPlayers {
-		killRanking.Items = append(killRanking.Items, player)
-		killRanking.Players[player.PlayerID] = player
+	id := archiveID(rankingType, define.RankingPeriodSeason, periodID)
+
+	count, err := m.rewardRecords.CountDocuments(bson.M{"_id": id})
+	if err != nil || count > 0 {
+		return err
+	}
+
+	ok, err := m.client.SetNX(fmt.Sprintf("%s:reward_lock:%s", m.prefix, id), 1, m.interval)
+	if err != nil || !ok {
+		return err
+	}
+
+	archive := &define.RankingArchive{}
+	if err = m.archives.FindOne(bson.M{"_id": id}, archive); err != nil {
+		// 榜单无人上榜时没有结算快照
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil
+		}
+		return err
+	}
+
+	// 每个玩家按第一个匹配的名次区间发放奖励
+	receivers := make([][]string, len(m.rewards))
+	for _, item := range archive.Items {
+		for i, reward := range m.rewards {
+			if reward.RankingType != 0 && reward.RankingType != rankingType {
+				continue
+			}
+			if item.Rank >= reward.MinRank && item.Rank <= reward.MaxRank {
+				receivers[i] = append(receivers[i], item.PlayerID)
+				break
+			}
+		}
+	}
+
+	sent := 0
+	for i, reward := range m.rewards {
+		for start := 0; start < len(receivers[i]); start += maxMailReceivers {
+			end := start + maxMailReceivers
+			if end > len(receivers[i]) {
+				end = len(receivers[i])
+			}
+
+			err = m.sendMail(&pb.SendMailRequest{
+				PlayerIds:      receivers[i][start:end],
+				Title:          "赛季排行榜奖励",
+				Content:        fmt.Sprintf("恭喜您在%s赛季%s排行榜中获得第%d-%d名，请领取奖励。", periodID, rankingTypeNames[rankingType], reward.MinRank, reward.MaxRank),
+				Attachments:    toRewardAttachments(&reward),
+				IdempotencyKey: "ranking_reward:" + id,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		sent += len(receivers[i])
+	}
+
+	_, err = m.rewardRecords.InsertOne(bson.M{"_id": id, "receivers": sent, "finish_time": time.Now()})
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		return err
+	}
+
+	log.Infof("Ranking rewards distributed: ranking_type=%d, period_id=%s, receivers=%d", rankingType, periodID, sent)
+
+	return nil
+}
+
+// 转换名次奖励为邮件附件
+func toRewardAttachments(reward *RankingReward) []*pb.MailAttachment {
+	attachments := make([]*pb.MailAttachment, 0, len(reward.Items)+2)
+	for _, item := range reward.Items {
+		attachments = append(attachments, &pb.MailAttachment{
+			Type:   AttachmentTypeItem,
+			ItemId: int32(item.ItemID),
+			Count:  int32(item.Count),
+		})
+	}
+
+	if reward.Coin > 0 {
+		attachments = append(attachments, &pb.MailAttachment{Type: AttachmentTypeCoin, Count: reward.Coin})
+	}
+
+	if reward.Diamond > 0 {
+		attachments = append(attachments, &pb.MailAttachment{Type: AttachmentTypeDiamond, Count: reward.Diamond})
+	}
+
+	return attachments
+}
+
+// 从结算快照中分页查询历史周期排行榜
+func (m *RankingManager) getArchivedList(rankingType, period int32, periodID string, page, pageSize int) ([]*RankingItem, int64, error) {
+	archive := &define.RankingArchive{}
+	if err := m.archives.FindOne(bson.M{"_id": archiveID(rankingType, period, periodID)}, archive); err != nil {
+		return nil, 0, err
+	}
+
+	total := len(archive.Items)
+	start := (page - 1) * pageSize
+	if start > total {
+		start = total
+	}
+	end := start + pageSize
+	if end > total {
+		end = total
+	}
+
+	items := make([]*RankingItem, 0, end-start)
+	for _, item := range archive.Items[start:end] {
+		items = append(items, &RankingItem{
+			PlayerID:   item.PlayerID,
+			Nickname:   item.Nickname,
+			Rank:       item.Rank,
+			Score:      int(item.Score),
+			Level:      item.Level,
+			Avatar:     item.Avatar,
+			UpdateTime: archive.ArchiveTime.Unix(),
+		})
+	}
+
+	return items, int64(total), nil
+}
+
+// 从结算快照中查询玩家在历史周期的排名
+func (m *RankingManager) getArchivedRank(playerID string, rankingType, period int32, periodID string) (*RankingItem, int64, error) {
+	archive := &define.RankingArchive{}
+	if err := m.archives.FindOne(bson.M{"_id": archiveID(rankingType, period, periodID)}, archive); err != nil {
+		return nil, 0, err
+	}
+
+	for _, item := range archive.Items {
+		if item.PlayerID == playerID {
+			return &RankingItem{
+				PlayerID:   item.PlayerID,
+				Nickname:   item.Nickname,
+				Rank:       item.Rank,
+				Score:      int(item.Score),
+				Level:      item.Level,
+				Avatar:     item.Avatar,
+				UpdateTime: archive.ArchiveTime.Unix(),
+			}, archive.Total, nil
+		}
+	}
+
+	return nil, 0, ErrNotRanked
+}
+
+// 计算时间所在的周期ID及起止时间；赛季开始前没有赛季榜
+func (m *RankingManager) periodOf(period int32, t time.Time) (id string, start, end time.Time, ok bool) {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+
+	switch period {
+	case define.RankingPeriodAll:
+		return "", time.Time{}, time.Time{}, true
+	case define.RankingPeriodDaily:
+		return day.Format("20060102"), day, day.AddDate(0, 0, 1), true
+	case define.RankingPeriodWeekly:
+		// 周一为每周第一天
+		start = day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+		year, week := start.ISOWeek()
+		return fmt.Sprintf("%dW%02d", year, week), start, start.AddDate(0, 0, 7), true
+	case define.RankingPeriodSeason:
+		if t.Before(m.seasonStart) {
+			return "", time.Time{}, time.Time{}, false
+		}
+		n := int64(t.Sub(m.seasonStart) / m.seasonDuration)
+		start = m.seasonStart.Add(time.Duration(n) * m.seasonDuration)
+		return fmt.Sprintf("S%d", n+1), start, start.Add(m.seasonDuration), true
+	}
+
+	return "", time.Time{}, time.Time{}, false
+}
+
+// 批量填充排行榜项的玩家信息
+func (m *RankingManager) fillPlayers(items []*RankingItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	playerIDs := make([]string, len(items))
+	for i, item := range items {
+		playerIDs[i] = item.PlayerID
+	}
+
+	values, err := m.client.HMGet(m.playerKey(), playerIDs...)
+	if err != nil {
+		return err
+	}
+
+	for i, value := range values {
+		if value == nil {
+			continue
+		}
+
+		player := &rankingPlayer{}
+		if err = json.Unmarshal([]byte(xconv.String(value)), player); err != nil {
+			log.Warnf("decode ranking player failed: player_id=%s, err=%v", items[i].PlayerID, err)
+			continue
+		}
+
+		items[i].Nickname = player.Nickname
+		items[i].Avatar = player.Avatar
+		items[i].Level = player.Level
+		items[i].UpdateTime = player.UpdateTime
+	}
+
+	return nil
+}
+
+func (m *RankingManager) scoreKey(rankingType, period int32, periodID string) string {
+	if period == define.RankingPeriodAll {
+		return fmt.Sprintf("%s:score:%d", m.prefix, rankingType)
+	}
+	return fmt.Sprintf("%s:score:%d:%d:%s", m.prefix, rankingType, period, periodID)
+}
+
+func (m *RankingManager) playerKey() string {
+	return fmt.Sprintf("%s:player", m.prefix)
+}
+
+// 排序规则：复合分数降序，相同时按玩家ID逆序，与有序集合的逆序排列一致
+func rankingLess(a, b *RankingItem) bool {
+	if a.Composite != b.Composite {
+		return a.Composite > b.Composite
+	}
+	return a.PlayerID > b.PlayerID
+}
+
+// 修正附近排名人数
+func clampAroundSize(size int) int {
+	if size <= 0 {
+		return defaultAroundSize
+	}
+	if size > maxAroundSize {
+		return maxAroundSize
+	}
+	return size
+}
+
+// 结算快照ID
+func archiveID(rankingType, period int32, periodID string) string {
+	return fmt.Sprintf("%d:%d:%s", rankingType, period, periodID)
+}
+
+// 排行榜类型是否有效
+func isValidRankingType(rankingType int32) bool {
+	for _, t := range rankingTypes {
+		if t == rankingType {
+			return true
+		}
 	}
 
-	m.rankings[int32(4)] = killRanking
-	m.sortRankingList(killRanking)
+	return false
 }
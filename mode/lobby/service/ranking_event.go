@@ -0,0 +1,277 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"ghserver/define"
+	"ghserver/utils/kafka"
+	"ghserver/utils/mongodb"
+
+	"github.com/dobyte/due/v2/etc"
+	"github.com/dobyte/due/v2/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	battleRecordCollection = "battle_record" // 战斗记录集合
+	defaultMinBattles      = 10              // 默认进入胜率榜的最少场次
+	consumeRetryInterval   = time.Second     // 消费失败后的重试间隔
+	maxHandleRetryInterval = time.Minute     // 处理事件失败后的最大重试间隔
+)
+
+// 战斗记录汇总
+type battleStats struct {
+	Total int64 `bson:"total"`
+	Wins  int64 `bson:"wins"`
+	Kills int64 `bson:"kills"`
+}
+
+// RankingConsumer 排行榜事件消费者，根据战斗结算及玩家成长事件更新排行榜
+type RankingConsumer struct {
+	rankingManager *RankingManager
+	records        *mongodb.MongoDBClient
+	players        *mongodb.MongoDBClient
+	battle         *kafka.KafkaConsumer
+	player         *kafka.KafkaConsumer
+	minBattles     int64
+	cancel         context.CancelFunc
+}
+
+func NewRankingConsumer(rankingManager *RankingManager, database string) (*RankingConsumer, error) {
+	records, err := mongodb.NewMongoDBClient(database, battleRecordCollection)
+	if err != nil {
+		return nil, err
+	}
+
+	// 按玩家汇总周期内战斗记录的索引
+	if err = records.EnsureIndex("idx_player_end_time", bson.D{{Key: "player_id", Value: 1}, {Key: "end_time", Value: 1}}, false); err != nil {
+		return nil, err
+	}
+
+	players, err := mongodb.NewMongoDBClient(database, playerCollection)
+	if err != nil {
+		return nil, err
+	}
+
+	brokers := etc.Get("etc.kafka.default.brokers", []string{"localhost:9092"}).Strings()
+	groupID := etc.Get("etc.ranking.events.groupID", "lobby-ranking").String()
+
+	c := &RankingConsumer{
+		rankingManager: rankingManager,
+		records:        records,
+		players:        players,
+		battle:         kafka.NewKafkaConsumer(brokers, etc.Get("etc.ranking.events.battleTopic", "battle_data_topic").String(), groupID, 0),
+		player:         kafka.NewKafkaConsumer(brokers, etc.Get("etc.ranking.events.playerTopic", "player_event_topic").String(), groupID, 0),
+		minBattles:     etc.Get("etc.ranking.events.minBattles", defaultMinBattles).Int64(),
+	}
+
+	if c.minBattles <= 0 {
+		c.minBattles = defaultMinBattles
+	}
+
+	return c, nil
+}
+
+// Start 开始消费事件
+func (c *RankingConsumer) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+
+	go c.consume(ctx, "battle", c.battle, c.retry(ctx, "battle", c.handleBattleEvent))
+	go c.consume(ctx, "player", c.player, c.retry(ctx, "player", c.handlePlayerEvent))
+}
+
+// Stop 停止消费事件
+func (c *RankingConsumer) Stop() {
+	if c.cancel != nil {
+		c.cancel()
+	}
+
+	if err := c.battle.Close(); err != nil {
+		log.Warnf("close battle event consumer failed: %v", err)
+	}
+
+	if err := c.player.Close(); err != nil {
+		log.Warnf("close player event consumer failed: %v", err)
+	}
+}
+
+// 持续消费，读取失败时等待后重试
+func (c *RankingConsumer) consume(ctx context.Context, name string, consumer *kafka.KafkaConsumer, handler kafka.MessageHandler) {
+	for {
+		err := consumer.Consume(ctx, handler)
+		if ctx.Err() != nil {
+			return
+		}
+
+		log.Errorf("consume %s events failed: %v", name, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(consumeRetryInterval):
+		}
+	}
+}
+
+// 处理事件失败时按退避间隔重试直至成功或停止消费，失败的事件不提交偏移量，不会被后续事件跳过
+func (c *RankingConsumer) retry(ctx context.Context, name string, handler kafka.MessageHandler) kafka.MessageHandler {
+	return func(message []byte) error {
+		interval := consumeRetryInterval
+		for {
+			err := handler(message)
+			if err == nil {
+				return nil
+			}
+
+			log.Errorf("handle %s event failed, retry in %v: %v", name, interval, err)
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(interval):
+			}
+
+			interval = min(interval*2, maxHandleRetryInterval)
+		}
+	}
+}
+
+// 处理战斗结算事件：保存战斗记录后按汇总结果更新击杀榜及胜率榜，重复消费不会重复计数
+func (c *RankingConsumer) handleBattleEvent(message []byte) error {
+	event := &define.BattleEvent{}
+	if err := json.Unmarshal(message, event); err != nil {
+		log.Errorf("decode battle event failed: %v", err)
+		return nil
+	}
+
+	for _, record := range event.Records {
+		if record.PlayerID == "" {
+			continue
+		}
+
+		if record.ID == "" {
+			record.ID = event.BattleID + ":" + record.PlayerID
+		}
+
+		if record.RoomID == "" {
+			record.RoomID = event.BattleID
+		}
+
+		_, err := c.records.GetCollection().ReplaceOne(context.Background(), bson.M{"_id": record.ID}, &record,
+			options.Replace().SetUpsert(true))
+		if err != nil {
+			return err
+		}
+
+		if err = c.updateBattleRankings(record.PlayerID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// 处理玩家成长事件，更新等级榜、战斗力榜或财富榜
+func (c *RankingConsumer) handlePlayerEvent(message []byte) error {
+	event := &define.PlayerEvent{}
+	if err := json.Unmarshal(message, event); err != nil {
+		log.Errorf("decode player event failed: %v", err)
+		return nil
+	}
+
+	var (
+		rankingType int32
+		score       int
+	)
+
+	switch event.Type {
+	case define.PlayerEventLevelUp:
+		rankingType, score = define.RankingTypeLevel, event.Level
+	case define.PlayerEventPower:
+		rankingType, score = define.RankingTypePower, event.Power
+	case define.PlayerEventWealth:
+		rankingType, score = define.RankingTypeWealth, int(event.Coin)
+	default:
+		log.Warnf("unknown player event: type=%d, player_id=%s", event.Type, event.PlayerID)
+		return nil
+	}
+
+	return c.rankingManager.UpdatePlayerScore(event.PlayerID, event.Nickname, event.Avatar, event.Level, score, rankingType)
+}
+
+// 按周期汇总玩家的战斗记录，更新各周期的击杀榜及胜率榜（万分比），周期内场次不足时不进入该周期的胜率榜
+func (c *RankingConsumer) updateBattleRankings(playerID string) error {
+	player := &define.Player{}
+	if err := c.players.FindOne(bson.M{"_id": playerID}, player); err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return err
+	}
+
+	// 击杀榜与胜率榜共用同一周期的汇总结果
+	summaries := make(map[int32]battleStats, len(rankingPeriods)+1)
+	statsOf := func(period int32, start, end time.Time) (battleStats, error) {
+		if stats, ok := summaries[period]; ok {
+			return stats, nil
+		}
+
+		stats, err := c.battleStats(playerID, start, end)
+		if err != nil {
+			return stats, err
+		}
+		summaries[period] = stats
+
+		return stats, nil
+	}
+
+	err := c.rankingManager.UpdatePeriodScores(playerID, player.Nickname, "", player.Level, define.RankingTypeKill,
+		func(period int32, start, end time.Time) (int, bool, error) {
+			stats, err := statsOf(period, start, end)
+			return int(stats.Kills), stats.Total > 0, err
+		})
+	if err != nil {
+		return err
+	}
+
+	return c.rankingManager.UpdatePeriodScores(playerID, player.Nickname, "", player.Level, define.RankingTypeWinRate,
+		func(period int32, start, end time.Time) (int, bool, error) {
+			stats, err := statsOf(period, start, end)
+			if err != nil || stats.Total < c.minBattles {
+				return 0, false, err
+			}
+			return int(stats.Wins * 10000 / stats.Total), true, nil
+		})
+}
+
+// 汇总玩家在[start, end)内结束的战斗记录，起止时间为零值时汇总全部记录
+func (c *RankingConsumer) battleStats(playerID string, start, end time.Time) (battleStats, error) {
+	match := bson.M{"player_id": playerID}
+	if !start.IsZero() {
+		match["end_time"] = bson.M{"$gte": start, "$lt": end}
+	}
+
+	pipeline := bson.A{
+		bson.M{"$match": match},
+		bson.M{"$group": bson.M{
+			"_id":   nil,
+			"total": bson.M{"$sum": 1},
+			"wins":  bson.M{"$sum": bson.M{"$cond": bson.A{"$result", 1, 0}}},
+			"kills": bson.M{"$sum": "$kill_count"},
+		}},
+	}
+
+	results := make([]battleStats, 0, 1)
+	if err := c.records.Aggregate(pipeline, &results); err != nil {
+		return battleStats{}, err
+	}
+
+	if len(results) == 0 {
+		return battleStats{}, nil
+	}
+
+	return results[0], nil
+}
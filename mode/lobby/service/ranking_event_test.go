@@ -0,0 +1,42 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRankingConsumerRetry(t *testing.T) {
+	c := &RankingConsumer{}
+
+	calls := 0
+	handler := c.retry(context.Background(), "test", func([]byte) error {
+		if calls++; calls == 1 {
+			return errors.New("temporary failure")
+		}
+		return nil
+	})
+
+	if err := handler(nil); err != nil {
+		t.Fatalf("handler returned %v, want nil", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("handler calls = %d, want 2", calls)
+	}
+}
+
+// 停止消费时不再重试，返回错误使偏移量不被提交
+func TestRankingConsumerRetryCanceled(t *testing.T) {
+	c := &RankingConsumer{}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	handler := c.retry(ctx, "test", func([]byte) error {
+		cancel()
+		return errors.New("permanent failure")
+	})
+
+	if err := handler(nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("handler returned %v, want %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"fmt"
+	"sync"
+)
+
+// 测试钱包，按幂等键去重，余额不足时返回ErrInsufficientBalance
+type fakeWallet struct {
+	mutex    sync.Mutex
+	initial  int64            // 玩家初始余额
+	balances map[string]int64 // player_id:currency_type -> balance
+	keys     map[string]int64 // idempotency_key -> balance
+	credited int64            // 累计增加
+	debited  int64            // 累计扣除
+	failures int              // 接下来增加货币失败的次数，模拟钱包服务不可用
+}
+
+func newFakeWallet(initial int64) *fakeWallet {
+	return &fakeWallet{
+		initial:  initial,
+		balances: make(map[string]int64),
+		keys:     make(map[string]int64),
+	}
+}
+
+func (w *fakeWallet) Credit(playerID string, currencyType int32, amount int64, reason int32, idempotencyKey, remark string) (int64, error) {
+	w.mutex.Lock()
+	if w.failures > 0 {
+		w.failures--
+		w.mutex.Unlock()
+		return 0, ErrWalletFailed
+	}
+	w.mutex.Unlock()
+
+	return w.change(playerID, currencyType, amount, idempotencyKey)
+}
+
+func (w *fakeWallet) Debit(playerID string, currencyType int32, amount int64, reason int32, idempotencyKey, remark string) (int64, error) {
+	return w.change(playerID, currencyType, -amount, idempotencyKey)
+}
+
+func (w *fakeWallet) change(playerID string, currencyType int32, amount int64, idempotencyKey string) (int64, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if balance, ok := w.keys[idempotencyKey]; ok && idempotencyKey != "" {
+		return balance, nil
+	}
+
+	account := fmt.Sprintf("%s:%d", playerID, currencyType)
+	balance, ok := w.balances[account]
+	if !ok {
+		balance = w.initial
+	}
+
+	if balance+amount < 0 {
+		return balance, ErrInsufficientBalance
+	}
+
+	balance += amount
+	w.balances[account] = balance
+	w.keys[idempotencyKey] = balance
+
+	if amount > 0 {
+		w.credited += amount
+	} else {
+		w.debited -= amount
+	}
+
+	return balance, nil
+}
+
+// 累计增加和扣除的货币
+func (w *fakeWallet) totals() (credited, debited int64) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return w.credited, w.debited
+}
+
+// 玩家当前余额
+func (w *fakeWallet) balance(playerID string, currencyType int32) int64 {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if balance, ok := w.balances[fmt.Sprintf("%s:%d", playerID, currencyType)]; ok {
+		return balance
+	}
+
+	return w.initial
+}
@@ -4,13 +4,21 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync/atomic"
 	"time"
 
+	"ghserver/define"
 	"ghserver/proto/pb"
+	"ghserver/utils/mongodb"
+	"ghserver/utils/pricing"
 
 	"github.com/dobyte/due/v2/cluster/node"
 	"github.com/dobyte/due/v2/codes"
+	"github.com/dobyte/due/v2/config"
+	"github.com/dobyte/due/v2/etc"
 	"github.com/dobyte/due/v2/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 // ShopServer 商场服务
@@ -22,17 +30,28 @@ type ShopServer struct {
 
 func NewShopServer(proxy *node.Proxy) *ShopServer {
 	return &ShopServer{
-		proxy:       proxy,
-		shopManager: NewShopManager(),
+		proxy: proxy,
 	}
 }
 
 func (s *ShopServer) Init() {
+	// 创建商场管理器并启动货币补发循环
+	shopManager, err := NewShopManager(etc.Get("etc.mongo.default.database", "game").String(),
+		etc.Get("etc.shop.table", defaultShopTable).String(),
+		etc.Get("etc.shop.campaignTable", defaultCampaignTable).String(),
+		etc.Get("etc.shop.rotationTable", defaultRotationTable).String(), NewWalletClient(s.proxy))
+	if err != nil {
+		log.Fatalf("create shop manager failed: %v", err)
+	}
+	s.shopManager = shopManager
+	s.shopManager.Serve()
+
 	s.proxy.AddServiceProvider("shop", &pb.ShopService_ServiceDesc, s)
 }
 
 func (s *ShopServer) Close() error {
-	// 清理资源
+	// 停止货币补发循环
+	s.shopManager.Stop()
 	return nil
 }
 
@@ -40,29 +59,20 @@ func (s *ShopServer) GetShopList(ctx context.Context, req *pb.GetShopListRequest
 	log.Debugf("Get shop list request: player_id=%s, shop_type=%d", req.PlayerId, req.ShopType)
 
 	// 获取商店列表
-	items := s.shopManager.GetShopList(req.PlayerId, int(req.ShopType))
-
-	// 转换为响应格式
-	shopItems := make([]*pb.ShopItem, len(items))
-	for i, item := range items {
-		shopItems[i] = &pb.ShopItem{
-			ItemId:        int32(item.ItemID),
-			OriginalPrice: int32(item.OriginalPrice),
-			CurrentPrice:  int32(item.CurrentPrice),
-			CurrencyType:  int32(item.CurrencyType),
-			Stock:         int32(item.Stock),
-			MaxBuyCount:   int32(item.MaxBuyCount),
-			BoughtCount:   int32(item.BoughtCount),
-			ExpireTime:    item.ExpireTime,
-			IsDiscount:    item.IsDiscount,
-			DiscountRate:  item.DiscountRate,
-		}
+	items, rotation, err := s.shopManager.GetShopList(req.PlayerId, int(req.ShopType))
+	if err != nil {
+		log.Errorf("get shop list failed: player_id=%s, err=%v", req.PlayerId, err)
+		return &pb.GetShopListResponse{
+			Code:    int32(codes.InternalError.Code()),
+			Message: "获取商店列表失败",
+		}, nil
 	}
 
 	return &pb.GetShopListResponse{
-		Code:    int32(codes.OK.Code()),
-		Message: "获取商店列表成功",
-		Items:   shopItems,
+		Code:     int32(codes.OK.Code()),
+		Message:  "获取商店列表成功",
+		Items:    toPBShopItems(items),
+		Rotation: toPBRotationInfo(rotation),
 	}, nil
 }
 
@@ -70,24 +80,45 @@ func (s *ShopServer) BuyItem(ctx context.Context, req *pb.BuyItemRequest) (*pb.B
 	log.Debugf("Buy item request: player_id=%s, item_id=%d, count=%d", req.PlayerId, req.ItemId, req.Count)
 
 	// 购买物品
-	boughtItems, spentCurrency, currencyType, err := s.shopManager.BuyItem(req.PlayerId, int(req.ItemId), int(req.Count))
+	result, err := s.shopManager.BuyItem(req.PlayerId, int(req.ShopType), int(req.ItemId), int(req.Count), int(req.ExpectedPrice), req.IdempotencyKey)
 	if err != nil {
-		return &pb.BuyItemResponse{
+		switch {
+		case errors.Is(err, ErrCoinNotEnough), errors.Is(err, ErrDiamondNotEnough):
+			return &pb.BuyItemResponse{
+				Code:    int32(define.InsufficientBalance.Code()),
+				Message: err.Error(),
+			}, nil
+		case errors.Is(err, ErrOrderConflict):
+			return &pb.BuyItemResponse{
+				Code:    int32(define.IdempotencyConflict.Code()),
+				Message: err.Error(),
+			}, nil
+		case errors.Is(err, ErrInvalidBuyCount), errors.Is(err, ErrShopItemNotFound), errors.Is(err, ErrShopItemExpired),
+			errors.Is(err, ErrOutOfStock), errors.Is(err, ErrPurchaseLimit), errors.Is(err, ErrPriceChanged),
+			errors.Is(err, ErrShopRefreshed), errors.Is(err, ErrOrderPending):
+			return &pb.BuyItemResponse{
 				Code:    int32(codes.InvalidArgument.Code()),
 				Message: err.Error(),
-			},
-			nil
+			}, nil
+		}
+
+		log.Errorf("buy item failed: player_id=%s, item_id=%d, err=%v", req.PlayerId, req.ItemId, err)
+		return &pb.BuyItemResponse{
+			Code:    int32(codes.InternalError.Code()),
+			Message: "购买失败",
+		}, nil
 	}
 
-	// 转换购买的物品
-	bagItems := make([]*pb.BagItem, len(boughtItems))
-	for i, item := range boughtItems {
+	// 转换发放的物品，物品已堆叠到背包中同一物品上
+	now := time.Now().Unix()
+	bagItems := make([]*pb.BagItem, len(result.Grant.Items))
+	for i, item := range result.Grant.Items {
 		bagItems[i] = &pb.BagItem{
-			Id:         item.ID,
-			ItemId:     item.ItemID,
-			Count:      item.Count,
-			CreateTime: item.CreateTime,
-			Attrs:      item.Attrs,
+			Id:         bagItemID(req.PlayerId, item.ItemID),
+			ItemId:     int32(item.ItemID),
+			Count:      int32(item.Count),
+			CreateTime: now,
+			Attrs:      map[string]string{"source": "shop"},
 		}
 	}
 
@@ -95,8 +126,12 @@ func (s *ShopServer) BuyItem(ctx context.Context, req *pb.BuyItemRequest) (*pb.B
 		Code:          int32(codes.OK.Code()),
 		Message:       "购买成功",
 		BoughtItems:   bagItems,
-		SpentCurrency: int32(spentCurrency),
-		CurrencyType:  int32(currencyType),
+		SpentCurrency: int32(result.TotalPrice),
+		CurrencyType:  int32(result.CurrencyType),
+		GainedCoin:    result.Grant.Coin,
+		GainedDiamond: result.Grant.Diamond,
+		FirstBonus:    result.FirstBonus,
+		OrderId:       result.OrderID,
 	}, nil
 }
 
@@ -104,16 +139,32 @@ func (s *ShopServer) GetDiscountInfo(ctx context.Context, req *pb.GetDiscountInf
 	log.Debugf("Get discount info request: player_id=%s", req.PlayerId)
 
 	// 获取折扣信息
-	discounts := s.shopManager.GetDiscountInfo(req.PlayerId)
+	discounts, err := s.shopManager.GetDiscountInfo(req.PlayerId)
+	if err != nil {
+		log.Errorf("get discount info failed: player_id=%s, err=%v", req.PlayerId, err)
+		return &pb.GetDiscountInfoResponse{
+			Code:    int32(codes.InternalError.Code()),
+			Message: "获取折扣信息失败",
+		}, nil
+	}
 
 	// 转换为响应格式
 	discountInfos := make([]*pb.DiscountInfo, len(discounts))
 	for i, discount := range discounts {
+		itemIDs := make([]int32, len(discount.ItemIDs))
+		for j, itemID := range discount.ItemIDs {
+			itemIDs[j] = int32(itemID)
+		}
+
 		discountInfos[i] = &pb.DiscountInfo{
 			ShopType:     int32(discount.ShopType),
 			DiscountRate: discount.DiscountRate,
 			StartTime:    discount.StartTime,
 			EndTime:      discount.EndTime,
+			CampaignId:   discount.CampaignID,
+			Name:         discount.Name,
+			ItemIds:      itemIDs,
+			Stacking:     int32(discount.Stacking),
 		}
 	}
 
@@ -124,306 +175,713 @@ func (s *ShopServer) GetDiscountInfo(ctx context.Context, req *pb.GetDiscountInf
 	}, nil
 }
 
+func (s *ShopServer) RefreshShop(ctx context.Context, req *pb.RefreshShopRequest) (*pb.RefreshShopResponse, error) {
+	log.Debugf("Refresh shop request: player_id=%s, shop_type=%d", req.PlayerId, req.ShopType)
+
+	// 手动刷新轮换商店
+	items, rotation, err := s.shopManager.RefreshShop(req.PlayerId, int(req.ShopType), int(req.ExpectedCost))
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrCoinNotEnough), errors.Is(err, ErrDiamondNotEnough):
+			return &pb.RefreshShopResponse{
+				Code:    int32(define.InsufficientBalance.Code()),
+				Message: err.Error(),
+			}, nil
+		case errors.Is(err, ErrShopNotFound), errors.Is(err, ErrRefreshLimit), errors.Is(err, ErrRefreshCostChanged),
+			errors.Is(err, ErrShopRefreshed):
+			return &pb.RefreshShopResponse{
+				Code:    int32(codes.InvalidArgument.Code()),
+				Message: err.Error(),
+			}, nil
+		}
+
+		log.Errorf("refresh shop failed: player_id=%s, shop_type=%d, err=%v", req.PlayerId, req.ShopType, err)
+		return &pb.RefreshShopResponse{
+			Code:    int32(codes.InternalError.Code()),
+			Message: "刷新失败",
+		}, nil
+	}
+
+	return &pb.RefreshShopResponse{
+		Code:     int32(codes.OK.Code()),
+		Message:  "刷新成功",
+		Items:    toPBShopItems(items),
+		Rotation: toPBRotationInfo(rotation),
+	}, nil
+}
+
+func (s *ShopServer) RefundShopOrder(ctx context.Context, req *pb.RefundShopOrderRequest) (*pb.RefundShopOrderResponse, error) {
+	log.Infof("Refund shop order request: order_id=%s, operator=%s, reason=%s", req.OrderId, req.Operator, req.Reason)
+
+	// 订单退款
+	order, err := s.shopManager.RefundOrder(req.OrderId, req.Operator, req.Reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrOrderNotFound):
+			return &pb.RefundShopOrderResponse{
+				Code:    int32(codes.NotFound.Code()),
+				Message: err.Error(),
+			}, nil
+		case errors.Is(err, ErrOrderNotRefundable), errors.Is(err, ErrRefundItemUsed), errors.Is(err, ErrRefundBalance):
+			return &pb.RefundShopOrderResponse{
+				Code:    int32(codes.InvalidArgument.Code()),
+				Message: err.Error(),
+			}, nil
+		}
+
+		log.Errorf("refund shop order failed: order_id=%s, err=%v", req.OrderId, err)
+		return &pb.RefundShopOrderResponse{
+			Code:    int32(codes.InternalError.Code()),
+			Message: "退款失败",
+		}, nil
+	}
+
+	return &pb.RefundShopOrderResponse{
+		Code:    int32(codes.OK.Code()),
+		Message: "退款成功",
+		Order:   toPBShopOrder(order),
+	}, nil
+}
+
+// 转换订单为响应格式
+func toPBShopOrder(order *define.ShopOrder) *pb.ShopOrder {
+	return &pb.ShopOrder{
+		OrderId:      order.ID,
+		PlayerId:     order.PlayerID,
+		ShopType:     int32(order.ShopType),
+		ItemId:       int32(order.ItemID),
+		Count:        int32(order.Count),
+		TotalPrice:   order.TotalPrice,
+		CurrencyType: int32(order.CurrencyType),
+		Grant:        toPBShopGrant(&ShopGrant{Items: order.Items, Coin: order.Coin, Diamond: order.Diamond}),
+		FirstBonus:   order.FirstBonus,
+		Status:       int32(order.Status),
+		Remark:       order.Remark,
+		CreateTime:   order.CreateTime.Unix(),
+		UpdateTime:   order.UpdateTime.Unix(),
+	}
+}
+
+// 转换商店物品为响应格式
+func toPBShopItems(items []*ShopItem) []*pb.ShopItem {
+	shopItems := make([]*pb.ShopItem, len(items))
+	for i, item := range items {
+		shopItems[i] = &pb.ShopItem{
+			ItemId:         int32(item.ItemID),
+			OriginalPrice:  int32(item.OriginalPrice),
+			CurrentPrice:   int32(item.CurrentPrice),
+			CurrencyType:   int32(item.CurrencyType),
+			Stock:          int32(item.Stock),
+			MaxBuyCount:    int32(item.MaxBuyCount),
+			BoughtCount:    int32(item.BoughtCount),
+			ExpireTime:     item.ExpireTime,
+			IsDiscount:     item.IsDiscount,
+			DiscountRate:   item.DiscountRate,
+			LimitType:      int32(item.LimitType),
+			LimitResetTime: item.LimitResetTime,
+			Contents:       toPBShopGrant(item.Contents),
+			FirstBonus:     toPBShopGrant(item.FirstBonus),
+			FirstAvailable: item.FirstAvailable,
+		}
+	}
+
+	return shopItems
+}
+
+// 转换购买内容为响应格式
+func toPBShopGrant(grant *ShopGrant) *pb.ShopGrant {
+	if grant == nil {
+		return nil
+	}
+
+	items := make([]*pb.RewardItem, len(grant.Items))
+	for i, item := range grant.Items {
+		items[i] = &pb.RewardItem{ItemId: int32(item.ItemID), Count: int32(item.Count)}
+	}
+
+	return &pb.ShopGrant{Items: items, Coin: grant.Coin, Diamond: grant.Diamond}
+}
+
+// 转换轮换商店刷新信息为响应格式，普通商店返回nil
+func toPBRotationInfo(info *RotationInfo) *pb.RotationInfo {
+	if info == nil {
+		return nil
+	}
+
+	return &pb.RotationInfo{
+		NextRefreshTime:     info.NextRefreshTime,
+		RefreshCount:        int32(info.RefreshCount),
+		MaxRefreshCount:     int32(info.MaxRefreshCount),
+		RefreshCost:         int32(info.RefreshCost),
+		RefreshCurrencyType: int32(info.RefreshCurrencyType),
+	}
+}
+
 // ShopItem 商店物品
 type ShopItem struct {
-	ItemID        int
-	OriginalPrice int
-	CurrentPrice  int
-	CurrencyType  int // 1金币，2钻石
-	Stock         int
-	MaxBuyCount   int
-	BoughtCount   int
-	ExpireTime    int64
-	IsDiscount    bool
-	DiscountRate  float32
+	ShopType       int
+	ItemID         int
+	OriginalPrice  int
+	CurrentPrice   int
+	CurrencyType   int // 1金币，2钻石
+	Stock          int
+	MaxBuyCount    int
+	BoughtCount    int
+	ExpireTime     int64
+	IsDiscount     bool
+	DiscountRate   float32      // 展示用折扣率
+	Discount       pricing.Rate // 折扣率基点，价格按基点计算
+	LimitType      int          // 限购周期
+	LimitEvent     string       // 活动限购的活动ID
+	LimitResetTime int64        // 限购重置时间，0表示不重置
+	Contents       *ShopGrant   // 购买获得的内容，单个物品为物品本身
+	FirstBonus     *ShopGrant   // 首购奖励，nil表示没有
+	FirstAvailable bool         // 玩家是否可获得首购奖励
 }
 
 // DiscountInfo 折扣信息
 type DiscountInfo struct {
-	ShopType     int
+	ShopType     int // 0表示全部商店
 	DiscountRate float32
 	StartTime    int64
 	EndTime      int64
+	CampaignID   string
+	Name         string
+	ItemIDs      []int // 为空表示商店内全部物品
+	Stacking     int
 }
 
-// Item 背包物品
-type Item struct {
-	ID         string            // 物品实例ID
-	ItemID     int32             // 物品ID
-	Count      int32             // 数量
-	CreateTime int64             // 创建时间
-	Attrs      map[string]string // 物品属性
+// PurchaseResult 购买结果
+type PurchaseResult struct {
+	OrderID      string     // 订单ID，扣款及发货的幂等键
+	Grant        *ShopGrant // 实际发放的内容，含首购奖励
+	FirstBonus   bool       // 是否发放了首购奖励
+	TotalPrice   int
+	CurrencyType int
 }
 
-// PlayerCurrency 玩家货币
-type PlayerCurrency struct {
-	Coins int
-	Gems  int
-}
+var (
+	ErrShopNotFound     = errors.New("商店不存在")
+	ErrInvalidBuyCount  = errors.New("购买数量错误")
+	ErrShopItemNotFound = errors.New("物品不存在")
+	ErrShopItemExpired  = errors.New("物品已过期")
+	ErrOutOfStock       = errors.New("库存不足")
+	ErrCoinNotEnough    = errors.New("金币不足")
+	ErrDiamondNotEnough = errors.New("钻石不足")
+	ErrPriceChanged     = errors.New("价格已变化")
+)
+
+// 价格取整方式，折扣后不足1的部分四舍五入
+const priceRounding = pricing.RoundHalfUp
+
+const (
+	creditRetryInterval = 30 * time.Second // 货币补发间隔，更新时间早于一个间隔的待发放订单才补发
+	creditRetryBatch    = 100              // 每次补发的最大订单数
+)
 
 // ShopManager 商场管理器
 type ShopManager struct {
-	shopItems      map[int][]*ShopItem    // shop_type -> items
-	playerBuys     map[string]map[int]int // player_id -> item_id -> bought_count
-	playerCurrency map[string]*PlayerCurrency
+	table         string                      // 商店配置表名
+	campaignTable string                      // 折扣活动配置表名
+	catalog       atomic.Pointer[shopCatalog] // 商品目录，配置表更新时整体替换
+	campaigns     atomic.Pointer[campaignSet] // 折扣活动，配置表更新时整体替换
+	rotationTable string                      // 轮换商店配置表名
+	rotations     atomic.Pointer[rotationSet] // 轮换商店，配置表更新时整体替换
+	rotationStore *RotationStore              // 玩家轮换商店状态
+	stock         Stock                       // 库存，集群内共享限量物品的剩余库存
+	delivery      Delivery                    // 发货，物品堆叠到背包，货币通过钱包发放
+	limiter       Limiter                     // 限购，按周期持久化玩家购买数量
+	wallet        Wallet                      // 钱包，货币由钱包服务扣除
+	players       *mongodb.MongoDBClient      // 玩家，用于判断活动参与条件
+	done          chan struct{}               // 关闭时停止货币补发循环
 }
 
-func NewShopManager() *ShopManager {
+func NewShopManager(database, table, campaignTable, rotationTable string, wallet Wallet) (*ShopManager, error) {
+	limiter, err := NewPurchaseLimiter(database)
+	if err != nil {
+		return nil, err
+	}
+
+	rotationStore, err := NewRotationStore(database)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err := NewShopDelivery(database, wallet)
+	if err != nil {
+		return nil, err
+	}
+
+	players, err := mongodb.NewMongoDBClient(database, playerCollection)
+	if err != nil {
+		return nil, err
+	}
+
 	m := &ShopManager{
-		shopItems:      make(map[int][]*ShopItem),
-		playerBuys:     make(map[string]map[int]int),
-		playerCurrency: make(map[string]*PlayerCurrency),
+		table:         table,
+		campaignTable: campaignTable,
+		rotationTable: rotationTable,
+		rotationStore: rotationStore,
+		stock: NewStockStore(
+			etc.Get("etc.shop.redis", "default").String(),
+			etc.Get("etc.shop.prefix", defaultStockPrefix).String(),
+			etc.Get("etc.shop.reserveTimeout", defaultReserveTimeout).Duration(),
+		),
+		limiter:  limiter,
+		delivery: delivery,
+		wallet:   wallet,
+		players:  players,
+		done:     make(chan struct{}),
+	}
+
+	// 加载商店、折扣活动及轮换商店配置表
+	if err = m.Reload(); err != nil {
+		return nil, err
+	}
+
+	if err = m.ReloadCampaigns(); err != nil {
+		return nil, err
+	}
+
+	if err = m.ReloadRotations(); err != nil {
+		return nil, err
+	}
+
+	// 配置表变化时热更新
+	config.Watch(func(names ...string) {
+		for _, name := range names {
+			switch name {
+			case m.table:
+				if err := m.Reload(); err != nil {
+					log.Errorf("reload shop table failed, keep current catalog: %v", err)
+					continue
+				}
+				log.Infof("shop table reloaded: %s", m.table)
+			case m.campaignTable:
+				if err := m.ReloadCampaigns(); err != nil {
+					log.Errorf("reload campaign table failed, keep current campaigns: %v", err)
+					continue
+				}
+				log.Infof("campaign table reloaded: %s", m.campaignTable)
+			case m.rotationTable:
+				if err := m.ReloadRotations(); err != nil {
+					log.Errorf("reload rotation table failed, keep current rotations: %v", err)
+					continue
+				}
+				log.Infof("rotation table reloaded: %s", m.rotationTable)
+			}
+		}
+	}, m.table, m.campaignTable, m.rotationTable)
+
+	return m, nil
+}
+
+// Reload 重新加载商店配置表，校验失败时保留当前目录；
+// 配置库存变化的限量物品按变化量增减剩余库存，已售出及预占中的数量不受影响
+func (m *ShopManager) Reload() error {
+	catalog, err := loadShopCatalog(m.table)
+	if err != nil {
+		return err
+	}
+
+	if err = m.stock.Init(catalog); err != nil {
+		return err
 	}
 
-	// 初始化商店物品
-	m.initShopItems()
+	m.catalog.Store(catalog)
 
-	return m
+	return nil
 }
 
-func (m *ShopManager) GetShopList(playerID string, shopType int) []*ShopItem {
-	// 初始化玩家购买记录
-	if _, exists := m.playerBuys[playerID]; !exists {
-		m.playerBuys[playerID] = make(map[int]int)
+// ReloadCampaigns 重新加载折扣活动配置表，校验失败时保留当前活动
+func (m *ShopManager) ReloadCampaigns() error {
+	campaigns, err := loadShopCampaigns(m.campaignTable)
+	if err != nil {
+		return err
 	}
 
-	// 初始化玩家货币
-	if _, exists := m.playerCurrency[playerID]; !exists {
-		m.playerCurrency[playerID] = &PlayerCurrency{
-			Coins: 10000,
-			Gems:  1000,
+	m.campaigns.Store(campaigns)
+
+	return nil
+}
+
+// 获取玩家当前可参与的折扣活动，仅在存在限定玩家的活动时查询玩家
+func (m *ShopManager) playerCampaigns(playerID string, now time.Time) ([]*shopCampaign, error) {
+	active := make([]*shopCampaign, 0)
+	audience := false
+	for _, campaign := range m.campaigns.Load().campaigns {
+		if campaign.active(now) {
+			active = append(active, campaign)
+			audience = audience || campaign.hasAudience()
 		}
 	}
 
-	items, exists := m.shopItems[shopType]
+	if !audience {
+		return active, nil
+	}
+
+	var player *define.Player
+	p := &define.Player{}
+	if err := m.players.FindOne(bson.M{"_id": playerID}, p); err == nil {
+		player = p
+	} else if !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+
+	campaigns := active[:0]
+	for _, campaign := range active {
+		if campaign.matches(player) {
+			campaigns = append(campaigns, campaign)
+		}
+	}
+
+	return campaigns, nil
+}
+
+// 计算物品对玩家的单价及折扣率，商店列表与购买使用同一计算保证展示价格与扣款一致；
+// 叠加折扣相乘后与独占活动折扣比较，取价格更低者
+func itemPrice(item *ShopItem, campaigns []*shopCampaign) (int, pricing.Rate) {
+	stacked, exclusive := discountRates(item, campaigns)
+
+	price := pricing.Apply(int64(item.OriginalPrice), priceRounding, stacked...)
+	if exclusivePrice := pricing.Apply(int64(item.OriginalPrice), priceRounding, exclusive); exclusivePrice < price {
+		return int(exclusivePrice), exclusive
+	}
+
+	return int(price), pricing.Multiply(priceRounding, stacked...)
+}
+
+// GetShopList 获取商店列表，轮换商店同时返回刷新信息
+func (m *ShopManager) GetShopList(playerID string, shopType int) ([]*ShopItem, *RotationInfo, error) {
+	if shop, ok := m.rotations.Load().shops[shopType]; ok {
+		return m.getRotationList(playerID, shop)
+	}
+
+	items, exists := m.catalog.Load().shops[shopType]
 	if !exists {
-		return []*ShopItem{}
+		return []*ShopItem{}, nil, nil
+	}
+
+	// 查询当前限购周期内的购买数量
+	counts, err := m.limiter.Counts(playerID, items)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 查询限量物品的剩余库存
+	itemIDs := make([]int, 0, len(items))
+	for _, item := range items {
+		if item.Stock > 0 {
+			itemIDs = append(itemIDs, item.ItemID)
+		}
+	}
+
+	remaining, err := m.stock.Remaining(itemIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 查询已首购的物品
+	purchased, err := m.delivery.FirstPurchased(playerID, items)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	campaigns, err := m.playerCampaigns(playerID, now)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// 克隆物品并更新购买数量
 	result := make([]*ShopItem, len(items))
-	now := time.Now().Unix()
 
 	for i, item := range items {
 		// 克隆物品
 		result[i] = &ShopItem{}
 		*result[i] = *item
-		result[i].BoughtCount = m.playerBuys[playerID][item.ItemID]
+		result[i].BoughtCount = counts[item.ItemID]
+		result[i].FirstAvailable = item.FirstBonus != nil && !purchased[item.ItemID]
+		if item.Stock > 0 {
+			result[i].Stock = remaining[item.ItemID]
+		}
+
+		if item.MaxBuyCount > 0 {
+			if _, resetTime := purchaseWindow(item, now); !resetTime.IsZero() {
+				result[i].LimitResetTime = resetTime.Unix()
+			}
+		}
 
 		// 检查限时物品是否过期
-		if item.ExpireTime > 0 && item.ExpireTime < now {
+		if item.ExpireTime > 0 && item.ExpireTime < now.Unix() {
 			result[i].Stock = 0
 		}
 
-		// 应用折扣
-		if item.IsDiscount && item.ExpireTime > now {
-			result[i].CurrentPrice = int(float32(item.OriginalPrice) * item.DiscountRate)
-		} else {
-			result[i].CurrentPrice = item.OriginalPrice
-			result[i].IsDiscount = false
-		}
+		// 应用物品折扣及折扣活动
+		result[i].CurrentPrice, result[i].Discount = itemPrice(item, campaigns)
+		result[i].DiscountRate = result[i].Discount.Float32()
+		result[i].IsDiscount = result[i].Discount.Discounted()
 	}
 
-	return result
+	return result, nil, nil
 }
 
-func (m *ShopManager) BuyItem(playerID string, itemID, count int) ([]Item, int, int, error) {
-	// 初始化玩家数据
-	if _, exists := m.playerBuys[playerID]; !exists {
-		m.playerBuys[playerID] = make(map[int]int)
+// BuyItem 购买物品，expectedPrice不为0时与当前单价不一致则返回ErrPriceChanged；
+// 购买轮换商店物品时需指定商店类型，普通商店物品可不指定。
+// 每次购买生成订单，指定幂等键时重复请求返回首次购买的结果，不重复扣款
+func (m *ShopManager) BuyItem(playerID string, shopType, itemID, count, expectedPrice int, idempotencyKey string) (*PurchaseResult, error) {
+	if count <= 0 {
+		return nil, ErrInvalidBuyCount
 	}
-	if _, exists := m.playerCurrency[playerID]; !exists {
-		m.playerCurrency[playerID] = &PlayerCurrency{
-			Coins: 10000,
-			Gems:  1000,
-		}
+
+	order, done, err := m.delivery.Open(playerID, shopType, itemID, count, idempotencyKey)
+	if err != nil {
+		return nil, err
 	}
 
-	// 查找物品
-	var targetItem *ShopItem
-	for _, items := range m.shopItems {
-		for _, item := range items {
-			if item.ItemID == itemID {
-				targetItem = item
-				break
-			}
+	if done {
+		// 首次购买的货币发放失败时，重复请求补发
+		if err = m.delivery.Credit(order); err != nil {
+			log.Warnf("credit shop order failed: order_id=%s, err=%v", order.ID, err)
 		}
-		if targetItem != nil {
-			break
+		return orderResult(order), nil
+	}
+
+	var result *PurchaseResult
+	if shop, ok := m.rotations.Load().shops[shopType]; ok {
+		result, err = m.buyRotationItem(order, shop, expectedPrice)
+	} else {
+		result, err = m.buyCatalogItem(order, expectedPrice)
+	}
+
+	// 记录失败原因，订单已由其他请求处理时保持不变
+	if err != nil && !errors.Is(err, ErrOrderPending) {
+		if err := m.delivery.Fail(order, err); err != nil {
+			log.Warnf("mark shop order failed failed: order_id=%s, err=%v", order.ID, err)
 		}
 	}
 
-	if targetItem == nil {
-		return nil, 0, 0, errors.New("物品不存在")
+	return result, err
+}
+
+// 购买普通商店物品
+func (m *ShopManager) buyCatalogItem(order *define.ShopOrder, expectedPrice int) (*PurchaseResult, error) {
+	playerID, itemID, count := order.PlayerID, order.ItemID, order.Count
+
+	// 查找物品
+	targetItem, ok := m.catalog.Load().items[itemID]
+	if !ok || (order.ShopType > 0 && targetItem.ShopType != order.ShopType) {
+		return nil, ErrShopItemNotFound
 	}
 
 	// 检查物品是否过期
-	now := time.Now().Unix()
-	if targetItem.ExpireTime > 0 && targetItem.ExpireTime < now {
-		return nil, 0, 0, errors.New("物品已过期")
+	now := time.Now()
+	if targetItem.ExpireTime > 0 && targetItem.ExpireTime < now.Unix() {
+		return nil, ErrShopItemExpired
 	}
 
-	// 检查库存
-	if targetItem.Stock > 0 && count > targetItem.Stock {
-		return nil, 0, 0, errors.New("库存不足")
+	// 计算价格，与商店列表展示的价格一致
+	campaigns, err := m.playerCampaigns(playerID, now)
+	if err != nil {
+		return nil, err
 	}
 
-	// 检查购买限制
-	boughtCount := m.playerBuys[playerID][itemID]
-	if targetItem.MaxBuyCount > 0 && boughtCount+count > targetItem.MaxBuyCount {
-		return nil, 0, 0, errors.New("超出购买限制")
+	price, _ := itemPrice(targetItem, campaigns)
+	if expectedPrice > 0 && expectedPrice != price {
+		return nil, ErrPriceChanged
 	}
+	totalPrice := price * count
 
-	// 计算价格
-	price := targetItem.CurrentPrice
-	if targetItem.IsDiscount && targetItem.ExpireTime > now {
-		price = int(float32(targetItem.OriginalPrice) * targetItem.DiscountRate)
+	// 占用限购数量
+	limitID, err := m.limiter.Take(playerID, targetItem, count)
+	if err != nil {
+		return nil, err
 	}
-	totalPrice := price * count
 
-	// 检查货币
-	currency := m.playerCurrency[playerID]
-	if targetItem.CurrencyType == 1 {
-		// 金币
-		if currency.Coins < totalPrice {
-			return nil, 0, 0, errors.New("金币不足")
+	// 预占库存
+	var reservation *StockReservation
+	if targetItem.Stock > 0 {
+		if reservation, err = m.stock.Reserve(itemID, count); err != nil {
+			m.releaseLimit(limitID, count)
+			return nil, err
 		}
-		currency.Coins -= totalPrice
-	} else {
-		// 钻石
-		if currency.Gems < totalPrice {
-			return nil, 0, 0, errors.New("钻石不足")
+	}
+
+	// 扣款后提交库存预占再发货，失败时退回限购数量并回滚库存
+	order.LimitID = limitID
+	return m.settle(order, targetItem, totalPrice, func() error {
+		return m.commitStock(reservation)
+	}, func() {
+		m.releaseLimit(limitID, count)
+		m.rollbackStock(reservation)
+	})
+}
+
+// 通过钱包扣除货币并发放购买内容，扣款后发货前调用commit确认占用的资源；
+// 扣款失败时调用rollback，确认或发货失败时退款后调用rollback。
+// 扣款幂等键由订单ID及处理次数组成，接管超时订单时不会重复扣款
+func (m *ShopManager) settle(order *define.ShopOrder, item *ShopItem, totalPrice int, commit func() error, rollback func()) (*PurchaseResult, error) {
+	order.TotalPrice, order.CurrencyType = int64(totalPrice), item.CurrencyType
+
+	key := fmt.Sprintf("shop:%s:%d", order.ID, order.Attempt)
+	remark := fmt.Sprintf("order_id=%s, shop_type=%d, item_id=%d, count=%d", order.ID, item.ShopType, item.ItemID, order.Count)
+	if totalPrice > 0 {
+		if _, err := m.wallet.Debit(order.PlayerID, int32(item.CurrencyType), int64(totalPrice), define.WalletReasonShopBuy, key, remark); err != nil {
+			rollback()
+			return nil, currencyError(err, item.CurrencyType)
 		}
-		currency.Gems -= totalPrice
 	}
 
-	// 更新购买记录
-	m.playerBuys[playerID][itemID] += count
+	if commit != nil {
+		if err := commit(); err != nil {
+			m.refund(order, key, remark)
+			rollback()
+			return nil, err
+		}
+	}
 
-	// 减少库存
-	if targetItem.Stock > 0 {
-		targetItem.Stock -= count
+	if err := m.delivery.Deliver(order, item); err != nil {
+		// 订单已由其他请求以相同的扣款幂等键完成时无需退款
+		if !errors.Is(err, ErrOrderPending) {
+			m.refund(order, key, remark)
+		}
+		rollback()
+		return nil, err
 	}
 
-	// 创建购买的物品
-	boughtItems := make([]Item, 0, count)
-	for i := 0; i < count; i++ {
-		item := Item{
-			ID:         fmt.Sprintf("%s_item_%d_%d", playerID, itemID, time.Now().UnixNano()+int64(i)),
-			ItemID:     int32(itemID),
-			Count:      1,
-			CreateTime: now,
-			Attrs:      map[string]string{"source": "shop"},
+	return orderResult(order), nil
+}
+
+// Serve 启动货币补发循环，补发发货时未能计入钱包的货币
+func (m *ShopManager) Serve() {
+	go func() {
+		ticker := time.NewTicker(creditRetryInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-m.done:
+				return
+			case <-ticker.C:
+				m.retryCredits(time.Now())
+			}
 		}
-		boughtItems = append(boughtItems, item)
-	}
-
-	return boughtItems, totalPrice, targetItem.CurrencyType, nil
-}
-
-func (m *ShopManager) GetDiscountInfo(playerID string) []*DiscountInfo {
-	// 示例折扣信息
-	discounts := []*DiscountInfo{
-		{
-			ShopType:     1,
-			DiscountRate: 0.9,
-			StartTime:    time.Now().Unix() - 86400,
-			EndTime:      time.Now().Unix() + 7*86400,
-		},
-		{
-			ShopType:     2,
-			DiscountRate: 0.7,
-			StartTime:    time.Now().Unix() - 86400,
-			EndTime:      time.Now().Unix() + 2*86400,
-		},
-	}
-
-	return discounts
-}
-
-func (m *ShopManager) initShopItems() {
-	// 普通商店
-	m.shopItems[1] = []*ShopItem{
-		{
-			ItemID:        1001,
-			OriginalPrice: 100,
-			CurrentPrice:  100,
-			CurrencyType:  1,
-			Stock:         999,
-			MaxBuyCount:   0,
-			BoughtCount:   0,
-			ExpireTime:    0,
-			IsDiscount:    false,
-			DiscountRate:  1.0,
-		},
-		{
-			ItemID:        1002,
-			OriginalPrice: 200,
-			CurrentPrice:  200,
-			CurrencyType:  1,
-			Stock:         999,
-			MaxBuyCount:   0,
-			BoughtCount:   0,
-			ExpireTime:    0,
-			IsDiscount:    false,
-			DiscountRate:  1.0,
-		},
-		{
-			ItemID:        2001,
-			OriginalPrice: 1000,
-			CurrentPrice:  900,
-			CurrencyType:  1,
-			Stock:         50,
-			MaxBuyCount:   0,
-			BoughtCount:   0,
-			ExpireTime:    0,
-			IsDiscount:    true,
-			DiscountRate:  0.9,
-		},
-	}
-
-	// 限时商店
-	weekLater := time.Now().Unix() + 7*86400
-	m.shopItems[2] = []*ShopItem{
-		{
-			ItemID:        2005,
-			OriginalPrice: 5000,
-			CurrentPrice:  3500,
-			CurrencyType:  1,
-			Stock:         10,
-			MaxBuyCount:   1,
-			BoughtCount:   0,
-			ExpireTime:    weekLater,
-			IsDiscount:    true,
-			DiscountRate:  0.7,
-		},
-		{
-			ItemID:        3001,
-			OriginalPrice: 100,
-			CurrentPrice:  80,
-			CurrencyType:  2,
-			Stock:         20,
-			MaxBuyCount:   5,
-			BoughtCount:   0,
-			ExpireTime:    weekLater,
-			IsDiscount:    true,
-			DiscountRate:  0.8,
-		},
-	}
-
-	// 活动商店
-	m.shopItems[3] = []*ShopItem{
-		{
-			ItemID:        4001,
-			OriginalPrice: 1000,
-			CurrentPrice:  1000,
-			CurrencyType:  2,
-			Stock:         99,
-			MaxBuyCount:   0,
-			BoughtCount:   0,
-			ExpireTime:    0,
-			IsDiscount:    false,
-			DiscountRate:  1.0,
-		},
+	}()
+}
+
+// Stop 停止货币补发循环
+func (m *ShopManager) Stop() {
+	select {
+	case <-m.done:
+	default:
+		close(m.done)
+	}
+}
+
+// 补发货币待发放的订单，以发货时的幂等键发放，多个大厅节点同时补发不会重复发放
+func (m *ShopManager) retryCredits(now time.Time) {
+	orders, err := m.delivery.PendingCredits(now.Add(-creditRetryInterval), creditRetryBatch)
+	if err != nil {
+		log.Errorf("query pending shop credits failed: %v", err)
+		return
 	}
+
+	for _, order := range orders {
+		if err = m.delivery.Credit(order); err != nil {
+			log.Warnf("retry shop credit failed: player_id=%s, order_id=%s, err=%v", order.PlayerID, order.ID, err)
+		}
+	}
+}
+
+// 退回本次处理的扣款，失败时记录日志以便人工补偿
+func (m *ShopManager) refund(order *define.ShopOrder, key, remark string) {
+	if order.TotalPrice <= 0 {
+		return
+	}
+
+	if _, err := m.wallet.Credit(order.PlayerID, int32(order.CurrencyType), order.TotalPrice, define.WalletReasonRefund, "refund:"+key, remark); err != nil {
+		log.Errorf("refund shop purchase failed: player_id=%s, order_id=%s, price=%d, err=%v", order.PlayerID, order.ID, order.TotalPrice, err)
+	}
+}
+
+// 余额不足时按货币类型转换为对应错误
+func currencyError(err error, currencyType int) error {
+	if !errors.Is(err, ErrInsufficientBalance) {
+		return err
+	}
+
+	if currencyType == define.CurrencyTypeCoin {
+		return ErrCoinNotEnough
+	}
+
+	return ErrDiamondNotEnough
+}
+
+// 退回限购数量，失败时仅记录日志
+func (m *ShopManager) releaseLimit(limitID string, count int) {
+	if err := m.limiter.Release(limitID, count); err != nil {
+		log.Warnf("release purchase limit failed: id=%s, count=%d, err=%v", limitID, count, err)
+	}
+}
+
+// 提交库存预占，预占已超时被回收且库存已被其他玩家买走时返回ErrOutOfStock
+func (m *ShopManager) commitStock(reservation *StockReservation) error {
+	if reservation == nil {
+		return nil
+	}
+
+	ok, err := m.stock.Commit(reservation)
+	if err != nil {
+		log.Errorf("commit stock reservation failed: item_id=%d, count=%d, err=%v", reservation.ItemID, reservation.Count, err)
+		return err
+	}
+
+	if !ok {
+		log.Warnf("stock reservation expired before commit: item_id=%d, count=%d", reservation.ItemID, reservation.Count)
+		return ErrOutOfStock
+	}
+
+	return nil
+}
+
+// 回滚库存预占，失败时仅记录日志，预占超时后自动退回
+func (m *ShopManager) rollbackStock(reservation *StockReservation) {
+	if reservation == nil {
+		return
+	}
+
+	if err := m.stock.Rollback(reservation); err != nil {
+		log.Warnf("rollback stock reservation failed: item_id=%d, count=%d, err=%v", reservation.ItemID, reservation.Count, err)
+	}
+}
+
+// GetDiscountInfo 获取玩家当前可参与的折扣活动，每个生效商店一条，未限定商店的活动商店类型为0
+func (m *ShopManager) GetDiscountInfo(playerID string) ([]*DiscountInfo, error) {
+	campaigns, err := m.playerCampaigns(playerID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	discounts := make([]*DiscountInfo, 0, len(campaigns))
+	for _, campaign := range campaigns {
+		shopTypes := campaign.ShopTypes
+		if len(shopTypes) == 0 {
+			shopTypes = []int{0}
+		}
+
+		for _, shopType := range shopTypes {
+			discounts = append(discounts, &DiscountInfo{
+				ShopType:     shopType,
+				DiscountRate: campaign.rate.Float32(),
+				StartTime:    campaign.StartTime,
+				EndTime:      campaign.EndTime,
+				CampaignID:   campaign.ID,
+				Name:         campaign.Name,
+				ItemIDs:      campaign.ItemIDs,
+				Stacking:     campaign.Stacking,
+			})
+		}
+	}
+
+	return discounts, nil
 }
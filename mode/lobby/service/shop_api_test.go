@@ -0,0 +1,542 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"ghserver/define"
+
+	"github.com/dobyte/due/v2/utils/xuuid"
+)
+
+// 限量物品，库存为50
+const testStockItemID = 2001
+
+// 测试商品目录
+var testShopItems = []ShopItemConfig{
+	{ShopType: 1, ItemID: 1001, Price: 100, CurrencyType: define.CurrencyTypeCoin},
+	{ShopType: 1, ItemID: testStockItemID, Price: 1000, CurrencyType: define.CurrencyTypeCoin, Stock: 50, DiscountRate: 0.9},
+	{ShopType: 2, ItemID: 9001, Price: 680, CurrencyType: define.CurrencyTypeDiamond, Contents: &ShopGrantConfig{
+		Items: []ShopGrantItemConfig{{ItemID: 1001, Count: 10}},
+		Coin:  5000,
+	}},
+}
+
+func newTestShopManager(t *testing.T, wallet Wallet, delivery Delivery) *ShopManager {
+	t.Helper()
+
+	catalog, err := newShopCatalog(testShopItems)
+	if err != nil {
+		t.Fatalf("create shop catalog failed: %v", err)
+	}
+
+	stock := newFakeStock()
+	if err = stock.Init(catalog); err != nil {
+		t.Fatalf("init stock failed: %v", err)
+	}
+
+	m := &ShopManager{
+		stock:    stock,
+		delivery: delivery,
+		limiter:  newFakeLimiter(),
+		wallet:   wallet,
+	}
+	m.catalog.Store(catalog)
+	m.campaigns.Store(&campaignSet{})
+	m.rotations.Store(&rotationSet{shops: make(map[int]*rotationShop)})
+
+	return m
+}
+
+func TestShopManagerConcurrentBuyItem(t *testing.T) {
+	wallet := newFakeWallet(1000000)
+	m := newTestShopManager(t, wallet, newFakeDelivery(wallet))
+
+	item := m.catalog.Load().items[testStockItemID]
+
+	var (
+		wg        sync.WaitGroup
+		succeeded atomic.Int64
+		soldOut   atomic.Int64
+		spent     atomic.Int64
+		buyers    = item.Stock * 2
+	)
+
+	for i := 0; i < buyers; i++ {
+		wg.Add(1)
+		go func(playerID string) {
+			defer wg.Done()
+
+			result, err := m.BuyItem(playerID, 0, testStockItemID, 1, 0, "")
+			switch {
+			case err == nil:
+				succeeded.Add(1)
+				spent.Add(int64(result.TotalPrice))
+			case errors.Is(err, ErrOutOfStock):
+				soldOut.Add(1)
+			default:
+				t.Errorf("buy item failed: player_id=%s, err=%v", playerID, err)
+			}
+		}(fmt.Sprintf("%d", 200000+i))
+	}
+
+	wg.Wait()
+
+	if n := succeeded.Load(); n != int64(item.Stock) {
+		t.Errorf("succeeded purchases = %d, want %d", n, item.Stock)
+	}
+
+	if n := soldOut.Load(); n != int64(buyers-item.Stock) {
+		t.Errorf("sold out purchases = %d, want %d", n, buyers-item.Stock)
+	}
+
+	remaining, err := m.stock.Remaining([]int{testStockItemID})
+	if err != nil {
+		t.Fatalf("query remaining stock failed: %v", err)
+	}
+
+	if remaining[testStockItemID] != 0 {
+		t.Errorf("remaining stock = %d, want 0", remaining[testStockItemID])
+	}
+
+	// 售罄的购买不扣款，成功的购买只扣一次
+	if credited, debited := wallet.totals(); debited-credited != spent.Load() {
+		t.Errorf("wallet net debit = %d, want %d", debited-credited, spent.Load())
+	}
+}
+
+func TestShopManagerConcurrentBuyItemIdempotent(t *testing.T) {
+	wallet := newFakeWallet(1000000)
+	m := newTestShopManager(t, wallet, newFakeDelivery(wallet))
+
+	const (
+		playerID = "200000"
+		key      = "buy-once"
+		workers  = 32
+	)
+
+	var (
+		wg     sync.WaitGroup
+		mutex  sync.Mutex
+		orders = make(map[string]struct{})
+	)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			result, err := m.BuyItem(playerID, 0, testStockItemID, 1, 0, key)
+			if err != nil {
+				// 首次请求处理中时重复请求返回ErrOrderPending
+				if !errors.Is(err, ErrOrderPending) {
+					t.Errorf("buy item failed: %v", err)
+				}
+				return
+			}
+
+			mutex.Lock()
+			orders[result.OrderID] = struct{}{}
+			mutex.Unlock()
+		}()
+	}
+
+	wg.Wait()
+
+	if len(orders) != 1 {
+		t.Errorf("orders = %d, want 1", len(orders))
+	}
+
+	remaining, err := m.stock.Remaining([]int{testStockItemID})
+	if err != nil {
+		t.Fatalf("query remaining stock failed: %v", err)
+	}
+
+	item := m.catalog.Load().items[testStockItemID]
+	if remaining[testStockItemID] != item.Stock-1 {
+		t.Errorf("remaining stock = %d, want %d", remaining[testStockItemID], item.Stock-1)
+	}
+
+	if _, debited := wallet.totals(); debited != 900 {
+		t.Errorf("wallet debited = %d, want 900", debited)
+	}
+}
+
+// 测试发货，订单保存在内存中，状态流转与ShopDelivery一致
+type fakeDelivery struct {
+	mutex    sync.Mutex
+	wallet   Wallet
+	orders   map[string]*define.ShopOrder
+	firsts   map[string]bool
+	failures int // 接下来发货失败的次数，模拟物品写入失败
+}
+
+func newFakeDelivery(wallet Wallet) *fakeDelivery {
+	return &fakeDelivery{
+		wallet: wallet,
+		orders: make(map[string]*define.ShopOrder),
+		firsts: make(map[string]bool),
+	}
+}
+
+func (d *fakeDelivery) Open(playerID string, shopType, itemID, count int, idempotencyKey string) (*define.ShopOrder, bool, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	now := time.Now()
+	order := &define.ShopOrder{
+		ID:             xuuid.UUID(),
+		PlayerID:       playerID,
+		IdempotencyKey: idempotencyKey,
+		ShopType:       shopType,
+		ItemID:         itemID,
+		Count:          count,
+		Items:          []define.ItemInfo{},
+		Status:         define.ShopOrderPending,
+		CreateTime:     now,
+		UpdateTime:     now,
+	}
+
+	if idempotencyKey != "" {
+		order.ID = playerID + ":" + idempotencyKey
+	}
+
+	existing, ok := d.orders[order.ID]
+	if !ok {
+		d.orders[order.ID] = cloneOrder(order)
+		return order, false, nil
+	}
+
+	if existing.ShopType != shopType || existing.ItemID != itemID || existing.Count != count {
+		return nil, false, ErrOrderConflict
+	}
+
+	switch existing.Status {
+	case define.ShopOrderPending:
+		return nil, false, ErrOrderPending
+	case define.ShopOrderFailed:
+		existing.Status, existing.Attempt, existing.Remark, existing.UpdateTime = define.ShopOrderPending, existing.Attempt+1, "", now
+		return cloneOrder(existing), false, nil
+	default:
+		return cloneOrder(existing), true, nil
+	}
+}
+
+func (d *fakeDelivery) Fail(order *define.ShopOrder, cause error) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if existing, ok := d.orders[order.ID]; ok && existing.Status == define.ShopOrderPending && existing.Attempt == order.Attempt {
+		existing.Status, existing.Remark = define.ShopOrderFailed, cause.Error()
+	}
+
+	return nil
+}
+
+func (d *fakeDelivery) Deliver(order *define.ShopOrder, item *ShopItem) error {
+	if err := d.complete(order, item); err != nil {
+		return err
+	}
+
+	// 货币发放失败时保持待发放
+	_ = d.Credit(order)
+
+	return nil
+}
+
+// 发放物品并将订单标记为已完成
+func (d *fakeDelivery) complete(order *define.ShopOrder, item *ShopItem) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.failures > 0 {
+		d.failures--
+		return errors.New("deliver failed")
+	}
+
+	existing, ok := d.orders[order.ID]
+	if !ok || existing.Status != define.ShopOrderPending || existing.Attempt != order.Attempt {
+		return ErrOrderPending
+	}
+
+	grant := item.Contents.multiply(order.Count)
+	first := false
+	if item.FirstBonus != nil && !d.firsts[firstPurchaseID(order.PlayerID, item.ItemID)] {
+		d.firsts[firstPurchaseID(order.PlayerID, item.ItemID)] = true
+		grant.add(item.FirstBonus)
+		first = true
+	}
+
+	order.Status, order.UpdateTime = define.ShopOrderCompleted, time.Now()
+	order.Items, order.Coin, order.Diamond, order.FirstBonus = grant.Items, grant.Coin, grant.Diamond, first
+	order.CreditPending = grant.Coin > 0 || grant.Diamond > 0
+	d.orders[order.ID] = cloneOrder(order)
+
+	return nil
+}
+
+func (d *fakeDelivery) Credit(order *define.ShopOrder) error {
+	if !order.CreditPending {
+		return nil
+	}
+
+	if order.Coin > 0 {
+		if _, err := d.wallet.Credit(order.PlayerID, define.CurrencyTypeCoin, order.Coin, define.WalletReasonShopBuy, "shop:"+order.ID+":coin", ""); err != nil {
+			return err
+		}
+	}
+
+	if order.Diamond > 0 {
+		if _, err := d.wallet.Credit(order.PlayerID, define.CurrencyTypeDiamond, order.Diamond, define.WalletReasonShopBuy, "shop:"+order.ID+":diamond", ""); err != nil {
+			return err
+		}
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if existing, ok := d.orders[order.ID]; ok {
+		existing.CreditPending = false
+	}
+	order.CreditPending = false
+
+	return nil
+}
+
+func (d *fakeDelivery) PendingCredits(before time.Time, limit int64) ([]*define.ShopOrder, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	orders := make([]*define.ShopOrder, 0)
+	for _, order := range d.orders {
+		if order.CreditPending && order.UpdateTime.Before(before) && int64(len(orders)) < limit {
+			orders = append(orders, cloneOrder(order))
+		}
+	}
+
+	return orders, nil
+}
+
+func (d *fakeDelivery) FirstPurchased(playerID string, items []*ShopItem) (map[int]bool, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	purchased := make(map[int]bool)
+	for _, item := range items {
+		if d.firsts[firstPurchaseID(playerID, item.ItemID)] {
+			purchased[item.ItemID] = true
+		}
+	}
+
+	return purchased, nil
+}
+
+func (d *fakeDelivery) Load(orderID string) (*define.ShopOrder, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	order, ok := d.orders[orderID]
+	if !ok {
+		return nil, ErrOrderNotFound
+	}
+
+	return cloneOrder(order), nil
+}
+
+func (d *fakeDelivery) Reverse(order *define.ShopOrder, operator, reason string) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	existing, ok := d.orders[order.ID]
+	if !ok || existing.Status != define.ShopOrderCompleted {
+		return ErrOrderNotRefundable
+	}
+
+	if order.FirstBonus {
+		delete(d.firsts, firstPurchaseID(order.PlayerID, order.ItemID))
+	}
+
+	existing.Status, existing.Remark, existing.Operator = define.ShopOrderRefunding, reason, operator
+	order.Status, order.Remark, order.Operator = existing.Status, reason, operator
+
+	return nil
+}
+
+func (d *fakeDelivery) Restore(order *define.ShopOrder) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	existing, ok := d.orders[order.ID]
+	if !ok || existing.Status != define.ShopOrderRefunding || existing.RefundAttempt != order.RefundAttempt {
+		return ErrOrderPending
+	}
+
+	if order.FirstBonus {
+		d.firsts[firstPurchaseID(order.PlayerID, order.ItemID)] = true
+	}
+
+	existing.Status, existing.RefundAttempt = define.ShopOrderCompleted, existing.RefundAttempt+1
+	order.Status, order.RefundAttempt = existing.Status, existing.RefundAttempt
+
+	return nil
+}
+
+func (d *fakeDelivery) MarkRefunded(order *define.ShopOrder) (bool, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	existing, ok := d.orders[order.ID]
+	if !ok || existing.Status != define.ShopOrderRefunding {
+		return false, nil
+	}
+
+	existing.Status = define.ShopOrderRefunded
+	order.Status = existing.Status
+
+	return true, nil
+}
+
+func cloneOrder(order *define.ShopOrder) *define.ShopOrder {
+	clone := *order
+	clone.Items = append([]define.ItemInfo{}, order.Items...)
+	return &clone
+}
+
+// 测试库存，预占与提交的语义与StockStore一致，预占不会超时
+type fakeStock struct {
+	mutex     sync.Mutex
+	remaining map[int]int
+	totals    map[int]int    // 物品ID -> 配置库存
+	reserved  map[string]int // 预占ID -> 数量
+}
+
+func newFakeStock() *fakeStock {
+	return &fakeStock{
+		remaining: make(map[int]int),
+		totals:    make(map[int]int),
+		reserved:  make(map[string]int),
+	}
+}
+
+func (s *fakeStock) Init(catalog *shopCatalog) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for itemID, item := range catalog.items {
+		if item.Stock > 0 {
+			s.remaining[itemID] += item.Stock - s.totals[itemID]
+			s.totals[itemID] = item.Stock
+		}
+	}
+
+	return nil
+}
+
+func (s *fakeStock) Reserve(itemID, count int) (*StockReservation, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.remaining[itemID] < count {
+		return nil, ErrOutOfStock
+	}
+
+	reservation := &StockReservation{ItemID: itemID, Count: count, member: xuuid.UUID()}
+	s.remaining[itemID] -= count
+	s.reserved[reservation.member] = count
+
+	return reservation, nil
+}
+
+func (s *fakeStock) Commit(reservation *StockReservation) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.reserved[reservation.member]; !ok {
+		return false, nil
+	}
+
+	delete(s.reserved, reservation.member)
+	reservation.committed = true
+
+	return true, nil
+}
+
+func (s *fakeStock) Rollback(reservation *StockReservation) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.reserved[reservation.member]; ok || reservation.committed {
+		delete(s.reserved, reservation.member)
+		s.remaining[reservation.ItemID] += reservation.Count
+	}
+
+	return nil
+}
+
+func (s *fakeStock) Remaining(itemIDs []int) (map[int]int, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	remaining := make(map[int]int, len(itemIDs))
+	for _, itemID := range itemIDs {
+		remaining[itemID] = s.remaining[itemID]
+	}
+
+	return remaining, nil
+}
+
+// 测试限购，只按玩家及物品计数，不区分限购周期
+type fakeLimiter struct {
+	mutex  sync.Mutex
+	counts map[string]int
+}
+
+func newFakeLimiter() *fakeLimiter {
+	return &fakeLimiter{counts: make(map[string]int)}
+}
+
+func (l *fakeLimiter) Take(playerID string, item *ShopItem, count int) (string, error) {
+	if item.MaxBuyCount == 0 {
+		return "", nil
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	id := purchaseID(playerID, item.ItemID, "")
+	if l.counts[id]+count > item.MaxBuyCount {
+		return "", ErrPurchaseLimit
+	}
+	l.counts[id] += count
+
+	return id, nil
+}
+
+func (l *fakeLimiter) Release(id string, count int) error {
+	if id == "" {
+		return nil
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.counts[id] -= count
+
+	return nil
+}
+
+func (l *fakeLimiter) Counts(playerID string, items []*ShopItem) (map[int]int, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	counts := make(map[int]int, len(items))
+	for _, item := range items {
+		counts[item.ItemID] = l.counts[purchaseID(playerID, item.ItemID, "")]
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,317 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"ghserver/define"
+	"ghserver/utils/mongodb"
+
+	"github.com/dobyte/due/v2/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const shopFirstPurchaseCollection = "shop_first_purchase" // 玩家首购记录集合
+
+// ShopGrantConfig 购买获得的内容配置
+type ShopGrantConfig struct {
+	Items   []ShopGrantItemConfig `json:"items"`   // 物品
+	Coin    int64                 `json:"coin"`    // 金币
+	Diamond int64                 `json:"diamond"` // 钻石
+}
+
+// ShopGrantItemConfig 购买获得的物品配置
+type ShopGrantItemConfig struct {
+	ItemID int `json:"itemId"` // 物品ID
+	Count  int `json:"count"`  // 数量
+}
+
+// ShopGrant 购买获得的内容，物品堆叠到背包，货币通过钱包发放
+type ShopGrant struct {
+	Items   []define.ItemInfo
+	Coin    int64
+	Diamond int64
+}
+
+// firstPurchaseRecord 玩家首次购买记录
+type firstPurchaseRecord struct {
+	ID         string    `bson:"_id"`
+	PlayerID   string    `bson:"player_id"`
+	ItemID     int       `bson:"item_id"`
+	CreateTime time.Time `bson:"create_time"`
+}
+
+// 校验配置并生成购买内容，配置为空时返回nil
+func newShopGrant(c *ShopGrantConfig) (*ShopGrant, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	switch {
+	case c.Coin < 0:
+		return nil, fmt.Errorf("negative coin %d", c.Coin)
+	case c.Diamond < 0:
+		return nil, fmt.Errorf("negative diamond %d", c.Diamond)
+	}
+
+	grant := &ShopGrant{Coin: c.Coin, Diamond: c.Diamond}
+	for _, item := range c.Items {
+		if item.ItemID <= 0 || item.Count <= 0 {
+			return nil, fmt.Errorf("invalid grant item %d x %d", item.ItemID, item.Count)
+		}
+		grant.add(&ShopGrant{Items: []define.ItemInfo{{ItemID: item.ItemID, Count: item.Count}}})
+	}
+
+	if grant.empty() {
+		return nil, errors.New("empty grant")
+	}
+
+	return grant, nil
+}
+
+// 单个物品的购买内容
+func singleGrant(itemID int) *ShopGrant {
+	return &ShopGrant{Items: []define.ItemInfo{{ItemID: itemID, Count: 1}}}
+}
+
+// 是否没有任何内容
+func (g *ShopGrant) empty() bool {
+	return len(g.Items) == 0 && g.Coin == 0 && g.Diamond == 0
+}
+
+// 合并内容，同一物品合并数量
+func (g *ShopGrant) add(other *ShopGrant) {
+	if other == nil {
+		return
+	}
+
+	g.Coin += other.Coin
+	g.Diamond += other.Diamond
+
+	for _, item := range other.Items {
+		merged := false
+		for i := range g.Items {
+			if g.Items[i].ItemID == item.ItemID {
+				g.Items[i].Count += item.Count
+				merged = true
+				break
+			}
+		}
+
+		if !merged {
+			g.Items = append(g.Items, item)
+		}
+	}
+}
+
+// 购买count份的内容
+func (g *ShopGrant) multiply(count int) *ShopGrant {
+	result := &ShopGrant{Coin: g.Coin * int64(count), Diamond: g.Diamond * int64(count)}
+	for _, item := range g.Items {
+		result.Items = append(result.Items, define.ItemInfo{ItemID: item.ItemID, Count: item.Count * count})
+	}
+
+	return result
+}
+
+// Delivery 商店订单及发货
+type Delivery interface {
+	// Open 创建处理中的订单，幂等键已存在时返回已有订单，已完成或已退款时done为true
+	Open(playerID string, shopType, itemID, count int, idempotencyKey string) (order *define.ShopOrder, done bool, err error)
+	// Fail 标记本次处理失败
+	Fail(order *define.ShopOrder, cause error) error
+	// Deliver 发放购买内容并将订单标记为已完成，订单已不在本次处理中时返回ErrOrderPending
+	Deliver(order *define.ShopOrder, item *ShopItem) error
+	// FirstPurchased 查询玩家已首购过的物品
+	FirstPurchased(playerID string, items []*ShopItem) (map[int]bool, error)
+	// Load 查询订单，不存在时返回ErrOrderNotFound
+	Load(orderID string) (*define.ShopOrder, error)
+	// Reverse 回收订单发放的物品并标记退款中
+	Reverse(order *define.ShopOrder, operator, reason string) error
+	// Restore 撤销退款中的订单，恢复为已完成
+	Restore(order *define.ShopOrder) error
+	// MarkRefunded 标记已退款，返回false表示订单已被标记
+	MarkRefunded(order *define.ShopOrder) (bool, error)
+	// Credit 补发订单待发放的货币，幂等键与发货时一致
+	Credit(order *define.ShopOrder) error
+	// PendingCredits 查询更新时间早于before且货币待发放的订单
+	PendingCredits(before time.Time, limit int64) ([]*define.ShopOrder, error)
+}
+
+// ShopDelivery 商店发货，物品、首购记录与订单状态在同一事务内写入，货币通过钱包幂等发放
+type ShopDelivery struct {
+	items  *mongodb.MongoDBClient
+	firsts *mongodb.MongoDBClient
+	orders *mongodb.MongoDBClient
+	wallet Wallet
+}
+
+func NewShopDelivery(database string, wallet Wallet) (*ShopDelivery, error) {
+	items, err := mongodb.NewMongoDBClient(database, itemCollection)
+	if err != nil {
+		return nil, err
+	}
+
+	firsts, err := mongodb.NewMongoDBClient(database, shopFirstPurchaseCollection)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := mongodb.NewMongoDBClient(database, shopOrderCollection)
+	if err != nil {
+		return nil, err
+	}
+
+	// 按玩家查询订单的索引
+	if err = orders.EnsureIndex("idx_player_create_time", bson.D{{Key: "player_id", Value: 1}, {Key: "create_time", Value: -1}}, false); err != nil {
+		return nil, err
+	}
+
+	// 查询货币待发放订单的索引
+	if err = orders.EnsureIndex("idx_credit_pending", bson.D{{Key: "credit_pending", Value: 1}, {Key: "update_time", Value: 1}}, false); err != nil {
+		return nil, err
+	}
+
+	return &ShopDelivery{items: items, firsts: firsts, orders: orders, wallet: wallet}, nil
+}
+
+// FirstPurchased 查询玩家已首购过的物品
+func (d *ShopDelivery) FirstPurchased(playerID string, items []*ShopItem) (map[int]bool, error) {
+	ids := make([]string, 0)
+	for _, item := range items {
+		if item.FirstBonus != nil {
+			ids = append(ids, firstPurchaseID(playerID, item.ItemID))
+		}
+	}
+
+	purchased := make(map[int]bool, len(ids))
+	if len(ids) == 0 {
+		return purchased, nil
+	}
+
+	records := make([]firstPurchaseRecord, 0, len(ids))
+	if err := d.firsts.Find(bson.M{"_id": bson.M{"$in": ids}}, &records, 0, 0); err != nil {
+		return nil, err
+	}
+
+	for _, record := range records {
+		purchased[record.ItemID] = true
+	}
+
+	return purchased, nil
+}
+
+// Deliver 发放购买内容：首次购买时附加首购奖励，物品堆叠到背包，并在同一事务内将订单标记为已完成，
+// 订单已不在本次处理中时返回ErrOrderPending；物品写入失败时返回错误，调用方需退款。
+// 含货币的订单同时标记货币待发放，货币以订单ID为幂等键发放，失败时保持待发放由补发任务重试
+func (d *ShopDelivery) Deliver(order *define.ShopOrder, item *ShopItem) error {
+	var (
+		grant *ShopGrant
+		first bool
+	)
+
+	now := time.Now()
+	err := d.items.WithTransaction(func(ctx mongo.SessionContext) error {
+		grant = item.Contents.multiply(order.Count)
+		first = false
+
+		if item.FirstBonus != nil {
+			result, err := d.firsts.GetCollection().UpdateOne(ctx,
+				bson.M{"_id": firstPurchaseID(order.PlayerID, item.ItemID)},
+				bson.M{"$setOnInsert": bson.M{"player_id": order.PlayerID, "item_id": item.ItemID, "create_time": now}},
+				options.Update().SetUpsert(true))
+			if err != nil {
+				return err
+			}
+
+			if first = result.UpsertedCount == 1; first {
+				grant.add(item.FirstBonus)
+			}
+		}
+
+		result, err := d.orders.GetCollection().UpdateOne(ctx,
+			bson.M{"_id": order.ID, "status": define.ShopOrderPending, "attempt": order.Attempt},
+			bson.M{"$set": bson.M{
+				"status":         define.ShopOrderCompleted,
+				"total_price":    order.TotalPrice,
+				"currency_type":  order.CurrencyType,
+				"limit_id":       order.LimitID,
+				"items":          grant.Items,
+				"coin":           grant.Coin,
+				"diamond":        grant.Diamond,
+				"first_bonus":    first,
+				"credit_pending": grant.Coin > 0 || grant.Diamond > 0,
+				"update_time":    now,
+			}})
+		if err != nil {
+			return err
+		}
+
+		if result.MatchedCount == 0 {
+			return ErrOrderPending
+		}
+
+		return stackItems(ctx, d.items, order.PlayerID, grant.Items, now)
+	})
+	if err != nil {
+		return err
+	}
+
+	order.Status, order.UpdateTime = define.ShopOrderCompleted, now
+	order.Items, order.Coin, order.Diamond, order.FirstBonus = grant.Items, grant.Coin, grant.Diamond, first
+	order.CreditPending = grant.Coin > 0 || grant.Diamond > 0
+
+	// 物品已发放，货币发放失败时不影响购买结果
+	if err = d.Credit(order); err != nil {
+		log.Warnf("deliver shop currency failed, retry later: player_id=%s, order_id=%s, coin=%d, diamond=%d, err=%v",
+			order.PlayerID, order.ID, order.Coin, order.Diamond, err)
+	}
+
+	return nil
+}
+
+// Credit 补发订单待发放的货币并清除待发放标记，幂等键与发货时一致，重复调用不会重复发放
+func (d *ShopDelivery) Credit(order *define.ShopOrder) error {
+	if !order.CreditPending {
+		return nil
+	}
+
+	remark := fmt.Sprintf("order_id=%s, item_id=%d, count=%d", order.ID, order.ItemID, order.Count)
+	if order.Coin > 0 {
+		if _, err := d.wallet.Credit(order.PlayerID, define.CurrencyTypeCoin, order.Coin, define.WalletReasonShopBuy, "shop:"+order.ID+":coin", remark); err != nil {
+			return err
+		}
+	}
+
+	if order.Diamond > 0 {
+		if _, err := d.wallet.Credit(order.PlayerID, define.CurrencyTypeDiamond, order.Diamond, define.WalletReasonShopBuy, "shop:"+order.ID+":diamond", remark); err != nil {
+			return err
+		}
+	}
+
+	if err := d.orders.UpdateOne(bson.M{"_id": order.ID, "credit_pending": true}, bson.M{"$unset": bson.M{"credit_pending": ""}}); err != nil {
+		return err
+	}
+
+	order.CreditPending = false
+
+	return nil
+}
+
+// PendingCredits 查询更新时间早于before且货币待发放的订单，按更新时间升序
+func (d *ShopDelivery) PendingCredits(before time.Time, limit int64) ([]*define.ShopOrder, error) {
+	orders := make([]*define.ShopOrder, 0)
+	filter := bson.M{"credit_pending": true, "update_time": bson.M{"$lt": before}}
+	if err := d.orders.FindSort(filter, &orders, bson.D{{Key: "update_time", Value: 1}}, limit, 0); err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+func firstPurchaseID(playerID string, itemID int) string {
+	return fmt.Sprintf("%s:%d", playerID, itemID)
+}
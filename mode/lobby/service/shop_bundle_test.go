@@ -0,0 +1,87 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"ghserver/define"
+)
+
+// 礼包，价格680钻石，内含5000金币
+const testBundleItemID = 9001
+
+// 发货时金币发放失败，订单保持货币待发放，补发后只发放一次
+func TestShopManagerRetryCredits(t *testing.T) {
+	const (
+		playerID = "600000"
+		initial  = 10000
+	)
+
+	wallet := newFakeWallet(initial)
+	delivery := newFakeDelivery(wallet)
+	m := newTestShopManager(t, wallet, delivery)
+
+	wallet.failures = 1
+	result, err := m.BuyItem(playerID, 0, testBundleItemID, 1, 0, "")
+	if err != nil {
+		t.Fatalf("buy item failed: %v", err)
+	}
+
+	order, err := delivery.Load(result.OrderID)
+	if err != nil {
+		t.Fatalf("load order failed: %v", err)
+	}
+
+	if !order.CreditPending {
+		t.Fatalf("order credit pending = false, want true")
+	}
+
+	if balance := wallet.balance(playerID, define.CurrencyTypeCoin); balance != initial {
+		t.Fatalf("coin before retry = %d, want %d", balance, initial)
+	}
+
+	// 补发只处理更新时间早于一个补发间隔的订单
+	m.retryCredits(time.Now())
+	if balance := wallet.balance(playerID, define.CurrencyTypeCoin); balance != initial {
+		t.Fatalf("coin after early retry = %d, want %d", balance, initial)
+	}
+
+	for i := 0; i < 2; i++ {
+		m.retryCredits(time.Now().Add(creditRetryInterval + time.Second))
+	}
+
+	if balance := wallet.balance(playerID, define.CurrencyTypeCoin); balance != initial+result.Grant.Coin {
+		t.Errorf("coin after retry = %d, want %d", balance, initial+result.Grant.Coin)
+	}
+
+	if order, err = delivery.Load(result.OrderID); err != nil || order.CreditPending {
+		t.Errorf("order credit pending after retry = %v, err = %v, want false", order.CreditPending, err)
+	}
+}
+
+// 货币待发放的订单退款时先补发再回收，玩家余额恢复到购买前
+func TestShopManagerRefundOrderCreditPending(t *testing.T) {
+	const (
+		playerID = "600001"
+		initial  = 10000
+	)
+
+	wallet := newFakeWallet(initial)
+	m := newTestShopManager(t, wallet, newFakeDelivery(wallet))
+
+	wallet.failures = 1
+	result, err := m.BuyItem(playerID, 0, testBundleItemID, 1, 0, "")
+	if err != nil {
+		t.Fatalf("buy item failed: %v", err)
+	}
+
+	if _, err = m.RefundOrder(result.OrderID, "gm", "test"); err != nil {
+		t.Fatalf("refund order failed: %v", err)
+	}
+
+	for _, currencyType := range []int32{define.CurrencyTypeCoin, define.CurrencyTypeDiamond} {
+		if balance := wallet.balance(playerID, currencyType); balance != initial {
+			t.Errorf("currency %d balance after refund = %d, want %d", currencyType, balance, initial)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"ghserver/define"
+	"ghserver/utils/pricing"
+
+	"github.com/dobyte/due/v2/config"
+)
+
+const defaultCampaignTable = "shop_campaign" // 默认折扣活动配置表名
+
+// 折扣活动叠加方式
+const (
+	CampaignStackExclusive = 0 // 独占，不与物品折扣及其他活动叠加
+	CampaignStackMultiply  = 1 // 叠加，与物品折扣及其他叠加活动相乘
+)
+
+// ShopCampaignConfig 折扣活动配置，由策划导出到配置表
+type ShopCampaignConfig struct {
+	ID             string  `json:"id"`             // 活动ID
+	Name           string  `json:"name"`           // 活动名称
+	ShopTypes      []int   `json:"shopTypes"`      // 生效的商店类型，为空表示全部商店
+	ItemIDs        []int   `json:"itemIds"`        // 生效的物品ID，为空表示商店内全部物品
+	DiscountRate   float32 `json:"discountRate"`   // 折扣率，取值(0,1)，精确到万分之一
+	Stacking       int     `json:"stacking"`       // 叠加方式：0独占，1叠加
+	StartTime      int64   `json:"startTime"`      // 开始时间戳
+	EndTime        int64   `json:"endTime"`        // 结束时间戳
+	MinLevel       int     `json:"minLevel"`       // 最低等级，0表示不限
+	MaxLevel       int     `json:"maxLevel"`       // 最高等级，0表示不限
+	RegisterAfter  int64   `json:"registerAfter"`  // 注册时间不早于，0表示不限
+	RegisterBefore int64   `json:"registerBefore"` // 注册时间不晚于，0表示不限
+}
+
+// shopCampaign 折扣活动
+type shopCampaign struct {
+	ShopCampaignConfig
+	rate      pricing.Rate
+	shopTypes map[int]struct{}
+	itemIDs   map[int]struct{}
+}
+
+// campaignSet 折扣活动集合，加载后只读，重新加载时整体替换
+type campaignSet struct {
+	campaigns []*shopCampaign
+}
+
+// 从配置中心读取并校验折扣活动配置表，配置表不存在时没有活动
+func loadShopCampaigns(table string) (*campaignSet, error) {
+	if !config.Has(table) {
+		return &campaignSet{}, nil
+	}
+
+	configs := make([]ShopCampaignConfig, 0)
+	if err := config.Get(table + ".campaigns").Scan(&configs); err != nil {
+		return nil, fmt.Errorf("invalid campaign table %s: %v", table, err)
+	}
+
+	return newCampaignSet(configs)
+}
+
+// 校验配置并生成活动集合，存在错误时返回全部错误
+func newCampaignSet(configs []ShopCampaignConfig) (*campaignSet, error) {
+	set := &campaignSet{campaigns: make([]*shopCampaign, 0, len(configs))}
+	ids := make(map[string]struct{}, len(configs))
+
+	errs := make([]error, 0)
+	for i, c := range configs {
+		if err := validateCampaign(&c); err != nil {
+			errs = append(errs, fmt.Errorf("campaigns[%d]: %v", i, err))
+			continue
+		}
+
+		if _, ok := ids[c.ID]; ok {
+			errs = append(errs, fmt.Errorf("campaigns[%d]: duplicate campaign id %s", i, c.ID))
+			continue
+		}
+		ids[c.ID] = struct{}{}
+
+		campaign := &shopCampaign{
+			ShopCampaignConfig: c,
+			rate:               pricing.FromFloat(float64(c.DiscountRate)),
+			shopTypes:          make(map[int]struct{}, len(c.ShopTypes)),
+			itemIDs:            make(map[int]struct{}, len(c.ItemIDs)),
+		}
+
+		for _, shopType := range c.ShopTypes {
+			campaign.shopTypes[shopType] = struct{}{}
+		}
+
+		for _, itemID := range c.ItemIDs {
+			campaign.itemIDs[itemID] = struct{}{}
+		}
+
+		set.campaigns = append(set.campaigns, campaign)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return set, nil
+}
+
+// 校验单个折扣活动配置
+func validateCampaign(c *ShopCampaignConfig) error {
+	rate := pricing.FromFloat(float64(c.DiscountRate))
+
+	switch {
+	case c.ID == "":
+		return errors.New("missing campaign id")
+	case rate <= 0 || rate >= pricing.Full:
+		return fmt.Errorf("campaign %s: invalid discount rate %v", c.ID, c.DiscountRate)
+	case c.Stacking != CampaignStackExclusive && c.Stacking != CampaignStackMultiply:
+		return fmt.Errorf("campaign %s: unknown stacking %d", c.ID, c.Stacking)
+	case c.EndTime <= c.StartTime:
+		return fmt.Errorf("campaign %s: end time must be after start time", c.ID)
+	case c.MaxLevel > 0 && c.MinLevel > c.MaxLevel:
+		return fmt.Errorf("campaign %s: min level greater than max level", c.ID)
+	case c.RegisterBefore > 0 && c.RegisterAfter > c.RegisterBefore:
+		return fmt.Errorf("campaign %s: register after later than register before", c.ID)
+	}
+
+	return nil
+}
+
+// 活动是否在进行中
+func (c *shopCampaign) active(now time.Time) bool {
+	return now.Unix() >= c.StartTime && now.Unix() < c.EndTime
+}
+
+// 活动是否限定参与玩家
+func (c *shopCampaign) hasAudience() bool {
+	return c.MinLevel > 0 || c.MaxLevel > 0 || c.RegisterAfter > 0 || c.RegisterBefore > 0
+}
+
+// 玩家是否满足参与条件，玩家不存在时只能参与不限定玩家的活动
+func (c *shopCampaign) matches(player *define.Player) bool {
+	if !c.hasAudience() {
+		return true
+	}
+
+	if player == nil {
+		return false
+	}
+
+	if c.MinLevel > 0 && player.Level < c.MinLevel {
+		return false
+	}
+
+	if c.MaxLevel > 0 && player.Level > c.MaxLevel {
+		return false
+	}
+
+	if c.RegisterAfter > 0 && player.CreateTime.Unix() < c.RegisterAfter {
+		return false
+	}
+
+	if c.RegisterBefore > 0 && player.CreateTime.Unix() > c.RegisterBefore {
+		return false
+	}
+
+	return true
+}
+
+// 活动是否覆盖物品
+func (c *shopCampaign) covers(item *ShopItem) bool {
+	if len(c.shopTypes) > 0 {
+		if _, ok := c.shopTypes[item.ShopType]; !ok {
+			return false
+		}
+	}
+
+	if len(c.itemIDs) > 0 {
+		if _, ok := c.itemIDs[item.ItemID]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// 收集物品适用的折扣率：物品折扣与覆盖该物品的叠加活动折扣，以及最优的独占活动折扣
+func discountRates(item *ShopItem, campaigns []*shopCampaign) (stacked []pricing.Rate, exclusive pricing.Rate) {
+	stacked = []pricing.Rate{item.Discount}
+	exclusive = pricing.Full
+
+	for _, campaign := range campaigns {
+		if !campaign.covers(item) {
+			continue
+		}
+
+		if campaign.Stacking == CampaignStackMultiply {
+			stacked = append(stacked, campaign.rate)
+		} else if campaign.rate < exclusive {
+			exclusive = campaign.rate
+		}
+	}
+
+	return stacked, exclusive
+}
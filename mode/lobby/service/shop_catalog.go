@@ -0,0 +1,144 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+
+	"ghserver/define"
+	"ghserver/utils/pricing"
+
+	"github.com/dobyte/due/v2/config"
+)
+
+const defaultShopTable = "shop" // 默认商店配置表名
+
+// ShopItemConfig 商店物品配置，由策划导出到配置表
+type ShopItemConfig struct {
+	ShopType     int              `json:"shopType"`     // 商店类型
+	ItemID       int              `json:"itemId"`       // 物品或礼包ID，所有商店内唯一
+	Price        int              `json:"price"`        // 原价
+	CurrencyType int              `json:"currencyType"` // 货币类型：1金币，2钻石
+	Stock        int              `json:"stock"`        // 库存，0表示不限
+	MaxBuyCount  int              `json:"maxBuyCount"`  // 每个限购周期内每人限购数量，0表示不限
+	LimitType    int              `json:"limitType"`    // 限购周期：0永久，1每日，2每周，3活动期间
+	LimitEvent   string           `json:"limitEvent"`   // 活动ID，活动期间限购时必填，更换活动ID即重新计数
+	DiscountRate float32          `json:"discountRate"` // 折扣率，精确到万分之一，0或1表示不打折
+	ExpireTime   int64            `json:"expireTime"`   // 下架时间戳，0表示永久
+	Contents     *ShopGrantConfig `json:"contents"`     // 礼包内容，为空表示物品本身
+	FirstBonus   *ShopGrantConfig `json:"firstBonus"`   // 首购奖励，为空表示没有
+}
+
+// shopCatalog 商品目录，加载后只读，重新加载时整体替换
+type shopCatalog struct {
+	shops map[int][]*ShopItem // shop_type -> items
+	items map[int]*ShopItem   // item_id -> item
+}
+
+// 从配置中心读取并校验商店配置表
+func loadShopCatalog(table string) (*shopCatalog, error) {
+	if !config.Has(table) {
+		return nil, fmt.Errorf("shop table %s not found", table)
+	}
+
+	configs := make([]ShopItemConfig, 0)
+	if err := config.Get(table + ".items").Scan(&configs); err != nil {
+		return nil, fmt.Errorf("invalid shop table %s: %v", table, err)
+	}
+
+	return newShopCatalog(configs)
+}
+
+// 校验配置并生成商品目录，存在错误时返回全部错误且不生成目录
+func newShopCatalog(configs []ShopItemConfig) (*shopCatalog, error) {
+	catalog := &shopCatalog{
+		shops: make(map[int][]*ShopItem),
+		items: make(map[int]*ShopItem, len(configs)),
+	}
+
+	errs := make([]error, 0)
+	for i, c := range configs {
+		if err := validateShopItem(&c); err != nil {
+			errs = append(errs, fmt.Errorf("items[%d]: %v", i, err))
+			continue
+		}
+
+		if _, ok := catalog.items[c.ItemID]; ok {
+			errs = append(errs, fmt.Errorf("items[%d]: duplicate item id %d", i, c.ItemID))
+			continue
+		}
+
+		contents, err := newShopGrant(c.Contents)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("items[%d]: item %d: contents: %v", i, c.ItemID, err))
+			continue
+		}
+
+		if contents == nil {
+			contents = singleGrant(c.ItemID)
+		}
+
+		firstBonus, err := newShopGrant(c.FirstBonus)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("items[%d]: item %d: first bonus: %v", i, c.ItemID, err))
+			continue
+		}
+
+		discount := pricing.Full
+		if c.DiscountRate > 0 {
+			discount = pricing.FromFloat(float64(c.DiscountRate))
+		}
+
+		item := &ShopItem{
+			ShopType:      c.ShopType,
+			ItemID:        c.ItemID,
+			OriginalPrice: c.Price,
+			CurrentPrice:  c.Price,
+			CurrencyType:  c.CurrencyType,
+			Stock:         c.Stock,
+			MaxBuyCount:   c.MaxBuyCount,
+			LimitType:     c.LimitType,
+			LimitEvent:    c.LimitEvent,
+			ExpireTime:    c.ExpireTime,
+			IsDiscount:    discount.Discounted(),
+			DiscountRate:  discount.Float32(),
+			Discount:      discount,
+			Contents:      contents,
+			FirstBonus:    firstBonus,
+		}
+
+		catalog.items[item.ItemID] = item
+		catalog.shops[c.ShopType] = append(catalog.shops[c.ShopType], item)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return catalog, nil
+}
+
+// 校验单个商店物品配置
+func validateShopItem(c *ShopItemConfig) error {
+	switch {
+	case c.ShopType <= 0:
+		return fmt.Errorf("item %d: invalid shop type %d", c.ItemID, c.ShopType)
+	case c.ItemID <= 0:
+		return fmt.Errorf("invalid item id %d", c.ItemID)
+	case c.CurrencyType != define.CurrencyTypeCoin && c.CurrencyType != define.CurrencyTypeDiamond:
+		return fmt.Errorf("item %d: unknown currency type %d", c.ItemID, c.CurrencyType)
+	case c.Price < 0:
+		return fmt.Errorf("item %d: negative price %d", c.ItemID, c.Price)
+	case c.Stock < 0:
+		return fmt.Errorf("item %d: negative stock %d", c.ItemID, c.Stock)
+	case c.MaxBuyCount < 0:
+		return fmt.Errorf("item %d: negative max buy count %d", c.ItemID, c.MaxBuyCount)
+	case c.DiscountRate < 0 || c.DiscountRate > 1 || (c.DiscountRate > 0 && pricing.FromFloat(float64(c.DiscountRate)) == 0):
+		return fmt.Errorf("item %d: invalid discount rate %v", c.ItemID, c.DiscountRate)
+	case c.LimitType < define.PurchaseLimitLifetime || c.LimitType > define.PurchaseLimitEvent:
+		return fmt.Errorf("item %d: unknown limit type %d", c.ItemID, c.LimitType)
+	case c.LimitType == define.PurchaseLimitEvent && c.LimitEvent == "":
+		return fmt.Errorf("item %d: missing limit event", c.ItemID)
+	}
+
+	return nil
+}
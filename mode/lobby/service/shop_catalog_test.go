@@ -0,0 +1,121 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"ghserver/define"
+
+	"github.com/dobyte/due/v2/config"
+	"github.com/dobyte/due/v2/config/file"
+)
+
+// 按 configs/table 下的商店及折扣活动配置表计算价格
+func TestItemPriceTable(t *testing.T) {
+	config.SetConfigurator(config.NewConfigurator(config.WithSources(file.NewSource(file.WithPath("../../../configs/table")))))
+
+	catalog, err := loadShopCatalog(defaultShopTable)
+	if err != nil {
+		t.Fatalf("load shop catalog failed: %v", err)
+	}
+
+	campaigns, err := loadShopCampaigns(defaultCampaignTable)
+	if err != nil {
+		t.Fatalf("load shop campaigns failed: %v", err)
+	}
+
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.Local)
+
+	cases := []struct {
+		name   string
+		player *define.Player
+		want   map[int]int // 物品ID -> 单价
+	}{
+		{
+			name: "无活动",
+			want: map[int]int{1001: 100, 1002: 200, 1003: 50, 2001: 900, 2005: 3500, 3001: 80, 4001: 1000, 9001: 680},
+		},
+		{
+			// 周末特惠与物品折扣叠加，新手礼遇独占且更低
+			name:   "新手玩家",
+			player: &define.Player{Level: 5},
+			want:   map[int]int{1001: 50, 1002: 100, 1003: 45, 2001: 810, 2005: 3500, 3001: 80, 4001: 1000, 9001: 680},
+		},
+		{
+			// 钻石折扣独占，物品自身折扣更低时保留物品折扣
+			name:   "高等级玩家",
+			player: &define.Player{Level: 30},
+			want:   map[int]int{1001: 90, 1002: 180, 1003: 45, 2001: 810, 2005: 3500, 3001: 80, 4001: 850, 9001: 578},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			active := make([]*shopCampaign, 0)
+			if c.player != nil {
+				for _, campaign := range campaigns.campaigns {
+					if campaign.active(now) && campaign.matches(c.player) {
+						active = append(active, campaign)
+					}
+				}
+			}
+
+			if len(catalog.items) != len(c.want) {
+				t.Fatalf("catalog items = %d, want %d", len(catalog.items), len(c.want))
+			}
+
+			for itemID, want := range c.want {
+				item, ok := catalog.items[itemID]
+				if !ok {
+					t.Errorf("item %d not found", itemID)
+					continue
+				}
+
+				if got, _ := itemPrice(item, active); got != want {
+					t.Errorf("item %d price = %d, want %d", itemID, got, want)
+				}
+			}
+		})
+	}
+}
+
+// 价格不能整除时按四舍五入取整，叠加折扣只在最后取整一次
+func TestItemPriceRounding(t *testing.T) {
+	catalog, err := newShopCatalog([]ShopItemConfig{
+		{ShopType: 1, ItemID: 5001, Price: 995, CurrencyType: 1, DiscountRate: 0.7},
+		{ShopType: 1, ItemID: 5002, Price: 15, CurrencyType: 1, DiscountRate: 0.7},
+		{ShopType: 1, ItemID: 5003, Price: 999, CurrencyType: 1, DiscountRate: 0.7},
+	})
+	if err != nil {
+		t.Fatalf("new shop catalog failed: %v", err)
+	}
+
+	campaigns, err := newCampaignSet([]ShopCampaignConfig{
+		{ID: "stack", ShopTypes: []int{1}, DiscountRate: 0.9, Stacking: CampaignStackMultiply, StartTime: 1, EndTime: 2},
+	})
+	if err != nil {
+		t.Fatalf("new campaign set failed: %v", err)
+	}
+
+	cases := []struct {
+		name      string
+		itemID    int
+		campaigns []*shopCampaign
+		want      int
+	}{
+		// 995×0.7=696.5，向下取整为696
+		{name: "恰好一半进位", itemID: 5001, want: 697},
+		// 999×0.7=699.3，向上取整为700
+		{name: "不足一半舍去", itemID: 5003, want: 699},
+		// 995×0.7×0.9=626.85，向下取整为626
+		{name: "叠加后取整", itemID: 5001, campaigns: campaigns.campaigns, want: 627},
+		// 15×0.7×0.9=9.45，逐次取整时为10.5→11、9.9→10
+		{name: "叠加只取整一次", itemID: 5002, campaigns: campaigns.campaigns, want: 9},
+	}
+
+	for _, c := range cases {
+		if got, _ := itemPrice(catalog.items[c.itemID], c.campaigns); got != c.want {
+			t.Errorf("%s: item %d price = %d, want %d", c.name, c.itemID, got, c.want)
+		}
+	}
+}
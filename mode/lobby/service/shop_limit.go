@@ -0,0 +1,157 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"ghserver/define"
+	"ghserver/utils/mongodb"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const shopPurchaseCollection = "shop_purchase" // 玩家限购记录集合
+
+var ErrPurchaseLimit = errors.New("超出购买限制")
+
+// purchaseRecord 玩家在一个限购周期内的购买记录
+type purchaseRecord struct {
+	ID         string    `bson:"_id"`
+	PlayerID   string    `bson:"player_id"`
+	ItemID     int       `bson:"item_id"`
+	Window     string    `bson:"window"`
+	Count      int       `bson:"count"`
+	ExpireTime time.Time `bson:"expire_time,omitempty"`
+}
+
+// Limiter 限购，按限购周期记录玩家的购买数量
+type Limiter interface {
+	// Take 占用限购数量，超出限购时返回ErrPurchaseLimit；返回记录ID，购买失败时用于退回
+	Take(playerID string, item *ShopItem, count int) (string, error)
+	// Release 退回占用的限购数量
+	Release(id string, count int) error
+	// Counts 查询玩家在各限购物品当前周期内的购买数量
+	Counts(playerID string, items []*ShopItem) (map[int]int, error)
+}
+
+// PurchaseLimiter 限购管理器，按限购周期持久化玩家的购买数量，周期结束后重新计数
+type PurchaseLimiter struct {
+	records *mongodb.MongoDBClient
+}
+
+func NewPurchaseLimiter(database string) (*PurchaseLimiter, error) {
+	records, err := mongodb.NewMongoDBClient(database, shopPurchaseCollection)
+	if err != nil {
+		return nil, err
+	}
+
+	// 周期结束的记录自动清理
+	if err = records.EnsureTTLIndex("ttl_expire_time", "expire_time", 0); err != nil {
+		return nil, err
+	}
+
+	return &PurchaseLimiter{records: records}, nil
+}
+
+// Take 占用限购数量，以条件更新保证并发购买不超过限购数量；返回记录ID，购买失败时用于退回
+func (l *PurchaseLimiter) Take(playerID string, item *ShopItem, count int) (string, error) {
+	if item.MaxBuyCount == 0 {
+		return "", nil
+	}
+
+	if count > item.MaxBuyCount {
+		return "", ErrPurchaseLimit
+	}
+
+	window, resetTime := purchaseWindow(item, time.Now())
+	id := purchaseID(playerID, item.ItemID, window)
+
+	setOnInsert := bson.M{"player_id": playerID, "item_id": item.ItemID, "window": window}
+	if !resetTime.IsZero() {
+		setOnInsert["expire_time"] = resetTime
+	}
+
+	filter := bson.M{"_id": id, "count": bson.M{"$lte": item.MaxBuyCount - count}}
+	update := bson.M{"$inc": bson.M{"count": count}, "$setOnInsert": setOnInsert}
+
+	// 记录已存在但数量不满足条件时插入会主键冲突；首次并发插入也会冲突，因此冲突后重试一次
+	for i := 0; i < 2; i++ {
+		err := l.records.UpdateOne(filter, update, options.Update().SetUpsert(true))
+		if err == nil {
+			return id, nil
+		}
+
+		if !mongo.IsDuplicateKeyError(err) {
+			return "", err
+		}
+	}
+
+	return "", ErrPurchaseLimit
+}
+
+// Release 退回占用的限购数量
+func (l *PurchaseLimiter) Release(id string, count int) error {
+	if id == "" {
+		return nil
+	}
+
+	return l.records.UpdateOne(bson.M{"_id": id}, bson.M{"$inc": bson.M{"count": -count}})
+}
+
+// Counts 查询玩家在各限购物品当前周期内的购买数量
+func (l *PurchaseLimiter) Counts(playerID string, items []*ShopItem) (map[int]int, error) {
+	now := time.Now()
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		if item.MaxBuyCount > 0 {
+			window, _ := purchaseWindow(item, now)
+			ids = append(ids, purchaseID(playerID, item.ItemID, window))
+		}
+	}
+
+	counts := make(map[int]int, len(ids))
+	if len(ids) == 0 {
+		return counts, nil
+	}
+
+	records := make([]purchaseRecord, 0, len(ids))
+	if err := l.records.Find(bson.M{"_id": bson.M{"$in": ids}}, &records, 0, 0); err != nil {
+		return nil, err
+	}
+
+	for _, record := range records {
+		counts[record.ItemID] = record.Count
+	}
+
+	return counts, nil
+}
+
+// 计算物品当前的限购周期及重置时间，重置时间为零值表示不重置
+func purchaseWindow(item *ShopItem, t time.Time) (string, time.Time) {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+
+	switch item.LimitType {
+	case define.PurchaseLimitDaily:
+		return day.Format("20060102"), day.AddDate(0, 0, 1)
+	case define.PurchaseLimitWeekly:
+		// 周一为每周第一天
+		start := day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+		year, week := start.ISOWeek()
+		return fmt.Sprintf("%dW%02d", year, week), start.AddDate(0, 0, 7)
+	case define.PurchaseLimitEvent:
+		var end time.Time
+		if item.ExpireTime > 0 {
+			end = time.Unix(item.ExpireTime, 0)
+		}
+		return "E" + item.LimitEvent, end
+	default:
+		return "all", time.Time{}
+	}
+}
+
+func purchaseID(playerID string, itemID int, window string) string {
+	return fmt.Sprintf("%s:%d:%s", playerID, itemID, window)
+}
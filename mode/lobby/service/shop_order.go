@@ -0,0 +1,320 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"ghserver/define"
+
+	"github.com/dobyte/due/v2/utils/xuuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	shopOrderCollection = "shop_order" // 商店订单集合
+	orderTimeout        = time.Minute  // 处理中的订单超时后，携带相同幂等键的请求可接管处理
+)
+
+var (
+	ErrOrderNotFound      = errors.New("订单不存在")
+	ErrOrderConflict      = errors.New("幂等键已用于其他购买")
+	ErrOrderPending       = errors.New("订单处理中")
+	ErrOrderNotRefundable = errors.New("订单不可退款")
+	ErrRefundItemUsed     = errors.New("物品已使用，无法退款")
+	ErrRefundBalance      = errors.New("玩家货币不足，无法回收")
+)
+
+// Open 创建处理中的订单；幂等键已存在时，已完成或已退款的订单直接返回且done为true，
+// 失败的订单以新的扣款幂等键重新处理，超时未完成的订单由本次请求以原扣款幂等键接管
+func (d *ShopDelivery) Open(playerID string, shopType, itemID, count int, idempotencyKey string) (order *define.ShopOrder, done bool, err error) {
+	now := time.Now()
+	order = &define.ShopOrder{
+		ID:             xuuid.UUID(),
+		PlayerID:       playerID,
+		IdempotencyKey: idempotencyKey,
+		ShopType:       shopType,
+		ItemID:         itemID,
+		Count:          count,
+		Items:          []define.ItemInfo{},
+		Status:         define.ShopOrderPending,
+		CreateTime:     now,
+		UpdateTime:     now,
+	}
+
+	if idempotencyKey != "" {
+		order.ID = playerID + ":" + idempotencyKey
+	}
+
+	if _, err = d.orders.InsertOne(order); err == nil || idempotencyKey == "" || !mongo.IsDuplicateKeyError(err) {
+		return order, false, err
+	}
+
+	existing := &define.ShopOrder{}
+	if err = d.orders.FindOne(bson.M{"_id": order.ID}, existing); err != nil {
+		return nil, false, err
+	}
+
+	if existing.ShopType != shopType || existing.ItemID != itemID || existing.Count != count {
+		return nil, false, ErrOrderConflict
+	}
+
+	var filter, update bson.M
+	switch existing.Status {
+	case define.ShopOrderPending:
+		if now.Sub(existing.UpdateTime) < orderTimeout {
+			return nil, false, ErrOrderPending
+		}
+		filter = bson.M{"_id": existing.ID, "status": define.ShopOrderPending, "update_time": existing.UpdateTime}
+		update = bson.M{"update_time": now}
+	case define.ShopOrderFailed:
+		filter = bson.M{"_id": existing.ID, "status": define.ShopOrderFailed, "attempt": existing.Attempt}
+		update = bson.M{"status": define.ShopOrderPending, "attempt": existing.Attempt + 1, "remark": "", "update_time": now}
+	default:
+		return existing, true, nil
+	}
+
+	err = d.orders.FindOneAndUpdate(filter, bson.M{"$set": update}, existing, options.FindOneAndUpdate().SetReturnDocument(options.After))
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, false, ErrOrderPending
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	return existing, false, nil
+}
+
+// Fail 标记本次处理失败，失败原因记录在订单备注中
+func (d *ShopDelivery) Fail(order *define.ShopOrder, cause error) error {
+	filter := bson.M{"_id": order.ID, "status": define.ShopOrderPending, "attempt": order.Attempt}
+	update := bson.M{"$set": bson.M{"status": define.ShopOrderFailed, "remark": cause.Error(), "update_time": time.Now()}}
+	return d.orders.UpdateOne(filter, update)
+}
+
+// Load 查询订单
+func (d *ShopDelivery) Load(orderID string) (*define.ShopOrder, error) {
+	order := &define.ShopOrder{}
+	if err := d.orders.FindOne(bson.M{"_id": orderID}, order); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// Reverse 回收订单发放的物品并标记退款中，物品已被使用时返回ErrRefundItemUsed；
+// 发放过首购奖励时删除首购记录，玩家可再次获得首购奖励
+func (d *ShopDelivery) Reverse(order *define.ShopOrder, operator, reason string) error {
+	now := time.Now()
+	err := d.orders.WithTransaction(func(ctx mongo.SessionContext) error {
+		result, err := d.orders.GetCollection().UpdateOne(ctx,
+			bson.M{"_id": order.ID, "status": define.ShopOrderCompleted},
+			bson.M{"$set": bson.M{"status": define.ShopOrderRefunding, "remark": reason, "operator": operator, "update_time": now}})
+		if err != nil {
+			return err
+		}
+
+		if result.MatchedCount == 0 {
+			return ErrOrderNotRefundable
+		}
+
+		for _, item := range order.Items {
+			result, err = d.items.GetCollection().UpdateOne(ctx,
+				bson.M{"_id": bagItemID(order.PlayerID, item.ItemID), "count": bson.M{"$gte": item.Count}},
+				bson.M{"$inc": bson.M{"count": -item.Count}})
+			if err != nil {
+				return err
+			}
+
+			if result.MatchedCount == 0 {
+				return ErrRefundItemUsed
+			}
+		}
+
+		if order.FirstBonus {
+			_, err = d.firsts.GetCollection().DeleteOne(ctx, bson.M{"_id": firstPurchaseID(order.PlayerID, order.ItemID)})
+		}
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	order.Status, order.Remark, order.Operator, order.UpdateTime = define.ShopOrderRefunding, reason, operator, now
+
+	return nil
+}
+
+// Restore 撤销退款中的订单：退回回收的物品并恢复为已完成，恢复首购记录，
+// 退款重试次数加一，再次退款时使用新的货币回收幂等键
+func (d *ShopDelivery) Restore(order *define.ShopOrder) error {
+	now := time.Now()
+	err := d.orders.WithTransaction(func(ctx mongo.SessionContext) error {
+		result, err := d.orders.GetCollection().UpdateOne(ctx,
+			bson.M{"_id": order.ID, "status": define.ShopOrderRefunding, "refund_attempt": order.RefundAttempt},
+			bson.M{
+				"$set": bson.M{"status": define.ShopOrderCompleted, "update_time": now},
+				"$inc": bson.M{"refund_attempt": 1},
+			})
+		if err != nil {
+			return err
+		}
+
+		if result.MatchedCount == 0 {
+			return ErrOrderPending
+		}
+
+		if order.FirstBonus {
+			_, err = d.firsts.GetCollection().UpdateOne(ctx,
+				bson.M{"_id": firstPurchaseID(order.PlayerID, order.ItemID)},
+				bson.M{"$setOnInsert": bson.M{"player_id": order.PlayerID, "item_id": order.ItemID, "create_time": now}},
+				options.Update().SetUpsert(true))
+			if err != nil {
+				return err
+			}
+		}
+
+		return stackItems(ctx, d.items, order.PlayerID, order.Items, now)
+	})
+	if err != nil {
+		return err
+	}
+
+	order.Status, order.RefundAttempt, order.UpdateTime = define.ShopOrderCompleted, order.RefundAttempt+1, now
+
+	return nil
+}
+
+// MarkRefunded 标记已退款，返回false表示订单已被标记
+func (d *ShopDelivery) MarkRefunded(order *define.ShopOrder) (bool, error) {
+	now := time.Now()
+	err := d.orders.FindOneAndUpdate(
+		bson.M{"_id": order.ID, "status": define.ShopOrderRefunding},
+		bson.M{"$set": bson.M{"status": define.ShopOrderRefunded, "update_time": now}},
+		&define.ShopOrder{},
+	)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	order.Status, order.UpdateTime = define.ShopOrderRefunded, now
+
+	return true, nil
+}
+
+// RefundOrder 订单退款：回收发放的物品及货币，退回实付金额及限购数量，售出的限量库存不退回；
+// 退款中断时可重复调用，货币变更以订单ID及退款重试次数为幂等键只执行一次；
+// 玩家货币不足以回收时退回已回收的物品及货币，订单恢复为已完成并返回ErrRefundBalance
+func (m *ShopManager) RefundOrder(orderID, operator, reason string) (*define.ShopOrder, error) {
+	order, err := m.delivery.Load(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 发放的货币尚未计入钱包时先补发，再按发放内容回收
+	if err = m.delivery.Credit(order); err != nil {
+		return nil, err
+	}
+
+	switch order.Status {
+	case define.ShopOrderCompleted:
+		if err = m.delivery.Reverse(order, operator, reason); err != nil {
+			return nil, err
+		}
+	case define.ShopOrderRefunding:
+	case define.ShopOrderRefunded:
+		return order, nil
+	default:
+		return nil, ErrOrderNotRefundable
+	}
+
+	// 先回收发放的货币，再退回实付金额
+	// 与购买失败时退回扣款的幂等键（refund:shop:订单ID:处理次数）区分，避免订单重试后退款被视为重复请求
+	key := fmt.Sprintf("order_refund:%s:%d", order.ID, order.RefundAttempt)
+	remark := fmt.Sprintf("order_id=%s, operator=%s", order.ID, operator)
+	if err = m.reclaim(order, key, remark); err != nil {
+		return nil, err
+	}
+
+	if order.TotalPrice > 0 {
+		if _, err = m.wallet.Credit(order.PlayerID, int32(order.CurrencyType), order.TotalPrice, define.WalletReasonRefund, key, remark); err != nil {
+			return nil, err
+		}
+	}
+
+	refunded, err := m.delivery.MarkRefunded(order)
+	if err != nil {
+		return nil, err
+	}
+
+	if refunded {
+		m.releaseLimit(order.LimitID, order.Count)
+	}
+
+	return order, nil
+}
+
+// 回收订单发放的货币；余额不足时退回本次已回收的货币并撤销退款，其他错误保持退款中以便重试
+func (m *ShopManager) reclaim(order *define.ShopOrder, key, remark string) error {
+	grants := []struct {
+		currencyType int32
+		amount       int64
+		key          string
+	}{
+		{define.CurrencyTypeCoin, order.Coin, key + ":coin"},
+		{define.CurrencyTypeDiamond, order.Diamond, key + ":diamond"},
+	}
+
+	for i, grant := range grants {
+		if grant.amount <= 0 {
+			continue
+		}
+
+		_, err := m.wallet.Debit(order.PlayerID, grant.currencyType, grant.amount, define.WalletReasonRefund, grant.key, remark)
+		if err == nil {
+			continue
+		}
+
+		if !errors.Is(err, ErrInsufficientBalance) {
+			return err
+		}
+
+		for _, reclaimed := range grants[:i] {
+			if reclaimed.amount <= 0 {
+				continue
+			}
+
+			if _, err = m.wallet.Credit(order.PlayerID, reclaimed.currencyType, reclaimed.amount, define.WalletReasonRefund, reclaimed.key+":restore", remark); err != nil {
+				return err
+			}
+		}
+
+		if err = m.delivery.Restore(order); err != nil {
+			return err
+		}
+
+		return ErrRefundBalance
+	}
+
+	return nil
+}
+
+// 由订单生成购买结果，用于幂等重试时返回首次购买的结果
+func orderResult(order *define.ShopOrder) *PurchaseResult {
+	return &PurchaseResult{
+		OrderID:      order.ID,
+		Grant:        &ShopGrant{Items: order.Items, Coin: order.Coin, Diamond: order.Diamond},
+		FirstBonus:   order.FirstBonus,
+		TotalPrice:   int(order.TotalPrice),
+		CurrencyType: order.CurrencyType,
+	}
+}
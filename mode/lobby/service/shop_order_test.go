@@ -0,0 +1,51 @@
+package server
+
+import (
+	"testing"
+
+	"ghserver/define"
+)
+
+// 首次处理发货失败并退回扣款后重试成功，订单退款不能因幂等键与失败时的退回相同而被跳过
+func TestShopManagerRefundOrderAfterFailedAttempt(t *testing.T) {
+	const (
+		playerID = "400000"
+		key      = "buy-retry"
+		initial  = 10000
+	)
+
+	wallet := newFakeWallet(initial)
+	delivery := newFakeDelivery(wallet)
+	delivery.failures = 1
+	m := newTestShopManager(t, wallet, delivery)
+
+	if _, err := m.BuyItem(playerID, 0, 1001, 1, 0, key); err == nil {
+		t.Fatalf("first attempt succeeded, want deliver failure")
+	}
+
+	if balance := wallet.balance(playerID, define.CurrencyTypeCoin); balance != initial {
+		t.Fatalf("balance after failed attempt = %d, want %d", balance, initial)
+	}
+
+	result, err := m.BuyItem(playerID, 0, 1001, 1, 0, key)
+	if err != nil {
+		t.Fatalf("retry buy item failed: %v", err)
+	}
+
+	if balance := wallet.balance(playerID, define.CurrencyTypeCoin); balance != initial-int64(result.TotalPrice) {
+		t.Fatalf("balance after retry = %d, want %d", balance, initial-int64(result.TotalPrice))
+	}
+
+	order, err := m.RefundOrder(result.OrderID, "gm", "test")
+	if err != nil {
+		t.Fatalf("refund order failed: %v", err)
+	}
+
+	if order.Attempt != 1 || order.Status != define.ShopOrderRefunded {
+		t.Errorf("order attempt = %d, status = %d, want attempt 1, status %d", order.Attempt, order.Status, define.ShopOrderRefunded)
+	}
+
+	if balance := wallet.balance(playerID, define.CurrencyTypeCoin); balance != initial {
+		t.Errorf("balance after refund = %d, want %d", balance, initial)
+	}
+}
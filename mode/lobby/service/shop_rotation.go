@@ -0,0 +1,567 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"time"
+
+	"ghserver/define"
+	"ghserver/utils/mongodb"
+	"ghserver/utils/pricing"
+
+	"github.com/dobyte/due/v2/config"
+	"github.com/dobyte/due/v2/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	defaultRotationTable   = "shop_rotation" // 默认轮换商店配置表名
+	shopRotationCollection = "shop_rotation" // 玩家轮换商店状态集合
+)
+
+var (
+	ErrRefreshLimit       = errors.New("刷新次数已用完")
+	ErrShopRefreshed      = errors.New("商店已刷新")
+	ErrRefreshCostChanged = errors.New("刷新价格已变化")
+)
+
+// RotationPoolItem 轮换商店物品池配置
+type RotationPoolItem struct {
+	ItemID       int     `json:"itemId"`       // 物品ID，同一物品池内唯一
+	Price        int     `json:"price"`        // 原价
+	CurrencyType int     `json:"currencyType"` // 货币类型：1金币，2钻石
+	DiscountRate float32 `json:"discountRate"` // 折扣率，精确到万分之一，0或1表示不打折
+	Weight       int     `json:"weight"`       // 抽取权重
+	MinStock     int     `json:"minStock"`     // 每人最少可购买数量
+	MaxStock     int     `json:"maxStock"`     // 每人最多可购买数量，实际数量在范围内随机
+}
+
+// RotationShopConfig 轮换商店配置，定时从物品池按权重抽取物品，玩家可付费手动刷新
+type RotationShopConfig struct {
+	ShopType        int                `json:"shopType"`        // 商店类型，不应与普通商店重复
+	Slots           int                `json:"slots"`           // 每次抽取的物品数量
+	Period          int64              `json:"period"`          // 自动刷新周期，单位秒
+	MaxRefresh      int                `json:"maxRefresh"`      // 每日手动刷新次数上限，0表示不可手动刷新
+	RefreshCurrency int                `json:"refreshCurrency"` // 手动刷新货币类型：1金币，2钻石
+	RefreshCosts    []int              `json:"refreshCosts"`    // 当日第n次手动刷新的价格，超出后按最后一档
+	Pool            []RotationPoolItem `json:"pool"`            // 物品池
+}
+
+// rotationShop 轮换商店
+type rotationShop struct {
+	RotationShopConfig
+	items []*ShopItem // 物品池，与配置顺序一致
+}
+
+// rotationSet 轮换商店集合，加载后只读，重新加载时整体替换
+type rotationSet struct {
+	shops map[int]*rotationShop // shop_type -> shop
+}
+
+// rotationSlot 轮换商店格子
+type rotationSlot struct {
+	item  *ShopItem
+	stock int // 玩家在本轮可购买的数量
+}
+
+// rotationState 玩家轮换商店状态，抽取结果由种子、周期及周期内刷新次数决定
+type rotationState struct {
+	ID         string         `bson:"_id"`
+	PlayerID   string         `bson:"player_id"`
+	ShopType   int            `bson:"shop_type"`
+	Seed       int64          `bson:"seed"`        // 玩家随机种子
+	Period     int64          `bson:"period"`      // 当前自动刷新周期
+	Refresh    int            `bson:"refresh"`     // 当前周期内手动刷新次数
+	Day        string         `bson:"day"`         // 最近一次手动刷新的日期
+	DayRefresh int            `bson:"day_refresh"` // 最近一次手动刷新当日的刷新次数
+	Bought     map[string]int `bson:"bought"`      // 格子 -> 本轮已购买数量
+	RefreshKey string         `bson:"refresh_key"` // 最近一次手动刷新的幂等键
+}
+
+// RotationInfo 轮换商店刷新信息
+type RotationInfo struct {
+	NextRefreshTime     int64
+	RefreshCount        int
+	MaxRefreshCount     int
+	RefreshCost         int
+	RefreshCurrencyType int
+}
+
+// 从配置中心读取并校验轮换商店配置表，配置表不存在时没有轮换商店
+func loadRotationShops(table string) (*rotationSet, error) {
+	if !config.Has(table) {
+		return &rotationSet{shops: make(map[int]*rotationShop)}, nil
+	}
+
+	configs := make([]RotationShopConfig, 0)
+	if err := config.Get(table + ".shops").Scan(&configs); err != nil {
+		return nil, fmt.Errorf("invalid rotation table %s: %v", table, err)
+	}
+
+	return newRotationSet(configs)
+}
+
+// 校验配置并生成轮换商店集合，存在错误时返回全部错误
+func newRotationSet(configs []RotationShopConfig) (*rotationSet, error) {
+	set := &rotationSet{shops: make(map[int]*rotationShop, len(configs))}
+
+	errs := make([]error, 0)
+	for i, c := range configs {
+		if err := validateRotationShop(&c); err != nil {
+			errs = append(errs, fmt.Errorf("shops[%d]: %v", i, err))
+			continue
+		}
+
+		if _, ok := set.shops[c.ShopType]; ok {
+			errs = append(errs, fmt.Errorf("shops[%d]: duplicate shop type %d", i, c.ShopType))
+			continue
+		}
+
+		shop := &rotationShop{
+			RotationShopConfig: c,
+			items:              make([]*ShopItem, len(c.Pool)),
+		}
+
+		for j, p := range c.Pool {
+			discount := pricing.Full
+			if p.DiscountRate > 0 {
+				discount = pricing.FromFloat(float64(p.DiscountRate))
+			}
+
+			shop.items[j] = &ShopItem{
+				ShopType:      c.ShopType,
+				ItemID:        p.ItemID,
+				OriginalPrice: p.Price,
+				CurrentPrice:  p.Price,
+				CurrencyType:  p.CurrencyType,
+				IsDiscount:    discount.Discounted(),
+				DiscountRate:  discount.Float32(),
+				Discount:      discount,
+				Contents:      singleGrant(p.ItemID),
+			}
+		}
+
+		set.shops[c.ShopType] = shop
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return set, nil
+}
+
+// 校验单个轮换商店配置
+func validateRotationShop(c *RotationShopConfig) error {
+	switch {
+	case c.ShopType <= 0:
+		return fmt.Errorf("invalid shop type %d", c.ShopType)
+	case c.Slots <= 0:
+		return fmt.Errorf("shop %d: invalid slots %d", c.ShopType, c.Slots)
+	case c.Period <= 0:
+		return fmt.Errorf("shop %d: invalid period %d", c.ShopType, c.Period)
+	case c.MaxRefresh < 0:
+		return fmt.Errorf("shop %d: negative max refresh %d", c.ShopType, c.MaxRefresh)
+	case c.MaxRefresh > 0 && c.RefreshCurrency != define.CurrencyTypeCoin && c.RefreshCurrency != define.CurrencyTypeDiamond:
+		return fmt.Errorf("shop %d: unknown refresh currency type %d", c.ShopType, c.RefreshCurrency)
+	case c.MaxRefresh > 0 && len(c.RefreshCosts) == 0:
+		return fmt.Errorf("shop %d: missing refresh costs", c.ShopType)
+	case len(c.Pool) < c.Slots:
+		return fmt.Errorf("shop %d: pool size %d less than slots %d", c.ShopType, len(c.Pool), c.Slots)
+	}
+
+	for _, cost := range c.RefreshCosts {
+		if cost < 0 {
+			return fmt.Errorf("shop %d: negative refresh cost %d", c.ShopType, cost)
+		}
+	}
+
+	itemIDs := make(map[int]struct{}, len(c.Pool))
+	for _, p := range c.Pool {
+		switch {
+		case p.ItemID <= 0:
+			return fmt.Errorf("shop %d: invalid item id %d", c.ShopType, p.ItemID)
+		case p.CurrencyType != define.CurrencyTypeCoin && p.CurrencyType != define.CurrencyTypeDiamond:
+			return fmt.Errorf("shop %d: item %d: unknown currency type %d", c.ShopType, p.ItemID, p.CurrencyType)
+		case p.Price < 0:
+			return fmt.Errorf("shop %d: item %d: negative price %d", c.ShopType, p.ItemID, p.Price)
+		case p.DiscountRate < 0 || p.DiscountRate > 1 || (p.DiscountRate > 0 && pricing.FromFloat(float64(p.DiscountRate)) == 0):
+			return fmt.Errorf("shop %d: item %d: invalid discount rate %v", c.ShopType, p.ItemID, p.DiscountRate)
+		case p.Weight <= 0:
+			return fmt.Errorf("shop %d: item %d: invalid weight %d", c.ShopType, p.ItemID, p.Weight)
+		case p.MinStock <= 0 || p.MaxStock < p.MinStock:
+			return fmt.Errorf("shop %d: item %d: invalid stock range [%d, %d]", c.ShopType, p.ItemID, p.MinStock, p.MaxStock)
+		}
+
+		if _, ok := itemIDs[p.ItemID]; ok {
+			return fmt.Errorf("shop %d: duplicate item id %d", c.ShopType, p.ItemID)
+		}
+		itemIDs[p.ItemID] = struct{}{}
+	}
+
+	return nil
+}
+
+// 当前自动刷新周期
+func (s *rotationShop) period(now time.Time) int64 {
+	return now.Unix() / s.Period
+}
+
+// 当日第n次(从0开始)手动刷新的价格
+func (s *rotationShop) refreshCost(n int) int {
+	if len(s.RefreshCosts) == 0 {
+		return 0
+	}
+
+	return s.RefreshCosts[min(n, len(s.RefreshCosts)-1)]
+}
+
+// 按玩家种子、周期及周期内刷新次数抽取物品，相同输入的抽取结果一致；物品池按权重不放回抽取
+func (s *rotationShop) roll(state *rotationState) []rotationSlot {
+	rng := rand.New(rand.NewPCG(uint64(state.Seed), uint64(state.Period)<<16|uint64(state.Refresh)))
+
+	candidates := make([]int, len(s.Pool))
+	total := 0
+	for i, p := range s.Pool {
+		candidates[i] = i
+		total += p.Weight
+	}
+
+	slots := make([]rotationSlot, 0, s.Slots)
+	for len(slots) < s.Slots {
+		r := rng.IntN(total)
+		for j, index := range candidates {
+			p := s.Pool[index]
+			if r -= p.Weight; r >= 0 {
+				continue
+			}
+
+			slots = append(slots, rotationSlot{
+				item:  s.items[index],
+				stock: p.MinStock + rng.IntN(p.MaxStock-p.MinStock+1),
+			})
+			candidates = append(candidates[:j], candidates[j+1:]...)
+			total -= p.Weight
+			break
+		}
+	}
+
+	return slots
+}
+
+// 刷新信息，state的手动刷新次数按当日计算
+func (s *rotationShop) info(state *rotationState, now time.Time) *RotationInfo {
+	count := dayRefresh(state, now)
+
+	return &RotationInfo{
+		NextRefreshTime:     (s.period(now) + 1) * s.Period,
+		RefreshCount:        count,
+		MaxRefreshCount:     s.MaxRefresh,
+		RefreshCost:         s.refreshCost(count),
+		RefreshCurrencyType: s.RefreshCurrency,
+	}
+}
+
+// 玩家当日已手动刷新次数
+func dayRefresh(state *rotationState, now time.Time) int {
+	if state.Day != now.Format("20060102") {
+		return 0
+	}
+
+	return state.DayRefresh
+}
+
+// RotationStore 玩家轮换商店状态存储，购买及刷新以条件更新保证并发安全
+type RotationStore struct {
+	states *mongodb.MongoDBClient
+}
+
+func NewRotationStore(database string) (*RotationStore, error) {
+	states, err := mongodb.NewMongoDBClient(database, shopRotationCollection)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RotationStore{states: states}, nil
+}
+
+// Load 加载玩家当前周期的状态，首次访问时生成随机种子，进入新周期时重置刷新次数及购买数量
+func (s *RotationStore) Load(playerID string, shop *rotationShop, now time.Time) (*rotationState, error) {
+	id := rotationID(playerID, shop.ShopType)
+	period := shop.period(now)
+
+	for i := 0; i < 2; i++ {
+		state := &rotationState{}
+		err := s.states.FindOne(bson.M{"_id": id}, state)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			state = &rotationState{
+				ID:       id,
+				PlayerID: playerID,
+				ShopType: shop.ShopType,
+				Seed:     rand.Int64(),
+				Period:   period,
+				Bought:   map[string]int{},
+			}
+
+			// 并发首次访问时以先插入的种子为准
+			if _, err = s.states.InsertOne(state); err == nil {
+				return state, nil
+			}
+
+			if mongo.IsDuplicateKeyError(err) {
+				continue
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if state.Period == period {
+			return state, nil
+		}
+
+		// 进入新周期，并发重置时以先重置的为准
+		err = s.states.FindOneAndUpdate(
+			bson.M{"_id": id, "period": state.Period},
+			bson.M{"$set": bson.M{"period": period, "refresh": 0, "bought": bson.M{}}},
+			state,
+			options.FindOneAndUpdate().SetReturnDocument(options.After),
+		)
+		if err == nil {
+			return state, nil
+		}
+
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, err
+		}
+	}
+
+	return nil, ErrShopRefreshed
+}
+
+// Buy 占用格子的可购买数量，本轮已刷新时返回ErrShopRefreshed，数量不足时返回ErrOutOfStock
+func (s *RotationStore) Buy(state *rotationState, slot, stock, count int) error {
+	// 格子本轮尚未购买时没有购买数量字段，条件更新会直接匹配，需先校验数量不超过可购买数量
+	if count > stock {
+		return ErrOutOfStock
+	}
+
+	field := "bought." + strconv.Itoa(slot)
+	filter := bson.M{
+		"_id":     state.ID,
+		"period":  state.Period,
+		"refresh": state.Refresh,
+		field:     bson.M{"$not": bson.M{"$gt": stock - count}},
+	}
+
+	err := s.states.FindOneAndUpdate(filter, bson.M{"$inc": bson.M{field: count}}, &rotationState{})
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return err
+	}
+
+	n, err := s.states.CountDocuments(bson.M{"_id": state.ID, "period": state.Period, "refresh": state.Refresh})
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return ErrShopRefreshed
+	}
+
+	return ErrOutOfStock
+}
+
+// Release 退回格子的购买数量，本轮已刷新时无需退回
+func (s *RotationStore) Release(state *rotationState, slot, count int) error {
+	filter := bson.M{"_id": state.ID, "period": state.Period, "refresh": state.Refresh}
+	return s.states.UpdateOne(filter, bson.M{"$inc": bson.M{"bought." + strconv.Itoa(slot): -count}})
+}
+
+// Refresh 手动刷新，抽取下一轮物品并累加当日刷新次数；状态已变化时返回ErrShopRefreshed
+func (s *RotationStore) Refresh(state *rotationState, key string, now time.Time) (*rotationState, error) {
+	filter := bson.M{
+		"_id":         state.ID,
+		"period":      state.Period,
+		"refresh":     state.Refresh,
+		"day":         state.Day,
+		"day_refresh": state.DayRefresh,
+	}
+	update := bson.M{"$set": bson.M{
+		"refresh":     state.Refresh + 1,
+		"day":         now.Format("20060102"),
+		"day_refresh": dayRefresh(state, now) + 1,
+		"bought":      bson.M{},
+		"refresh_key": key,
+	}}
+
+	refreshed := &rotationState{}
+	err := s.states.FindOneAndUpdate(filter, update, refreshed, options.FindOneAndUpdate().SetReturnDocument(options.After))
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrShopRefreshed
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return refreshed, nil
+}
+
+func rotationID(playerID string, shopType int) string {
+	return fmt.Sprintf("%s:%d", playerID, shopType)
+}
+
+// ReloadRotations 重新加载轮换商店配置表，校验失败时保留当前配置
+func (m *ShopManager) ReloadRotations() error {
+	rotations, err := loadRotationShops(m.rotationTable)
+	if err != nil {
+		return err
+	}
+
+	m.rotations.Store(rotations)
+
+	return nil
+}
+
+// 生成玩家本轮的轮换商店物品，价格与普通商店使用同一计算
+func (m *ShopManager) rotationList(shop *rotationShop, state *rotationState, campaigns []*shopCampaign) []*ShopItem {
+	slots := shop.roll(state)
+	items := make([]*ShopItem, len(slots))
+
+	for i, slot := range slots {
+		item := &ShopItem{}
+		*item = *slot.item
+		item.MaxBuyCount = slot.stock
+		item.BoughtCount = state.Bought[strconv.Itoa(i)]
+		item.Stock = max(slot.stock-item.BoughtCount, 0)
+		item.CurrentPrice, item.Discount = itemPrice(slot.item, campaigns)
+		item.DiscountRate = item.Discount.Float32()
+		item.IsDiscount = item.Discount.Discounted()
+		items[i] = item
+	}
+
+	return items
+}
+
+// 获取轮换商店列表及刷新信息
+func (m *ShopManager) getRotationList(playerID string, shop *rotationShop) ([]*ShopItem, *RotationInfo, error) {
+	now := time.Now()
+	state, err := m.rotationStore.Load(playerID, shop, now)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	campaigns, err := m.playerCampaigns(playerID, now)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return m.rotationList(shop, state, campaigns), shop.info(state, now), nil
+}
+
+// 购买轮换商店物品，扣款或发货失败时退回购买数量
+func (m *ShopManager) buyRotationItem(order *define.ShopOrder, shop *rotationShop, expectedPrice int) (*PurchaseResult, error) {
+	playerID, itemID, count := order.PlayerID, order.ItemID, order.Count
+
+	now := time.Now()
+	state, err := m.rotationStore.Load(playerID, shop, now)
+	if err != nil {
+		return nil, err
+	}
+
+	slot := -1
+	var target rotationSlot
+	for i, s := range shop.roll(state) {
+		if s.item.ItemID == itemID {
+			slot, target = i, s
+			break
+		}
+	}
+
+	if slot < 0 {
+		return nil, ErrShopItemNotFound
+	}
+
+	campaigns, err := m.playerCampaigns(playerID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	price, _ := itemPrice(target.item, campaigns)
+	if expectedPrice > 0 && expectedPrice != price {
+		return nil, ErrPriceChanged
+	}
+
+	if err = m.rotationStore.Buy(state, slot, target.stock, count); err != nil {
+		return nil, err
+	}
+
+	return m.settle(order, target.item, price*count, nil, func() {
+		if err := m.rotationStore.Release(state, slot, count); err != nil {
+			log.Warnf("release rotation slot failed: player_id=%s, shop_type=%d, slot=%d, err=%v", playerID, shop.ShopType, slot, err)
+		}
+	})
+}
+
+// RefreshShop 付费手动刷新轮换商店，expectedCost不为0时与当前刷新价格不一致则返回ErrRefreshCostChanged；
+// 扣款幂等键由刷新前的状态决定，并发或重试的同一次刷新只扣款一次
+func (m *ShopManager) RefreshShop(playerID string, shopType, expectedCost int) ([]*ShopItem, *RotationInfo, error) {
+	shop, ok := m.rotations.Load().shops[shopType]
+	if !ok {
+		return nil, nil, ErrShopNotFound
+	}
+
+	now := time.Now()
+	state, err := m.rotationStore.Load(playerID, shop, now)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	count := dayRefresh(state, now)
+	if count >= shop.MaxRefresh {
+		return nil, nil, ErrRefreshLimit
+	}
+
+	cost := shop.refreshCost(count)
+	if expectedCost > 0 && expectedCost != cost {
+		return nil, nil, ErrRefreshCostChanged
+	}
+
+	key := fmt.Sprintf("shop_refresh:%s:%d:%d:%d", state.ID, state.Period, state.Refresh, count)
+	remark := fmt.Sprintf("shop_type=%d, refresh=%d", shopType, count+1)
+	if cost > 0 {
+		if _, err = m.wallet.Debit(playerID, int32(shop.RefreshCurrency), int64(cost), define.WalletReasonShopRefresh, key, remark); err != nil {
+			return nil, nil, currencyError(err, shop.RefreshCurrency)
+		}
+	}
+
+	refreshed, err := m.rotationStore.Refresh(state, key, now)
+	if errors.Is(err, ErrShopRefreshed) && cost > 0 {
+		// 并发的同一次刷新已完成时沿用其结果，否则退回刷新费用
+		if refreshed, err = m.rotationStore.Load(playerID, shop, now); err == nil && refreshed.RefreshKey != key {
+			m.refundRefresh(playerID, shop, cost, key, remark)
+			err = ErrShopRefreshed
+		}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	state = refreshed
+
+	campaigns, err := m.playerCampaigns(playerID, now)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return m.rotationList(shop, state, campaigns), shop.info(state, now), nil
+}
+
+// 退回刷新费用，失败时记录日志以便人工补偿
+func (m *ShopManager) refundRefresh(playerID string, shop *rotationShop, cost int, key, remark string) {
+	if _, err := m.wallet.Credit(playerID, int32(shop.RefreshCurrency), int64(cost), define.WalletReasonRefund, "refund:"+key, remark); err != nil {
+		log.Errorf("refund shop refresh failed: player_id=%s, key=%s, cost=%d, err=%v", playerID, key, cost, err)
+	}
+}
@@ -0,0 +1,16 @@
+package server
+
+import (
+	"errors"
+	"testing"
+)
+
+// 本轮尚未购买的格子，购买数量超过可购买数量时不访问存储直接返回库存不足
+func TestRotationStoreBuyExceedsStock(t *testing.T) {
+	s := &RotationStore{}
+	state := &rotationState{ID: "500000:3", Bought: map[string]int{}}
+
+	if err := s.Buy(state, 0, 2, 3); !errors.Is(err, ErrOutOfStock) {
+		t.Errorf("Buy(stock 2, count 3) = %v, want %v", err, ErrOutOfStock)
+	}
+}
@@ -0,0 +1,240 @@
+package server
+
+import (
+	"fmt"
+	"time"
+
+	"ghserver/utils/redis"
+
+	"github.com/dobyte/due/v2/utils/xconv"
+	"github.com/dobyte/due/v2/utils/xuuid"
+)
+
+const (
+	defaultStockPrefix    = "shop"      // 默认库存键前缀
+	defaultReserveTimeout = time.Minute // 默认预占超时时间
+)
+
+// 初始化库存：首次配置时剩余库存为配置库存；配置库存变化时剩余库存只增减变化量，
+// 保留已售出及预占中的数量，多个大厅节点重复执行结果一致
+// KEYS[1] 剩余库存 KEYS[2] 配置库存
+// ARGV 物品ID与配置库存交替排列
+const initStockScript = `
+for i = 1, #ARGV, 2 do
+	local total = tonumber(ARGV[i + 1])
+	local old = redis.call('HGET', KEYS[2], ARGV[i])
+	if not old then
+		redis.call('HSET', KEYS[1], ARGV[i], total)
+		redis.call('HSET', KEYS[2], ARGV[i], total)
+	elseif tonumber(old) ~= total then
+		redis.call('HINCRBY', KEYS[1], ARGV[i], total - tonumber(old))
+		redis.call('HSET', KEYS[2], ARGV[i], total)
+	end
+end
+return 1
+`
+
+// 预占库存：先回收超时未提交的预占，库存足够时扣减并记录预占
+// KEYS[1] 剩余库存 KEYS[2] 物品预占集合
+// ARGV[1] 物品ID ARGV[2] 数量 ARGV[3] 预占ID ARGV[4] 当前时间(毫秒) ARGV[5] 超时时间(毫秒)
+const reserveStockScript = `
+local expired = redis.call('ZRANGEBYSCORE', KEYS[2], '-inf', ARGV[4])
+for _, member in ipairs(expired) do
+	redis.call('HINCRBY', KEYS[1], ARGV[1], tonumber(string.match(member, ':(%d+)$')))
+	redis.call('ZREM', KEYS[2], member)
+end
+local count = tonumber(ARGV[2])
+if tonumber(redis.call('HGET', KEYS[1], ARGV[1]) or '0') < count then
+	return 0
+end
+redis.call('HINCRBY', KEYS[1], ARGV[1], -count)
+redis.call('ZADD', KEYS[2], ARGV[5], ARGV[3])
+return 1
+`
+
+// 回滚预占：预占仍有效时退回库存
+// KEYS[1] 剩余库存 KEYS[2] 物品预占集合
+// ARGV[1] 物品ID ARGV[2] 数量 ARGV[3] 预占ID
+const rollbackStockScript = `
+if redis.call('ZREM', KEYS[2], ARGV[3]) == 1 then
+	redis.call('HINCRBY', KEYS[1], ARGV[1], ARGV[2])
+	return 1
+end
+return 0
+`
+
+// 提交预占：预占仍在时直接提交；已超时被回收时重新扣减库存，库存不足则提交失败
+// KEYS[1] 剩余库存 KEYS[2] 物品预占集合
+// ARGV[1] 物品ID ARGV[2] 数量 ARGV[3] 预占ID
+const commitStockScript = `
+if redis.call('ZREM', KEYS[2], ARGV[3]) == 1 then
+	return 1
+end
+local count = tonumber(ARGV[2])
+if tonumber(redis.call('HGET', KEYS[1], ARGV[1]) or '0') < count then
+	return 0
+end
+redis.call('HINCRBY', KEYS[1], ARGV[1], -count)
+return 1
+`
+
+// 退回已提交的库存
+// KEYS[1] 剩余库存
+// ARGV[1] 物品ID ARGV[2] 数量
+const restockScript = `
+redis.call('HINCRBY', KEYS[1], ARGV[1], ARGV[2])
+return 1
+`
+
+// StockReservation 库存预占，提交后库存扣减生效，回滚或超时后库存退回
+type StockReservation struct {
+	ItemID    int
+	Count     int
+	member    string
+	committed bool
+}
+
+// Stock 库存，集群内共享限量物品的剩余库存
+type Stock interface {
+	// Init 按商品目录初始化限量物品的库存
+	Init(catalog *shopCatalog) error
+	// Reserve 预占库存，库存不足时返回ErrOutOfStock
+	Reserve(itemID, count int) (*StockReservation, error)
+	// Commit 提交预占，返回false表示预占已超时被回收且剩余库存不足
+	Commit(reservation *StockReservation) (bool, error)
+	// Rollback 回滚预占，退回库存
+	Rollback(reservation *StockReservation) error
+	// Remaining 查询物品剩余库存
+	Remaining(itemIDs []int) (map[int]int, error)
+}
+
+// StockStore Redis库存，集群内各大厅节点共享限量物品的剩余库存
+type StockStore struct {
+	client  *redis.RedisClient
+	prefix  string
+	timeout time.Duration
+}
+
+func NewStockStore(redisName, prefix string, timeout time.Duration) *StockStore {
+	s := &StockStore{
+		client:  redis.Instance(redisName),
+		prefix:  prefix,
+		timeout: timeout,
+	}
+
+	if s.prefix == "" {
+		s.prefix = defaultStockPrefix
+	}
+
+	if s.timeout <= 0 {
+		s.timeout = defaultReserveTimeout
+	}
+
+	return s
+}
+
+// Init 按商品目录初始化限量物品的库存
+func (s *StockStore) Init(catalog *shopCatalog) error {
+	args := make([]interface{}, 0, len(catalog.items)*2)
+	for itemID, item := range catalog.items {
+		if item.Stock > 0 {
+			args = append(args, itemID, item.Stock)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	_, err := s.client.Eval(initStockScript, []string{s.stockKey(), s.totalKey()}, args...)
+	return err
+}
+
+// Reserve 预占库存，库存不足时返回ErrOutOfStock；预占超时未提交时在下次预占该物品时退回
+func (s *StockStore) Reserve(itemID, count int) (*StockReservation, error) {
+	now := time.Now()
+	reservation := &StockReservation{
+		ItemID: itemID,
+		Count:  count,
+		member: fmt.Sprintf("%s:%d", xuuid.UUID(), count),
+	}
+
+	reply, err := s.client.Eval(reserveStockScript, []string{s.stockKey(), s.reserveKey(itemID)},
+		itemID, count, reservation.member, now.UnixMilli(), now.Add(s.timeout).UnixMilli())
+	if err != nil {
+		return nil, err
+	}
+
+	if xconv.Int(reply) == 0 {
+		return nil, ErrOutOfStock
+	}
+
+	return reservation, nil
+}
+
+// Commit 提交预占，返回false表示预占已超时被回收且剩余库存不足
+func (s *StockStore) Commit(reservation *StockReservation) (bool, error) {
+	reply, err := s.client.Eval(commitStockScript, []string{s.stockKey(), s.reserveKey(reservation.ItemID)},
+		reservation.ItemID, reservation.Count, reservation.member)
+	if err != nil {
+		return false, err
+	}
+
+	reservation.committed = xconv.Int(reply) == 1
+
+	return reservation.committed, nil
+}
+
+// Rollback 回滚预占，退回库存；已提交的预占直接退回提交时扣减的库存
+func (s *StockStore) Rollback(reservation *StockReservation) error {
+	if reservation.committed {
+		_, err := s.client.Eval(restockScript, []string{s.stockKey()}, reservation.ItemID, reservation.Count)
+		return err
+	}
+
+	_, err := s.client.Eval(rollbackStockScript, []string{s.stockKey(), s.reserveKey(reservation.ItemID)},
+		reservation.ItemID, reservation.Count, reservation.member)
+	return err
+}
+
+// Remaining 查询物品剩余库存
+func (s *StockStore) Remaining(itemIDs []int) (map[int]int, error) {
+	remaining := make(map[int]int, len(itemIDs))
+	if len(itemIDs) == 0 {
+		return remaining, nil
+	}
+
+	fields := make([]string, len(itemIDs))
+	for i, itemID := range itemIDs {
+		fields[i] = xconv.String(itemID)
+	}
+
+	values, err := s.client.HMGet(s.stockKey(), fields...)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, value := range values {
+		if value != nil {
+			// 配置库存调低至已售出数量以下时剩余库存为负，按售罄展示
+			remaining[itemIDs[i]] = xconv.Int(value)
+			if remaining[itemIDs[i]] < 0 {
+				remaining[itemIDs[i]] = 0
+			}
+		}
+	}
+
+	return remaining, nil
+}
+
+func (s *StockStore) stockKey() string {
+	return fmt.Sprintf("%s:stock", s.prefix)
+}
+
+func (s *StockStore) totalKey() string {
+	return fmt.Sprintf("%s:stock_total", s.prefix)
+}
+
+func (s *StockStore) reserveKey(itemID int) string {
+	return fmt.Sprintf("%s:reserve:%d", s.prefix, itemID)
+}
@@ -3,8 +3,11 @@ package server
 import (
 	"context"
 	"errors"
+	"fmt"
 
+	"ghserver/define"
 	"ghserver/proto/pb"
+	"ghserver/utils/shard"
 
 	"github.com/dobyte/due/v2/cluster/node"
 	"github.com/dobyte/due/v2/codes"
@@ -21,7 +24,7 @@ type TaskServer struct {
 func NewTaskServer(proxy *node.Proxy) *TaskServer {
 	return &TaskServer{
 		proxy:       proxy,
-		taskManager: NewTaskManager(),
+		taskManager: NewTaskManager(NewWalletClient(proxy)),
 	}
 }
 
@@ -136,6 +139,14 @@ func (s *TaskServer) SubmitTask(ctx context.Context, req *pb.SubmitTaskRequest)
 	// 提交任务
 	rewards, err := s.taskManager.SubmitTask(req.PlayerId, int(req.TaskId))
 	if err != nil {
+		if errors.Is(err, ErrWalletFailed) || errors.Is(err, ErrPlayerNotFound) {
+			log.Errorf("submit task failed: player_id=%s, task_id=%d, err=%v", req.PlayerId, req.TaskId, err)
+			return &pb.SubmitTaskResponse{
+				Code:    int32(codes.InternalError.Code()),
+				Message: "发放任务奖励失败",
+			}, nil
+		}
+
 		return &pb.SubmitTaskResponse{
 				Code:    int32(codes.InvalidArgument.Code()),
 				Message: err.Error(),
@@ -195,6 +206,13 @@ type Task struct {
 	NextTaskID  int
 }
 
+// 任务奖励类型
+const (
+	TaskRewardTypeExp  = 1 // 经验
+	TaskRewardTypeCoin = 2 // 金币
+	TaskRewardTypeItem = 3 // 物品
+)
+
 // TaskReward 任务奖励
 type TaskReward struct {
 	Type   int
@@ -204,14 +222,16 @@ type TaskReward struct {
 
 // TaskManager 任务管理器
 type TaskManager struct {
-	playerTasks map[string][]Task // player_id -> tasks
-	globalTasks map[int]*Task     // task_id -> template
+	playerTasks *shard.Map[[]Task] // player_id -> tasks
+	globalTasks map[int]*Task      // task_id -> template，初始化后只读
+	wallet      Wallet             // 钱包，金币奖励由钱包服务发放
 }
 
-func NewTaskManager() *TaskManager {
+func NewTaskManager(wallet Wallet) *TaskManager {
 	m := &TaskManager{
-		playerTasks: make(map[string][]Task),
+		playerTasks: shard.NewMap[[]Task](),
 		globalTasks: make(map[int]*Task),
+		wallet:      wallet,
 	}
 
 	// 初始化全局任务模板
@@ -221,124 +241,159 @@ func NewTaskManager() *TaskManager {
 }
 
 func (m *TaskManager) GetTaskList(playerID string, taskType int) []*Task {
-	// 初始化玩家任务
-	if _, exists := m.playerTasks[playerID]; !exists {
-		m.initPlayerTasks(playerID)
-	}
-
-	tasks := m.playerTasks[playerID]
 	result := make([]*Task, 0)
 
-	for i := range tasks {
-		// 根据任务类型筛选
-		if taskType == 0 || tasks[i].Type == taskType {
-			result = append(result, &tasks[i])
+	m.playerTasks.Do(playerID, m.newPlayerTasks, func(tasks []Task) {
+		for i := range tasks {
+			// 根据任务类型筛选
+			if taskType == 0 || tasks[i].Type == taskType {
+				result = append(result, cloneTask(&tasks[i]))
+			}
 		}
-	}
+	})
 
 	return result
 }
 
-func (m *TaskManager) GetTaskDetail(playerID string, taskID int) (*Task, error) {
-	// 初始化玩家任务
-	if _, exists := m.playerTasks[playerID]; !exists {
-		m.initPlayerTasks(playerID)
-	}
-
-	for i := range m.playerTasks[playerID] {
-		if m.playerTasks[playerID][i].ID == taskID {
-			return &m.playerTasks[playerID][i], nil
+func (m *TaskManager) GetTaskDetail(playerID string, taskID int) (task *Task, err error) {
+	m.playerTasks.Do(playerID, m.newPlayerTasks, func(tasks []Task) {
+		for i := range tasks {
+			if tasks[i].ID == taskID {
+				task = cloneTask(&tasks[i])
+				return
+			}
 		}
-	}
 
-	return nil, errors.New("任务不存在")
+		err = errors.New("任务不存在")
+	})
+
+	return
 }
 
-func (m *TaskManager) AcceptTask(playerID string, taskID int) error {
-	// 初始化玩家任务
-	if _, exists := m.playerTasks[playerID]; !exists {
-		m.initPlayerTasks(playerID)
-	}
+func (m *TaskManager) AcceptTask(playerID string, taskID int) (err error) {
+	m.playerTasks.Do(playerID, m.newPlayerTasks, func(tasks []Task) {
+		for i := range tasks {
+			if tasks[i].ID == taskID {
+				if tasks[i].Status != 1 {
+					err = errors.New("任务已接取或已完成")
+					return
+				}
 
-	for i := range m.playerTasks[playerID] {
-		if m.playerTasks[playerID][i].ID == taskID {
-			if m.playerTasks[playerID][i].Status != 1 {
-				return errors.New("任务已接取或已完成")
-			}
+				// 检查等级限制（这里假设玩家等级为1）
+				playerLevel := 1
+				if playerLevel < tasks[i].AcceptLevel {
+					err = errors.New("等级不足")
+					return
+				}
 
-			// 检查等级限制（这里假设玩家等级为1）
-			playerLevel := 1
-			if playerLevel < m.playerTasks[playerID][i].AcceptLevel {
-				return errors.New("等级不足")
+				// 标记为进行中
+				tasks[i].Status = 2
+				return
 			}
+		}
+
+		err = errors.New("任务不存在")
+	})
 
-			// 标记为进行中
-			m.playerTasks[playerID][i].Status = 2
-			return nil
+	return
+}
+
+// SubmitTask 提交任务：先通过钱包发放金币奖励再标记为已提交，发放失败时任务保持已完成状态可重新提交；
+// 金币奖励以玩家和任务ID为幂等键，重复提交不会重复发放
+func (m *TaskManager) SubmitTask(playerID string, taskID int) (rewards []TaskReward, err error) {
+	m.playerTasks.Do(playerID, m.newPlayerTasks, func(tasks []Task) {
+		for i := range tasks {
+			if tasks[i].ID == taskID {
+				if tasks[i].Status != 3 {
+					err = errors.New("任务未完成，无法提交")
+					return
+				}
+
+				rewards = make([]TaskReward, len(tasks[i].Rewards))
+				copy(rewards, tasks[i].Rewards)
+				return
+			}
 		}
+
+		err = errors.New("任务不存在")
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return errors.New("任务不存在")
-}
+	// 发放金币奖励
+	var coin int64
+	for _, reward := range rewards {
+		if reward.Type == TaskRewardTypeCoin && reward.Count > 0 {
+			coin += int64(reward.Count)
+		}
+	}
 
-func (m *TaskManager) SubmitTask(playerID string, taskID int) ([]TaskReward, error) {
-	// 初始化玩家任务
-	if _, exists := m.playerTasks[playerID]; !exists {
-		m.initPlayerTasks(playerID)
+	if coin > 0 {
+		key := fmt.Sprintf("task:%s:%d", playerID, taskID)
+		if _, err = m.wallet.Credit(playerID, define.CurrencyTypeCoin, coin, define.WalletReasonTaskReward, key, ""); err != nil {
+			return nil, err
+		}
 	}
 
-	for i := range m.playerTasks[playerID] {
-		if m.playerTasks[playerID][i].ID == taskID {
-			if m.playerTasks[playerID][i].Status != 3 {
-				return nil, errors.New("任务未完成，无法提交")
-			}
+	m.playerTasks.Do(playerID, m.newPlayerTasks, func(tasks []Task) {
+		for i := range tasks {
+			if tasks[i].ID == taskID {
+				// 并发提交时只有一个请求生效
+				if tasks[i].Status != 3 {
+					err = errors.New("任务未完成，无法提交")
+					return
+				}
 
-			// 标记为已提交
-			rewards := m.playerTasks[playerID][i].Rewards
-			m.playerTasks[playerID][i].Status = 4
+				// 标记为已提交
+				tasks[i].Status = 4
 
-			// 如果有后续任务，解锁后续任务
-			if m.playerTasks[playerID][i].NextTaskID > 0 {
-				for j := range m.playerTasks[playerID] {
-					if m.playerTasks[playerID][j].ID == m.playerTasks[playerID][i].NextTaskID {
-						m.playerTasks[playerID][j].Status = 1
-						break
+				// 如果有后续任务，解锁后续任务
+				if tasks[i].NextTaskID > 0 {
+					for j := range tasks {
+						if tasks[j].ID == tasks[i].NextTaskID {
+							tasks[j].Status = 1
+							break
+						}
 					}
 				}
-			}
 
-			return rewards, nil
+				return
+			}
 		}
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, errors.New("任务不存在")
+	return rewards, nil
 }
 
-func (m *TaskManager) GiveUpTask(playerID string, taskID int) error {
-	// 初始化玩家任务
-	if _, exists := m.playerTasks[playerID]; !exists {
-		m.initPlayerTasks(playerID)
-	}
+func (m *TaskManager) GiveUpTask(playerID string, taskID int) (err error) {
+	m.playerTasks.Do(playerID, m.newPlayerTasks, func(tasks []Task) {
+		for i := range tasks {
+			if tasks[i].ID == taskID {
+				if tasks[i].Status != 2 {
+					err = errors.New("只能放弃进行中的任务")
+					return
+				}
 
-	for i := range m.playerTasks[playerID] {
-		if m.playerTasks[playerID][i].ID == taskID {
-			if m.playerTasks[playerID][i].Status != 2 {
-				return errors.New("只能放弃进行中的任务")
-			}
+				// 重置为未接取
+				tasks[i].Status = 1
+				// 重置进度
+				tasks[i].Progress = 0
+				for k := range tasks[i].ProgressMap {
+					tasks[i].ProgressMap[k] = 0
+				}
 
-			// 重置为未接取
-			m.playerTasks[playerID][i].Status = 1
-			// 重置进度
-			m.playerTasks[playerID][i].Progress = 0
-			for k := range m.playerTasks[playerID][i].ProgressMap {
-				m.playerTasks[playerID][i].ProgressMap[k] = 0
+				return
 			}
-
-			return nil
 		}
-	}
 
-	return errors.New("任务不存在")
+		err = errors.New("任务不存在")
+	})
+
+	return
 }
 
 func (m *TaskManager) initGlobalTasks() {
@@ -419,25 +474,11 @@ func (m *TaskManager) initGlobalTasks() {
 	}
 }
 
-func (m *TaskManager) initPlayerTasks(playerID string) {
+func (m *TaskManager) newPlayerTasks() []Task {
 	// 复制全局任务模板到玩家任务
-	playerTasks := make([]Task, 0)
+	playerTasks := make([]Task, 0, len(m.globalTasks))
 	for _, taskTemplate := range m.globalTasks {
-		task := *taskTemplate
-		// 深拷贝map
-		task.Targets = make(map[string]int)
-		for k, v := range taskTemplate.Targets {
-			task.Targets[k] = v
-		}
-		task.ProgressMap = make(map[string]int)
-		for k, v := range taskTemplate.ProgressMap {
-			task.ProgressMap[k] = v
-		}
-		// 深拷贝rewards
-		task.Rewards = make([]TaskReward, len(taskTemplate.Rewards))
-		copy(task.Rewards, taskTemplate.Rewards)
-
-		playerTasks = append(playerTasks, task)
+		playerTasks = append(playerTasks, *cloneTask(taskTemplate))
 	}
 
 	// 为示例方便，将一些任务标记为已完成
@@ -454,5 +495,22 @@ func (m *TaskManager) initPlayerTasks(playerID string) {
 		}
 	}
 
-	m.playerTasks[playerID] = playerTasks
+	return playerTasks
+}
+
+// 深拷贝任务，避免调用方在锁外读写玩家任务数据
+func cloneTask(src *Task) *Task {
+	task := *src
+	task.Targets = make(map[string]int, len(src.Targets))
+	for k, v := range src.Targets {
+		task.Targets[k] = v
+	}
+	task.ProgressMap = make(map[string]int, len(src.ProgressMap))
+	for k, v := range src.ProgressMap {
+		task.ProgressMap[k] = v
+	}
+	task.Rewards = make([]TaskReward, len(src.Rewards))
+	copy(task.Rewards, src.Rewards)
+
+	return &task
 }
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// 日常巡逻，新玩家可接取
+const testAcceptableTaskID = 3001
+
+func TestTaskManagerConcurrentAcceptTask(t *testing.T) {
+	m := NewTaskManager(newFakeWallet(0))
+
+	const (
+		players = 16
+		workers = 32
+	)
+
+	var (
+		wg       sync.WaitGroup
+		accepted [players]atomic.Int32
+	)
+
+	for i := 0; i < players; i++ {
+		playerID := fmt.Sprintf("%d", 100000+i)
+		for j := 0; j < workers; j++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+
+				if err := m.AcceptTask(playerID, testAcceptableTaskID); err == nil {
+					accepted[i].Add(1)
+				}
+
+				// 并发读取任务列表，返回的任务为深拷贝，修改不影响玩家任务数据
+				for _, task := range m.GetTaskList(playerID, 0) {
+					task.ProgressMap["patrol"]++
+				}
+			}(i)
+		}
+	}
+
+	wg.Wait()
+
+	for i := range accepted {
+		if n := accepted[i].Load(); n != 1 {
+			t.Errorf("player %d accepted task %d times, want 1", 100000+i, n)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"ghserver/define"
+	"ghserver/proto/pb"
+	RPC "ghserver/proto/rpc_ctl"
+
+	"github.com/dobyte/due/v2/cluster/node"
+	"github.com/dobyte/due/v2/codes"
+)
+
+var (
+	ErrInsufficientBalance = errors.New("余额不足")
+	ErrWalletFailed        = errors.New("钱包操作失败")
+)
+
+// Wallet 钱包，大厅各管理器通过钱包变更玩家货币
+type Wallet interface {
+	// Credit 增加货币，返回变化后余额；幂等键相同的请求只生效一次
+	Credit(playerID string, currencyType int32, amount int64, reason int32, idempotencyKey, remark string) (int64, error)
+	// Debit 扣除货币，余额不足时返回ErrInsufficientBalance
+	Debit(playerID string, currencyType int32, amount int64, reason int32, idempotencyKey, remark string) (int64, error)
+}
+
+// WalletClient 钱包服务客户端，大厅各服务通过钱包服务变更玩家货币，不直接修改余额
+type WalletClient struct {
+	proxy *node.Proxy
+}
+
+func NewWalletClient(proxy *node.Proxy) *WalletClient {
+	return &WalletClient{proxy: proxy}
+}
+
+// Credit 增加货币，返回变化后余额；幂等键相同的请求只生效一次
+func (c *WalletClient) Credit(playerID string, currencyType int32, amount int64, reason int32, idempotencyKey, remark string) (int64, error) {
+	client, err := c.client()
+	if err != nil {
+		return 0, err
+	}
+
+	reply, err := client.Credit(context.Background(), &pb.WalletChangeRequest{
+		PlayerId:       playerID,
+		CurrencyType:   currencyType,
+		Amount:         amount,
+		Reason:         reason,
+		IdempotencyKey: idempotencyKey,
+		Remark:         remark,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrWalletFailed, err)
+	}
+
+	return reply.Balance, walletReplyError(reply.Code, reply.Message)
+}
+
+// Debit 扣除货币，余额不足时返回ErrInsufficientBalance，玩家不存在时返回ErrPlayerNotFound
+func (c *WalletClient) Debit(playerID string, currencyType int32, amount int64, reason int32, idempotencyKey, remark string) (int64, error) {
+	client, err := c.client()
+	if err != nil {
+		return 0, err
+	}
+
+	reply, err := client.Debit(context.Background(), &pb.WalletChangeRequest{
+		PlayerId:       playerID,
+		CurrencyType:   currencyType,
+		Amount:         amount,
+		Reason:         reason,
+		IdempotencyKey: idempotencyKey,
+		Remark:         remark,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrWalletFailed, err)
+	}
+
+	return reply.Balance, walletReplyError(reply.Code, reply.Message)
+}
+
+func (c *WalletClient) client() (pb.WalletServiceClient, error) {
+	client, err := RPC.NewRpcClient(c.proxy.NewMeshClient, RPC.ServiceTypeWallet)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWalletFailed, err)
+	}
+
+	return client.(pb.WalletServiceClient), nil
+}
+
+// 转换钱包服务响应码
+func walletReplyError(code int32, message string) error {
+	switch code {
+	case int32(codes.OK.Code()):
+		return nil
+	case int32(define.InsufficientBalance.Code()):
+		return ErrInsufficientBalance
+	case int32(define.NotFoundUser.Code()):
+		return ErrPlayerNotFound
+	default:
+		return fmt.Errorf("%w: code=%d, message=%s", ErrWalletFailed, code, message)
+	}
+}
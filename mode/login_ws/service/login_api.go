@@ -5,7 +5,7 @@ import (
 	loginpb "ghserver/proto/pb"
 	RPC "ghserver/proto/rpc_ctl"
 
-	code "ghserver/define/code"
+	code "ghserver/define"
 
 	"github.com/dobyte/due/component/http/v2"
 	"github.com/dobyte/due/v2/log"
@@ -81,6 +81,7 @@ func (a *LoginApi) Login(ctx http.Context) error {
 		Account:  req.Account,
 		Password: req.Password,
 		DeviceId: ctx.IP(),
+		ClientIp: ctx.IP(),
 	})
 	if err != nil {
 		return ctx.Failure(err)
@@ -121,7 +122,7 @@ func (a *LoginApi) Register(ctx http.Context) error {
 		return ctx.Failure(code.InternalError)
 	}
 
-	_, err = client.(loginpb.LoginServiceClient).Register(context.Background(), &loginpb.RegisterRequest{
+	reply, err := client.(loginpb.LoginServiceClient).Register(context.Background(), &loginpb.RegisterRequest{
 		Account:  req.Account,
 		Password: req.Password,
 		Nickname: req.Nickname,
@@ -131,5 +132,5 @@ func (a *LoginApi) Register(ctx http.Context) error {
 		return ctx.Failure(err)
 	}
 
-	return ctx.Success(&loginpb.RegisterResponse{Code: code.OK, Message: "注册成功"})
+	return ctx.Success(&loginpb.RegisterResponse{Code: reply.Code, Message: reply.Message})
 }
@@ -199,6 +199,7 @@ type LoginRequest struct {
 	Account       string                 `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`                   // 账号
 	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`                 // 密码（加密后）
 	DeviceId      string                 `protobuf:"bytes,3,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"` // 设备ID
+	ClientIp      string                 `protobuf:"bytes,4,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"` // 客户端IP地址
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -254,6 +255,13 @@ func (x *LoginRequest) GetDeviceId() string {
 	return ""
 }
 
+func (x *LoginRequest) GetClientIp() string {
+	if x != nil {
+		return x.ClientIp
+	}
+	return ""
+}
+
 type LoginResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
@@ -492,7 +500,7 @@ type QueueInfoResponse struct {
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
 	Position      int32                  `protobuf:"varint,3,opt,name=position,proto3" json:"position,omitempty"`                                // 当前位置
 	Total         int32                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`                                      // 总排队人数
-	EstimatedTime int32                  `protobuf:"varint,5,opt,name=estimated_time,json=estimatedTime,proto3" json:"estimated_time,omitempty"` // 预计等待时间（秒）
+	EstimatedTime int32                  `protobuf:"varint,5,opt,name=estimated_time,json=estimatedTime,proto3" json:"estimated_time,omitempty"` // 预计等待时间（秒），-1表示暂无法估算
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -562,33 +570,27 @@ func (x *QueueInfoResponse) GetEstimatedTime() int32 {
 	return 0
 }
 
-// 玩家信息
-type PlayerInfo struct {
+type LeaveQueueRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                              // 玩家ID
-	Nickname      string                 `protobuf:"bytes,2,opt,name=nickname,proto3" json:"nickname,omitempty"`                  // 昵称
-	Level         int32                  `protobuf:"varint,3,opt,name=level,proto3" json:"level,omitempty"`                       // 等级
-	Exp           int32                  `protobuf:"varint,4,opt,name=exp,proto3" json:"exp,omitempty"`                           // 经验值
-	VipLevel      int32                  `protobuf:"varint,5,opt,name=vip_level,json=vipLevel,proto3" json:"vip_level,omitempty"` // VIP等级
-	Items         []int32                `protobuf:"varint,6,rep,packed,name=items,proto3" json:"items,omitempty"`                // 物品ID列表
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // 会话令牌
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PlayerInfo) Reset() {
-	*x = PlayerInfo{}
+func (x *LeaveQueueRequest) Reset() {
+	*x = LeaveQueueRequest{}
 	mi := &file_game_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PlayerInfo) String() string {
+func (x *LeaveQueueRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PlayerInfo) ProtoMessage() {}
+func (*LeaveQueueRequest) ProtoMessage() {}
 
-func (x *PlayerInfo) ProtoReflect() protoreflect.Message {
+func (x *LeaveQueueRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -600,76 +602,40 @@ func (x *PlayerInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PlayerInfo.ProtoReflect.Descriptor instead.
-func (*PlayerInfo) Descriptor() ([]byte, []int) {
+// Deprecated: Use LeaveQueueRequest.ProtoReflect.Descriptor instead.
+func (*LeaveQueueRequest) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *PlayerInfo) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *PlayerInfo) GetNickname() string {
+func (x *LeaveQueueRequest) GetToken() string {
 	if x != nil {
-		return x.Nickname
+		return x.Token
 	}
 	return ""
 }
 
-func (x *PlayerInfo) GetLevel() int32 {
-	if x != nil {
-		return x.Level
-	}
-	return 0
-}
-
-func (x *PlayerInfo) GetExp() int32 {
-	if x != nil {
-		return x.Exp
-	}
-	return 0
-}
-
-func (x *PlayerInfo) GetVipLevel() int32 {
-	if x != nil {
-		return x.VipLevel
-	}
-	return 0
-}
-
-func (x *PlayerInfo) GetItems() []int32 {
-	if x != nil {
-		return x.Items
-	}
-	return nil
-}
-
-type CreateRoomRequest struct {
+// 网关连接鉴权请求，连接网关后首先发送
+type AuthRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`        // 玩家ID
-	LevelId       int32                  `protobuf:"varint,2,opt,name=level_id,json=levelId,proto3" json:"level_id,omitempty"`          // 关卡ID
-	MaxPlayers    int32                  `protobuf:"varint,3,opt,name=max_players,json=maxPlayers,proto3" json:"max_players,omitempty"` // 最大玩家数
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // 会话令牌
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateRoomRequest) Reset() {
-	*x = CreateRoomRequest{}
+func (x *AuthRequest) Reset() {
+	*x = AuthRequest{}
 	mi := &file_game_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateRoomRequest) String() string {
+func (x *AuthRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateRoomRequest) ProtoMessage() {}
+func (*AuthRequest) ProtoMessage() {}
 
-func (x *CreateRoomRequest) ProtoReflect() protoreflect.Message {
+func (x *AuthRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -681,55 +647,42 @@ func (x *CreateRoomRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateRoomRequest.ProtoReflect.Descriptor instead.
-func (*CreateRoomRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AuthRequest.ProtoReflect.Descriptor instead.
+func (*AuthRequest) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *CreateRoomRequest) GetPlayerId() string {
+func (x *AuthRequest) GetToken() string {
 	if x != nil {
-		return x.PlayerId
+		return x.Token
 	}
 	return ""
 }
 
-func (x *CreateRoomRequest) GetLevelId() int32 {
-	if x != nil {
-		return x.LevelId
-	}
-	return 0
-}
-
-func (x *CreateRoomRequest) GetMaxPlayers() int32 {
-	if x != nil {
-		return x.MaxPlayers
-	}
-	return 0
-}
-
-type CreateRoomResponse struct {
+type AuthResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	RoomId        string                 `protobuf:"bytes,3,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"` // 房间ID
+	PlayerId      string                 `protobuf:"bytes,3,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`                 // 玩家ID
+	QueuePosition int32                  `protobuf:"varint,4,opt,name=queue_position,json=queuePosition,proto3" json:"queue_position,omitempty"` // 排队位置，0表示已进入游戏
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateRoomResponse) Reset() {
-	*x = CreateRoomResponse{}
+func (x *AuthResponse) Reset() {
+	*x = AuthResponse{}
 	mi := &file_game_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateRoomResponse) String() string {
+func (x *AuthResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateRoomResponse) ProtoMessage() {}
+func (*AuthResponse) ProtoMessage() {}
 
-func (x *CreateRoomResponse) ProtoReflect() protoreflect.Message {
+func (x *AuthResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -741,54 +694,62 @@ func (x *CreateRoomResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateRoomResponse.ProtoReflect.Descriptor instead.
-func (*CreateRoomResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use AuthResponse.ProtoReflect.Descriptor instead.
+func (*AuthResponse) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *CreateRoomResponse) GetCode() int32 {
+func (x *AuthResponse) GetCode() int32 {
 	if x != nil {
 		return x.Code
 	}
 	return 0
 }
 
-func (x *CreateRoomResponse) GetMessage() string {
+func (x *AuthResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *CreateRoomResponse) GetRoomId() string {
+func (x *AuthResponse) GetPlayerId() string {
 	if x != nil {
-		return x.RoomId
+		return x.PlayerId
 	}
 	return ""
 }
 
-type JoinRoomRequest struct {
+func (x *AuthResponse) GetQueuePosition() int32 {
+	if x != nil {
+		return x.QueuePosition
+	}
+	return 0
+}
+
+// 排队放行通知
+type QueueAdmittedNotify struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID
-	RoomId        string                 `protobuf:"bytes,2,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`       // 房间ID
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`                       // 会话令牌，凭此令牌重连进入游戏
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *JoinRoomRequest) Reset() {
-	*x = JoinRoomRequest{}
+func (x *QueueAdmittedNotify) Reset() {
+	*x = QueueAdmittedNotify{}
 	mi := &file_game_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *JoinRoomRequest) String() string {
+func (x *QueueAdmittedNotify) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*JoinRoomRequest) ProtoMessage() {}
+func (*QueueAdmittedNotify) ProtoMessage() {}
 
-func (x *JoinRoomRequest) ProtoReflect() protoreflect.Message {
+func (x *QueueAdmittedNotify) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -800,48 +761,48 @@ func (x *JoinRoomRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use JoinRoomRequest.ProtoReflect.Descriptor instead.
-func (*JoinRoomRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use QueueAdmittedNotify.ProtoReflect.Descriptor instead.
+func (*QueueAdmittedNotify) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *JoinRoomRequest) GetPlayerId() string {
+func (x *QueueAdmittedNotify) GetPlayerId() string {
 	if x != nil {
 		return x.PlayerId
 	}
 	return ""
 }
 
-func (x *JoinRoomRequest) GetRoomId() string {
+func (x *QueueAdmittedNotify) GetToken() string {
 	if x != nil {
-		return x.RoomId
+		return x.Token
 	}
 	return ""
 }
 
-type JoinRoomResponse struct {
+// 排队放行失败通知，排队名额作废，需重新登录
+type QueueRejectedNotify struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Room          *RoomInfo              `protobuf:"bytes,3,opt,name=room,proto3" json:"room,omitempty"` // 房间信息
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`      // 错误码
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"` // 提示信息
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *JoinRoomResponse) Reset() {
-	*x = JoinRoomResponse{}
+func (x *QueueRejectedNotify) Reset() {
+	*x = QueueRejectedNotify{}
 	mi := &file_game_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *JoinRoomResponse) String() string {
+func (x *QueueRejectedNotify) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*JoinRoomResponse) ProtoMessage() {}
+func (*QueueRejectedNotify) ProtoMessage() {}
 
-func (x *JoinRoomResponse) ProtoReflect() protoreflect.Message {
+func (x *QueueRejectedNotify) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -853,54 +814,48 @@ func (x *JoinRoomResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use JoinRoomResponse.ProtoReflect.Descriptor instead.
-func (*JoinRoomResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use QueueRejectedNotify.ProtoReflect.Descriptor instead.
+func (*QueueRejectedNotify) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *JoinRoomResponse) GetCode() int32 {
+func (x *QueueRejectedNotify) GetCode() int32 {
 	if x != nil {
 		return x.Code
 	}
 	return 0
 }
 
-func (x *JoinRoomResponse) GetMessage() string {
+func (x *QueueRejectedNotify) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *JoinRoomResponse) GetRoom() *RoomInfo {
-	if x != nil {
-		return x.Room
-	}
-	return nil
-}
-
-type LeaveRoomRequest struct {
+// 踢下线通知
+type KickNotify struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID
-	RoomId        string                 `protobuf:"bytes,2,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`       // 房间ID
+	Reason        int32                  `protobuf:"varint,1,opt,name=reason,proto3" json:"reason,omitempty"`  // 踢出原因
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"` // 提示信息
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LeaveRoomRequest) Reset() {
-	*x = LeaveRoomRequest{}
+func (x *KickNotify) Reset() {
+	*x = KickNotify{}
 	mi := &file_game_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LeaveRoomRequest) String() string {
+func (x *KickNotify) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LeaveRoomRequest) ProtoMessage() {}
+func (*KickNotify) ProtoMessage() {}
 
-func (x *LeaveRoomRequest) ProtoReflect() protoreflect.Message {
+func (x *KickNotify) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -912,47 +867,52 @@ func (x *LeaveRoomRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LeaveRoomRequest.ProtoReflect.Descriptor instead.
-func (*LeaveRoomRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use KickNotify.ProtoReflect.Descriptor instead.
+func (*KickNotify) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *LeaveRoomRequest) GetPlayerId() string {
+func (x *KickNotify) GetReason() int32 {
 	if x != nil {
-		return x.PlayerId
+		return x.Reason
 	}
-	return ""
+	return 0
 }
 
-func (x *LeaveRoomRequest) GetRoomId() string {
+func (x *KickNotify) GetMessage() string {
 	if x != nil {
-		return x.RoomId
+		return x.Message
 	}
 	return ""
 }
 
-type StartBattleRequest struct {
+// 玩家信息
+type PlayerInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`       // 房间ID
-	PlayerId      string                 `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 请求的玩家ID（房主）
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                              // 玩家ID
+	Nickname      string                 `protobuf:"bytes,2,opt,name=nickname,proto3" json:"nickname,omitempty"`                  // 昵称
+	Level         int32                  `protobuf:"varint,3,opt,name=level,proto3" json:"level,omitempty"`                       // 等级
+	Exp           int32                  `protobuf:"varint,4,opt,name=exp,proto3" json:"exp,omitempty"`                           // 经验值
+	VipLevel      int32                  `protobuf:"varint,5,opt,name=vip_level,json=vipLevel,proto3" json:"vip_level,omitempty"` // VIP等级
+	Items         []int32                `protobuf:"varint,6,rep,packed,name=items,proto3" json:"items,omitempty"`                // 物品ID列表
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StartBattleRequest) Reset() {
-	*x = StartBattleRequest{}
+func (x *PlayerInfo) Reset() {
+	*x = PlayerInfo{}
 	mi := &file_game_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StartBattleRequest) String() string {
+func (x *PlayerInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StartBattleRequest) ProtoMessage() {}
+func (*PlayerInfo) ProtoMessage() {}
 
-func (x *StartBattleRequest) ProtoReflect() protoreflect.Message {
+func (x *PlayerInfo) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -964,49 +924,76 @@ func (x *StartBattleRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StartBattleRequest.ProtoReflect.Descriptor instead.
-func (*StartBattleRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use PlayerInfo.ProtoReflect.Descriptor instead.
+func (*PlayerInfo) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *StartBattleRequest) GetRoomId() string {
+func (x *PlayerInfo) GetId() string {
 	if x != nil {
-		return x.RoomId
+		return x.Id
 	}
 	return ""
 }
 
-func (x *StartBattleRequest) GetPlayerId() string {
+func (x *PlayerInfo) GetNickname() string {
 	if x != nil {
-		return x.PlayerId
+		return x.Nickname
 	}
 	return ""
 }
 
-type StartBattleResponse struct {
+func (x *PlayerInfo) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *PlayerInfo) GetExp() int32 {
+	if x != nil {
+		return x.Exp
+	}
+	return 0
+}
+
+func (x *PlayerInfo) GetVipLevel() int32 {
+	if x != nil {
+		return x.VipLevel
+	}
+	return 0
+}
+
+func (x *PlayerInfo) GetItems() []int32 {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type CreateRoomRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	BattleId      string                 `protobuf:"bytes,3,opt,name=battle_id,json=battleId,proto3" json:"battle_id,omitempty"` // 战斗ID
-	Config        *BattleConfig          `protobuf:"bytes,4,opt,name=config,proto3" json:"config,omitempty"`                     // 战斗配置
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`        // 玩家ID
+	LevelId       int32                  `protobuf:"varint,2,opt,name=level_id,json=levelId,proto3" json:"level_id,omitempty"`          // 关卡ID
+	MaxPlayers    int32                  `protobuf:"varint,3,opt,name=max_players,json=maxPlayers,proto3" json:"max_players,omitempty"` // 最大玩家数
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StartBattleResponse) Reset() {
-	*x = StartBattleResponse{}
+func (x *CreateRoomRequest) Reset() {
+	*x = CreateRoomRequest{}
 	mi := &file_game_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StartBattleResponse) String() string {
+func (x *CreateRoomRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StartBattleResponse) ProtoMessage() {}
+func (*CreateRoomRequest) ProtoMessage() {}
 
-func (x *StartBattleResponse) ProtoReflect() protoreflect.Message {
+func (x *CreateRoomRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1018,61 +1005,55 @@ func (x *StartBattleResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StartBattleResponse.ProtoReflect.Descriptor instead.
-func (*StartBattleResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateRoomRequest.ProtoReflect.Descriptor instead.
+func (*CreateRoomRequest) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *StartBattleResponse) GetCode() int32 {
+func (x *CreateRoomRequest) GetPlayerId() string {
 	if x != nil {
-		return x.Code
+		return x.PlayerId
 	}
-	return 0
+	return ""
 }
 
-func (x *StartBattleResponse) GetMessage() string {
+func (x *CreateRoomRequest) GetLevelId() int32 {
 	if x != nil {
-		return x.Message
+		return x.LevelId
 	}
-	return ""
+	return 0
 }
 
-func (x *StartBattleResponse) GetBattleId() string {
-	if x != nil {
-		return x.BattleId
-	}
-	return ""
-}
-
-func (x *StartBattleResponse) GetConfig() *BattleConfig {
+func (x *CreateRoomRequest) GetMaxPlayers() int32 {
 	if x != nil {
-		return x.Config
+		return x.MaxPlayers
 	}
-	return nil
+	return 0
 }
 
-type EndBattleRequest struct {
+type CreateRoomResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	BattleId      string                 `protobuf:"bytes,1,opt,name=battle_id,json=battleId,proto3" json:"battle_id,omitempty"` // 战斗ID
-	Result        *BattleResult          `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`                     // 战斗结果
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	RoomId        string                 `protobuf:"bytes,3,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"` // 房间ID
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *EndBattleRequest) Reset() {
-	*x = EndBattleRequest{}
+func (x *CreateRoomResponse) Reset() {
+	*x = CreateRoomResponse{}
 	mi := &file_game_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EndBattleRequest) String() string {
+func (x *CreateRoomResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EndBattleRequest) ProtoMessage() {}
+func (*CreateRoomResponse) ProtoMessage() {}
 
-func (x *EndBattleRequest) ProtoReflect() protoreflect.Message {
+func (x *CreateRoomResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1084,48 +1065,54 @@ func (x *EndBattleRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EndBattleRequest.ProtoReflect.Descriptor instead.
-func (*EndBattleRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateRoomResponse.ProtoReflect.Descriptor instead.
+func (*CreateRoomResponse) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *EndBattleRequest) GetBattleId() string {
+func (x *CreateRoomResponse) GetCode() int32 {
 	if x != nil {
-		return x.BattleId
+		return x.Code
+	}
+	return 0
+}
+
+func (x *CreateRoomResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
 	}
 	return ""
 }
 
-func (x *EndBattleRequest) GetResult() *BattleResult {
+func (x *CreateRoomResponse) GetRoomId() string {
 	if x != nil {
-		return x.Result
+		return x.RoomId
 	}
-	return nil
+	return ""
 }
 
-type EndBattleResponse struct {
+type JoinRoomRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Rewards       *Rewards               `protobuf:"bytes,3,opt,name=rewards,proto3" json:"rewards,omitempty"` // 战斗奖励
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID
+	RoomId        string                 `protobuf:"bytes,2,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`       // 房间ID
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *EndBattleResponse) Reset() {
-	*x = EndBattleResponse{}
+func (x *JoinRoomRequest) Reset() {
+	*x = JoinRoomRequest{}
 	mi := &file_game_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EndBattleResponse) String() string {
+func (x *JoinRoomRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EndBattleResponse) ProtoMessage() {}
+func (*JoinRoomRequest) ProtoMessage() {}
 
-func (x *EndBattleResponse) ProtoReflect() protoreflect.Message {
+func (x *JoinRoomRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1137,54 +1124,48 @@ func (x *EndBattleResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EndBattleResponse.ProtoReflect.Descriptor instead.
-func (*EndBattleResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use JoinRoomRequest.ProtoReflect.Descriptor instead.
+func (*JoinRoomRequest) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *EndBattleResponse) GetCode() int32 {
-	if x != nil {
-		return x.Code
-	}
-	return 0
-}
-
-func (x *EndBattleResponse) GetMessage() string {
+func (x *JoinRoomRequest) GetPlayerId() string {
 	if x != nil {
-		return x.Message
+		return x.PlayerId
 	}
 	return ""
 }
 
-func (x *EndBattleResponse) GetRewards() *Rewards {
+func (x *JoinRoomRequest) GetRoomId() string {
 	if x != nil {
-		return x.Rewards
+		return x.RoomId
 	}
-	return nil
+	return ""
 }
 
-type ReconnectBattleRequest struct {
+type JoinRoomResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID
-	BattleId      string                 `protobuf:"bytes,2,opt,name=battle_id,json=battleId,proto3" json:"battle_id,omitempty"` // 战斗ID
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Room          *RoomInfo              `protobuf:"bytes,3,opt,name=room,proto3" json:"room,omitempty"` // 房间信息
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReconnectBattleRequest) Reset() {
-	*x = ReconnectBattleRequest{}
+func (x *JoinRoomResponse) Reset() {
+	*x = JoinRoomResponse{}
 	mi := &file_game_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReconnectBattleRequest) String() string {
+func (x *JoinRoomResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReconnectBattleRequest) ProtoMessage() {}
+func (*JoinRoomResponse) ProtoMessage() {}
 
-func (x *ReconnectBattleRequest) ProtoReflect() protoreflect.Message {
+func (x *JoinRoomResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1196,48 +1177,54 @@ func (x *ReconnectBattleRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReconnectBattleRequest.ProtoReflect.Descriptor instead.
-func (*ReconnectBattleRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use JoinRoomResponse.ProtoReflect.Descriptor instead.
+func (*JoinRoomResponse) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *ReconnectBattleRequest) GetPlayerId() string {
+func (x *JoinRoomResponse) GetCode() int32 {
 	if x != nil {
-		return x.PlayerId
+		return x.Code
 	}
-	return ""
+	return 0
 }
 
-func (x *ReconnectBattleRequest) GetBattleId() string {
+func (x *JoinRoomResponse) GetMessage() string {
 	if x != nil {
-		return x.BattleId
+		return x.Message
 	}
 	return ""
 }
 
-type BattleStateResponse struct {
+func (x *JoinRoomResponse) GetRoom() *RoomInfo {
+	if x != nil {
+		return x.Room
+	}
+	return nil
+}
+
+type LeaveRoomRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	State         *BattleState           `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"` // 当前战斗状态
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID
+	RoomId        string                 `protobuf:"bytes,2,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`       // 房间ID
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BattleStateResponse) Reset() {
-	*x = BattleStateResponse{}
+func (x *LeaveRoomRequest) Reset() {
+	*x = LeaveRoomRequest{}
 	mi := &file_game_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BattleStateResponse) String() string {
+func (x *LeaveRoomRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BattleStateResponse) ProtoMessage() {}
+func (*LeaveRoomRequest) ProtoMessage() {}
 
-func (x *BattleStateResponse) ProtoReflect() protoreflect.Message {
+func (x *LeaveRoomRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1249,56 +1236,47 @@ func (x *BattleStateResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BattleStateResponse.ProtoReflect.Descriptor instead.
-func (*BattleStateResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use LeaveRoomRequest.ProtoReflect.Descriptor instead.
+func (*LeaveRoomRequest) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *BattleStateResponse) GetCode() int32 {
-	if x != nil {
-		return x.Code
-	}
-	return 0
-}
-
-func (x *BattleStateResponse) GetMessage() string {
+func (x *LeaveRoomRequest) GetPlayerId() string {
 	if x != nil {
-		return x.Message
+		return x.PlayerId
 	}
 	return ""
 }
 
-func (x *BattleStateResponse) GetState() *BattleState {
+func (x *LeaveRoomRequest) GetRoomId() string {
 	if x != nil {
-		return x.State
+		return x.RoomId
 	}
-	return nil
+	return ""
 }
 
-type SyncBattleActionRequest struct {
+type StartBattleRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	BattleId      string                 `protobuf:"bytes,1,opt,name=battle_id,json=battleId,proto3" json:"battle_id,omitempty"` // 战斗ID
-	PlayerId      string                 `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID
-	Timestamp     int64                  `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`              // 时间戳
-	Actions       []*BattleAction        `protobuf:"bytes,4,rep,name=actions,proto3" json:"actions,omitempty"`                   // 战斗动作列表
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`       // 房间ID
+	PlayerId      string                 `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 请求的玩家ID（房主）
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SyncBattleActionRequest) Reset() {
-	*x = SyncBattleActionRequest{}
+func (x *StartBattleRequest) Reset() {
+	*x = StartBattleRequest{}
 	mi := &file_game_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SyncBattleActionRequest) String() string {
+func (x *StartBattleRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SyncBattleActionRequest) ProtoMessage() {}
+func (*StartBattleRequest) ProtoMessage() {}
 
-func (x *SyncBattleActionRequest) ProtoReflect() protoreflect.Message {
+func (x *StartBattleRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1310,65 +1288,49 @@ func (x *SyncBattleActionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SyncBattleActionRequest.ProtoReflect.Descriptor instead.
-func (*SyncBattleActionRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use StartBattleRequest.ProtoReflect.Descriptor instead.
+func (*StartBattleRequest) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *SyncBattleActionRequest) GetBattleId() string {
+func (x *StartBattleRequest) GetRoomId() string {
 	if x != nil {
-		return x.BattleId
+		return x.RoomId
 	}
 	return ""
 }
 
-func (x *SyncBattleActionRequest) GetPlayerId() string {
+func (x *StartBattleRequest) GetPlayerId() string {
 	if x != nil {
 		return x.PlayerId
 	}
 	return ""
 }
 
-func (x *SyncBattleActionRequest) GetTimestamp() int64 {
-	if x != nil {
-		return x.Timestamp
-	}
-	return 0
-}
-
-func (x *SyncBattleActionRequest) GetActions() []*BattleAction {
-	if x != nil {
-		return x.Actions
-	}
-	return nil
-}
-
-type RoomInfo struct {
+type StartBattleResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                    // 房间ID
-	LevelId       int32                  `protobuf:"varint,2,opt,name=level_id,json=levelId,proto3" json:"level_id,omitempty"`          // 关卡ID
-	MaxPlayers    int32                  `protobuf:"varint,3,opt,name=max_players,json=maxPlayers,proto3" json:"max_players,omitempty"` // 最大玩家数
-	Players       []*RoomPlayer          `protobuf:"bytes,4,rep,name=players,proto3" json:"players,omitempty"`                          // 玩家列表
-	OwnerId       string                 `protobuf:"bytes,5,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`           // 房主ID
-	Status        int32                  `protobuf:"varint,6,opt,name=status,proto3" json:"status,omitempty"`                           // 房间状态：0等待中，1战斗中
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	BattleId      string                 `protobuf:"bytes,3,opt,name=battle_id,json=battleId,proto3" json:"battle_id,omitempty"` // 战斗ID
+	Config        *BattleConfig          `protobuf:"bytes,4,opt,name=config,proto3" json:"config,omitempty"`                     // 战斗配置
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RoomInfo) Reset() {
-	*x = RoomInfo{}
+func (x *StartBattleResponse) Reset() {
+	*x = StartBattleResponse{}
 	mi := &file_game_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RoomInfo) String() string {
+func (x *StartBattleResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RoomInfo) ProtoMessage() {}
+func (*StartBattleResponse) ProtoMessage() {}
 
-func (x *RoomInfo) ProtoReflect() protoreflect.Message {
+func (x *StartBattleResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1380,77 +1342,61 @@ func (x *RoomInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RoomInfo.ProtoReflect.Descriptor instead.
-func (*RoomInfo) Descriptor() ([]byte, []int) {
+// Deprecated: Use StartBattleResponse.ProtoReflect.Descriptor instead.
+func (*StartBattleResponse) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *RoomInfo) GetId() string {
+func (x *StartBattleResponse) GetCode() int32 {
 	if x != nil {
-		return x.Id
+		return x.Code
 	}
-	return ""
+	return 0
 }
 
-func (x *RoomInfo) GetLevelId() int32 {
+func (x *StartBattleResponse) GetMessage() string {
 	if x != nil {
-		return x.LevelId
+		return x.Message
 	}
-	return 0
+	return ""
 }
 
-func (x *RoomInfo) GetMaxPlayers() int32 {
+func (x *StartBattleResponse) GetBattleId() string {
 	if x != nil {
-		return x.MaxPlayers
+		return x.BattleId
 	}
-	return 0
+	return ""
 }
 
-func (x *RoomInfo) GetPlayers() []*RoomPlayer {
+func (x *StartBattleResponse) GetConfig() *BattleConfig {
 	if x != nil {
-		return x.Players
+		return x.Config
 	}
 	return nil
 }
 
-func (x *RoomInfo) GetOwnerId() string {
-	if x != nil {
-		return x.OwnerId
-	}
-	return ""
-}
-
-func (x *RoomInfo) GetStatus() int32 {
-	if x != nil {
-		return x.Status
-	}
-	return 0
-}
-
-type RoomPlayer struct {
+type EndBattleRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                           // 玩家ID
-	Nickname      string                 `protobuf:"bytes,2,opt,name=nickname,proto3" json:"nickname,omitempty"`               // 昵称
-	Level         int32                  `protobuf:"varint,3,opt,name=level,proto3" json:"level,omitempty"`                    // 等级
-	IsReady       bool                   `protobuf:"varint,4,opt,name=is_ready,json=isReady,proto3" json:"is_ready,omitempty"` // 是否准备
+	BattleId      string                 `protobuf:"bytes,1,opt,name=battle_id,json=battleId,proto3" json:"battle_id,omitempty"` // 战斗ID
+	Result        *BattleResult          `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`                     // 战斗结果
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RoomPlayer) Reset() {
-	*x = RoomPlayer{}
+func (x *EndBattleRequest) Reset() {
+	*x = EndBattleRequest{}
 	mi := &file_game_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RoomPlayer) String() string {
+func (x *EndBattleRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RoomPlayer) ProtoMessage() {}
+func (*EndBattleRequest) ProtoMessage() {}
 
-func (x *RoomPlayer) ProtoReflect() protoreflect.Message {
+func (x *EndBattleRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1462,63 +1408,48 @@ func (x *RoomPlayer) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RoomPlayer.ProtoReflect.Descriptor instead.
-func (*RoomPlayer) Descriptor() ([]byte, []int) {
+// Deprecated: Use EndBattleRequest.ProtoReflect.Descriptor instead.
+func (*EndBattleRequest) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *RoomPlayer) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *RoomPlayer) GetNickname() string {
+func (x *EndBattleRequest) GetBattleId() string {
 	if x != nil {
-		return x.Nickname
+		return x.BattleId
 	}
 	return ""
 }
 
-func (x *RoomPlayer) GetLevel() int32 {
-	if x != nil {
-		return x.Level
-	}
-	return 0
-}
-
-func (x *RoomPlayer) GetIsReady() bool {
+func (x *EndBattleRequest) GetResult() *BattleResult {
 	if x != nil {
-		return x.IsReady
+		return x.Result
 	}
-	return false
+	return nil
 }
 
-type BattleConfig struct {
+type EndBattleResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	LevelId       int32                  `protobuf:"varint,1,opt,name=level_id,json=levelId,proto3" json:"level_id,omitempty"`       // 关卡ID
-	Players       []*BattlePlayer        `protobuf:"bytes,2,rep,name=players,proto3" json:"players,omitempty"`                       // 战斗玩家列表
-	TimeLimit     int32                  `protobuf:"varint,3,opt,name=time_limit,json=timeLimit,proto3" json:"time_limit,omitempty"` // 时间限制（秒）
-	BossHp        int32                  `protobuf:"varint,4,opt,name=boss_hp,json=bossHp,proto3" json:"boss_hp,omitempty"`          // BOSS初始血量
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Rewards       *Rewards               `protobuf:"bytes,3,opt,name=rewards,proto3" json:"rewards,omitempty"` // 战斗奖励
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BattleConfig) Reset() {
-	*x = BattleConfig{}
+func (x *EndBattleResponse) Reset() {
+	*x = EndBattleResponse{}
 	mi := &file_game_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BattleConfig) String() string {
+func (x *EndBattleResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BattleConfig) ProtoMessage() {}
+func (*EndBattleResponse) ProtoMessage() {}
 
-func (x *BattleConfig) ProtoReflect() protoreflect.Message {
+func (x *EndBattleResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1530,65 +1461,54 @@ func (x *BattleConfig) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BattleConfig.ProtoReflect.Descriptor instead.
-func (*BattleConfig) Descriptor() ([]byte, []int) {
+// Deprecated: Use EndBattleResponse.ProtoReflect.Descriptor instead.
+func (*EndBattleResponse) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *BattleConfig) GetLevelId() int32 {
+func (x *EndBattleResponse) GetCode() int32 {
 	if x != nil {
-		return x.LevelId
+		return x.Code
 	}
 	return 0
 }
 
-func (x *BattleConfig) GetPlayers() []*BattlePlayer {
-	if x != nil {
-		return x.Players
-	}
-	return nil
-}
-
-func (x *BattleConfig) GetTimeLimit() int32 {
+func (x *EndBattleResponse) GetMessage() string {
 	if x != nil {
-		return x.TimeLimit
+		return x.Message
 	}
-	return 0
+	return ""
 }
 
-func (x *BattleConfig) GetBossHp() int32 {
+func (x *EndBattleResponse) GetRewards() *Rewards {
 	if x != nil {
-		return x.BossHp
+		return x.Rewards
 	}
-	return 0
+	return nil
 }
 
-type BattlePlayer struct {
+type ReconnectBattleRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`             // 玩家ID
-	Hp            int32                  `protobuf:"varint,2,opt,name=hp,proto3" json:"hp,omitempty"`            // 血量
-	Mp            int32                  `protobuf:"varint,3,opt,name=mp,proto3" json:"mp,omitempty"`            // 魔法值
-	Damage        int32                  `protobuf:"varint,4,opt,name=damage,proto3" json:"damage,omitempty"`    // 攻击力
-	Defense       int32                  `protobuf:"varint,5,opt,name=defense,proto3" json:"defense,omitempty"`  // 防御力
-	Position      *Position              `protobuf:"bytes,6,opt,name=position,proto3" json:"position,omitempty"` // 初始位置
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID
+	BattleId      string                 `protobuf:"bytes,2,opt,name=battle_id,json=battleId,proto3" json:"battle_id,omitempty"` // 战斗ID
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BattlePlayer) Reset() {
-	*x = BattlePlayer{}
+func (x *ReconnectBattleRequest) Reset() {
+	*x = ReconnectBattleRequest{}
 	mi := &file_game_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BattlePlayer) String() string {
+func (x *ReconnectBattleRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BattlePlayer) ProtoMessage() {}
+func (*ReconnectBattleRequest) ProtoMessage() {}
 
-func (x *BattlePlayer) ProtoReflect() protoreflect.Message {
+func (x *ReconnectBattleRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1600,76 +1520,48 @@ func (x *BattlePlayer) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BattlePlayer.ProtoReflect.Descriptor instead.
-func (*BattlePlayer) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReconnectBattleRequest.ProtoReflect.Descriptor instead.
+func (*ReconnectBattleRequest) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *BattlePlayer) GetId() string {
+func (x *ReconnectBattleRequest) GetPlayerId() string {
 	if x != nil {
-		return x.Id
+		return x.PlayerId
 	}
 	return ""
 }
 
-func (x *BattlePlayer) GetHp() int32 {
-	if x != nil {
-		return x.Hp
-	}
-	return 0
-}
-
-func (x *BattlePlayer) GetMp() int32 {
-	if x != nil {
-		return x.Mp
-	}
-	return 0
-}
-
-func (x *BattlePlayer) GetDamage() int32 {
-	if x != nil {
-		return x.Damage
-	}
-	return 0
-}
-
-func (x *BattlePlayer) GetDefense() int32 {
-	if x != nil {
-		return x.Defense
-	}
-	return 0
-}
-
-func (x *BattlePlayer) GetPosition() *Position {
+func (x *ReconnectBattleRequest) GetBattleId() string {
 	if x != nil {
-		return x.Position
+		return x.BattleId
 	}
-	return nil
+	return ""
 }
 
-type Position struct {
+type BattleStateResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	X             float32                `protobuf:"fixed32,1,opt,name=x,proto3" json:"x,omitempty"` // X坐标
-	Y             float32                `protobuf:"fixed32,2,opt,name=y,proto3" json:"y,omitempty"` // Y坐标
-	Z             float32                `protobuf:"fixed32,3,opt,name=z,proto3" json:"z,omitempty"` // Z坐标
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	State         *BattleState           `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"` // 当前战斗状态
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Position) Reset() {
-	*x = Position{}
+func (x *BattleStateResponse) Reset() {
+	*x = BattleStateResponse{}
 	mi := &file_game_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Position) String() string {
+func (x *BattleStateResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Position) ProtoMessage() {}
+func (*BattleStateResponse) ProtoMessage() {}
 
-func (x *Position) ProtoReflect() protoreflect.Message {
+func (x *BattleStateResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1681,58 +1573,56 @@ func (x *Position) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Position.ProtoReflect.Descriptor instead.
-func (*Position) Descriptor() ([]byte, []int) {
+// Deprecated: Use BattleStateResponse.ProtoReflect.Descriptor instead.
+func (*BattleStateResponse) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *Position) GetX() float32 {
+func (x *BattleStateResponse) GetCode() int32 {
 	if x != nil {
-		return x.X
+		return x.Code
 	}
 	return 0
 }
 
-func (x *Position) GetY() float32 {
+func (x *BattleStateResponse) GetMessage() string {
 	if x != nil {
-		return x.Y
+		return x.Message
 	}
-	return 0
+	return ""
 }
 
-func (x *Position) GetZ() float32 {
+func (x *BattleStateResponse) GetState() *BattleState {
 	if x != nil {
-		return x.Z
+		return x.State
 	}
-	return 0
+	return nil
 }
 
-type BattleAction struct {
+type SyncBattleActionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Type          int32                  `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`                        // 动作类型：1移动，2攻击，3技能，4拾取
-	Position      *Position              `protobuf:"bytes,2,opt,name=position,proto3" json:"position,omitempty"`                 // 位置（移动时）
-	TargetId      string                 `protobuf:"bytes,3,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"` // 目标ID（攻击时）
-	SkillId       int32                  `protobuf:"varint,4,opt,name=skill_id,json=skillId,proto3" json:"skill_id,omitempty"`   // 技能ID（技能时）
-	ItemId        int32                  `protobuf:"varint,5,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`      // 物品ID（拾取时）
-	Timestamp     int64                  `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`              // 时间戳
+	BattleId      string                 `protobuf:"bytes,1,opt,name=battle_id,json=battleId,proto3" json:"battle_id,omitempty"` // 战斗ID
+	PlayerId      string                 `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID
+	Timestamp     int64                  `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`              // 时间戳
+	Actions       []*BattleAction        `protobuf:"bytes,4,rep,name=actions,proto3" json:"actions,omitempty"`                   // 战斗动作列表
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BattleAction) Reset() {
-	*x = BattleAction{}
+func (x *SyncBattleActionRequest) Reset() {
+	*x = SyncBattleActionRequest{}
 	mi := &file_game_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BattleAction) String() string {
+func (x *SyncBattleActionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BattleAction) ProtoMessage() {}
+func (*SyncBattleActionRequest) ProtoMessage() {}
 
-func (x *BattleAction) ProtoReflect() protoreflect.Message {
+func (x *SyncBattleActionRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1744,76 +1634,65 @@ func (x *BattleAction) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BattleAction.ProtoReflect.Descriptor instead.
-func (*BattleAction) Descriptor() ([]byte, []int) {
+// Deprecated: Use SyncBattleActionRequest.ProtoReflect.Descriptor instead.
+func (*SyncBattleActionRequest) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *BattleAction) GetType() int32 {
-	if x != nil {
-		return x.Type
-	}
-	return 0
-}
-
-func (x *BattleAction) GetPosition() *Position {
+func (x *SyncBattleActionRequest) GetBattleId() string {
 	if x != nil {
-		return x.Position
+		return x.BattleId
 	}
-	return nil
+	return ""
 }
 
-func (x *BattleAction) GetTargetId() string {
+func (x *SyncBattleActionRequest) GetPlayerId() string {
 	if x != nil {
-		return x.TargetId
+		return x.PlayerId
 	}
 	return ""
 }
 
-func (x *BattleAction) GetSkillId() int32 {
+func (x *SyncBattleActionRequest) GetTimestamp() int64 {
 	if x != nil {
-		return x.SkillId
+		return x.Timestamp
 	}
 	return 0
 }
 
-func (x *BattleAction) GetItemId() int32 {
+func (x *SyncBattleActionRequest) GetActions() []*BattleAction {
 	if x != nil {
-		return x.ItemId
+		return x.Actions
 	}
-	return 0
-}
-
-func (x *BattleAction) GetTimestamp() int64 {
-	if x != nil {
-		return x.Timestamp
-	}
-	return 0
+	return nil
 }
 
-type BattleResult struct {
+type RoomInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	IsWin         bool                   `protobuf:"varint,1,opt,name=is_win,json=isWin,proto3" json:"is_win,omitempty"`                  // 是否胜利
-	BossHp        int32                  `protobuf:"varint,2,opt,name=boss_hp,json=bossHp,proto3" json:"boss_hp,omitempty"`               // BOSS剩余血量
-	PlayerStats   []*PlayerBattleStats   `protobuf:"bytes,3,rep,name=player_stats,json=playerStats,proto3" json:"player_stats,omitempty"` // 玩家战斗统计
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                    // 房间ID
+	LevelId       int32                  `protobuf:"varint,2,opt,name=level_id,json=levelId,proto3" json:"level_id,omitempty"`          // 关卡ID
+	MaxPlayers    int32                  `protobuf:"varint,3,opt,name=max_players,json=maxPlayers,proto3" json:"max_players,omitempty"` // 最大玩家数
+	Players       []*RoomPlayer          `protobuf:"bytes,4,rep,name=players,proto3" json:"players,omitempty"`                          // 玩家列表
+	OwnerId       string                 `protobuf:"bytes,5,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`           // 房主ID
+	Status        int32                  `protobuf:"varint,6,opt,name=status,proto3" json:"status,omitempty"`                           // 房间状态：0等待中，1战斗中
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BattleResult) Reset() {
-	*x = BattleResult{}
+func (x *RoomInfo) Reset() {
+	*x = RoomInfo{}
 	mi := &file_game_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BattleResult) String() string {
+func (x *RoomInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BattleResult) ProtoMessage() {}
+func (*RoomInfo) ProtoMessage() {}
 
-func (x *BattleResult) ProtoReflect() protoreflect.Message {
+func (x *RoomInfo) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1825,59 +1704,77 @@ func (x *BattleResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BattleResult.ProtoReflect.Descriptor instead.
-func (*BattleResult) Descriptor() ([]byte, []int) {
+// Deprecated: Use RoomInfo.ProtoReflect.Descriptor instead.
+func (*RoomInfo) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *BattleResult) GetIsWin() bool {
+func (x *RoomInfo) GetId() string {
 	if x != nil {
-		return x.IsWin
+		return x.Id
 	}
-	return false
+	return ""
 }
 
-func (x *BattleResult) GetBossHp() int32 {
+func (x *RoomInfo) GetLevelId() int32 {
 	if x != nil {
-		return x.BossHp
+		return x.LevelId
 	}
 	return 0
 }
 
-func (x *BattleResult) GetPlayerStats() []*PlayerBattleStats {
+func (x *RoomInfo) GetMaxPlayers() int32 {
 	if x != nil {
-		return x.PlayerStats
+		return x.MaxPlayers
+	}
+	return 0
+}
+
+func (x *RoomInfo) GetPlayers() []*RoomPlayer {
+	if x != nil {
+		return x.Players
 	}
 	return nil
 }
 
-type PlayerBattleStats struct {
+func (x *RoomInfo) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *RoomInfo) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+type RoomPlayer struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`           // 玩家ID
-	DamageDealt   int32                  `protobuf:"varint,2,opt,name=damage_dealt,json=damageDealt,proto3" json:"damage_dealt,omitempty"` // 造成伤害
-	DamageTaken   int32                  `protobuf:"varint,3,opt,name=damage_taken,json=damageTaken,proto3" json:"damage_taken,omitempty"` // 承受伤害
-	Kills         int32                  `protobuf:"varint,4,opt,name=kills,proto3" json:"kills,omitempty"`                                // 击杀数
-	Deaths        int32                  `protobuf:"varint,5,opt,name=deaths,proto3" json:"deaths,omitempty"`                              // 死亡数
-	Heals         int32                  `protobuf:"varint,6,opt,name=heals,proto3" json:"heals,omitempty"`                                // 治疗量
-	ReviveCount   int32                  `protobuf:"varint,7,opt,name=revive_count,json=reviveCount,proto3" json:"revive_count,omitempty"` // 被复活次数
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                           // 玩家ID
+	Nickname      string                 `protobuf:"bytes,2,opt,name=nickname,proto3" json:"nickname,omitempty"`               // 昵称
+	Level         int32                  `protobuf:"varint,3,opt,name=level,proto3" json:"level,omitempty"`                    // 等级
+	IsReady       bool                   `protobuf:"varint,4,opt,name=is_ready,json=isReady,proto3" json:"is_ready,omitempty"` // 是否准备
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PlayerBattleStats) Reset() {
-	*x = PlayerBattleStats{}
+func (x *RoomPlayer) Reset() {
+	*x = RoomPlayer{}
 	mi := &file_game_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PlayerBattleStats) String() string {
+func (x *RoomPlayer) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PlayerBattleStats) ProtoMessage() {}
+func (*RoomPlayer) ProtoMessage() {}
 
-func (x *PlayerBattleStats) ProtoReflect() protoreflect.Message {
+func (x *RoomPlayer) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1889,86 +1786,63 @@ func (x *PlayerBattleStats) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PlayerBattleStats.ProtoReflect.Descriptor instead.
-func (*PlayerBattleStats) Descriptor() ([]byte, []int) {
+// Deprecated: Use RoomPlayer.ProtoReflect.Descriptor instead.
+func (*RoomPlayer) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *PlayerBattleStats) GetPlayerId() string {
+func (x *RoomPlayer) GetId() string {
 	if x != nil {
-		return x.PlayerId
+		return x.Id
 	}
 	return ""
 }
 
-func (x *PlayerBattleStats) GetDamageDealt() int32 {
-	if x != nil {
-		return x.DamageDealt
-	}
-	return 0
-}
-
-func (x *PlayerBattleStats) GetDamageTaken() int32 {
-	if x != nil {
-		return x.DamageTaken
-	}
-	return 0
-}
-
-func (x *PlayerBattleStats) GetKills() int32 {
-	if x != nil {
-		return x.Kills
-	}
-	return 0
-}
-
-func (x *PlayerBattleStats) GetDeaths() int32 {
+func (x *RoomPlayer) GetNickname() string {
 	if x != nil {
-		return x.Deaths
+		return x.Nickname
 	}
-	return 0
+	return ""
 }
 
-func (x *PlayerBattleStats) GetHeals() int32 {
+func (x *RoomPlayer) GetLevel() int32 {
 	if x != nil {
-		return x.Heals
+		return x.Level
 	}
 	return 0
 }
 
-func (x *PlayerBattleStats) GetReviveCount() int32 {
+func (x *RoomPlayer) GetIsReady() bool {
 	if x != nil {
-		return x.ReviveCount
+		return x.IsReady
 	}
-	return 0
+	return false
 }
 
-type BattleState struct {
+type BattleConfig struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	BattleId      string                 `protobuf:"bytes,1,opt,name=battle_id,json=battleId,proto3" json:"battle_id,omitempty"`           // 战斗ID
-	Status        int32                  `protobuf:"varint,2,opt,name=status,proto3" json:"status,omitempty"`                              // 状态：1进行中，2已结束
-	StartTime     int64                  `protobuf:"varint,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`       // 开始时间
-	CurrentTime   int64                  `protobuf:"varint,4,opt,name=current_time,json=currentTime,proto3" json:"current_time,omitempty"` // 当前时间
-	BossHp        int32                  `protobuf:"varint,5,opt,name=boss_hp,json=bossHp,proto3" json:"boss_hp,omitempty"`                // BOSS当前血量
-	Players       []*LivePlayerState     `protobuf:"bytes,6,rep,name=players,proto3" json:"players,omitempty"`                             // 存活玩家状态
+	LevelId       int32                  `protobuf:"varint,1,opt,name=level_id,json=levelId,proto3" json:"level_id,omitempty"`       // 关卡ID
+	Players       []*BattlePlayer        `protobuf:"bytes,2,rep,name=players,proto3" json:"players,omitempty"`                       // 战斗玩家列表
+	TimeLimit     int32                  `protobuf:"varint,3,opt,name=time_limit,json=timeLimit,proto3" json:"time_limit,omitempty"` // 时间限制（秒）
+	BossHp        int32                  `protobuf:"varint,4,opt,name=boss_hp,json=bossHp,proto3" json:"boss_hp,omitempty"`          // BOSS初始血量
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BattleState) Reset() {
-	*x = BattleState{}
+func (x *BattleConfig) Reset() {
+	*x = BattleConfig{}
 	mi := &file_game_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BattleState) String() string {
+func (x *BattleConfig) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BattleState) ProtoMessage() {}
+func (*BattleConfig) ProtoMessage() {}
 
-func (x *BattleState) ProtoReflect() protoreflect.Message {
+func (x *BattleConfig) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1980,79 +1854,65 @@ func (x *BattleState) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BattleState.ProtoReflect.Descriptor instead.
-func (*BattleState) Descriptor() ([]byte, []int) {
+// Deprecated: Use BattleConfig.ProtoReflect.Descriptor instead.
+func (*BattleConfig) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *BattleState) GetBattleId() string {
-	if x != nil {
-		return x.BattleId
-	}
-	return ""
-}
-
-func (x *BattleState) GetStatus() int32 {
+func (x *BattleConfig) GetLevelId() int32 {
 	if x != nil {
-		return x.Status
+		return x.LevelId
 	}
 	return 0
 }
 
-func (x *BattleState) GetStartTime() int64 {
+func (x *BattleConfig) GetPlayers() []*BattlePlayer {
 	if x != nil {
-		return x.StartTime
+		return x.Players
 	}
-	return 0
+	return nil
 }
 
-func (x *BattleState) GetCurrentTime() int64 {
+func (x *BattleConfig) GetTimeLimit() int32 {
 	if x != nil {
-		return x.CurrentTime
+		return x.TimeLimit
 	}
 	return 0
 }
 
-func (x *BattleState) GetBossHp() int32 {
+func (x *BattleConfig) GetBossHp() int32 {
 	if x != nil {
 		return x.BossHp
 	}
 	return 0
 }
 
-func (x *BattleState) GetPlayers() []*LivePlayerState {
-	if x != nil {
-		return x.Players
-	}
-	return nil
-}
-
-type LivePlayerState struct {
+type BattlePlayer struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`           // 玩家ID
-	Hp            int32                  `protobuf:"varint,2,opt,name=hp,proto3" json:"hp,omitempty"`                                      // 当前血量
-	Mp            int32                  `protobuf:"varint,3,opt,name=mp,proto3" json:"mp,omitempty"`                                      // 当前魔法值
-	Position      *Position              `protobuf:"bytes,4,opt,name=position,proto3" json:"position,omitempty"`                           // 当前位置
-	State         int32                  `protobuf:"varint,5,opt,name=state,proto3" json:"state,omitempty"`                                // 状态：1存活，2死亡
-	RespawnTime   int64                  `protobuf:"varint,6,opt,name=respawn_time,json=respawnTime,proto3" json:"respawn_time,omitempty"` // 复活时间
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`             // 玩家ID
+	Hp            int32                  `protobuf:"varint,2,opt,name=hp,proto3" json:"hp,omitempty"`            // 血量
+	Mp            int32                  `protobuf:"varint,3,opt,name=mp,proto3" json:"mp,omitempty"`            // 魔法值
+	Damage        int32                  `protobuf:"varint,4,opt,name=damage,proto3" json:"damage,omitempty"`    // 攻击力
+	Defense       int32                  `protobuf:"varint,5,opt,name=defense,proto3" json:"defense,omitempty"`  // 防御力
+	Position      *Position              `protobuf:"bytes,6,opt,name=position,proto3" json:"position,omitempty"` // 初始位置
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LivePlayerState) Reset() {
-	*x = LivePlayerState{}
+func (x *BattlePlayer) Reset() {
+	*x = BattlePlayer{}
 	mi := &file_game_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LivePlayerState) String() string {
+func (x *BattlePlayer) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LivePlayerState) ProtoMessage() {}
+func (*BattlePlayer) ProtoMessage() {}
 
-func (x *LivePlayerState) ProtoReflect() protoreflect.Message {
+func (x *BattlePlayer) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2064,76 +1924,76 @@ func (x *LivePlayerState) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LivePlayerState.ProtoReflect.Descriptor instead.
-func (*LivePlayerState) Descriptor() ([]byte, []int) {
+// Deprecated: Use BattlePlayer.ProtoReflect.Descriptor instead.
+func (*BattlePlayer) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *LivePlayerState) GetPlayerId() string {
+func (x *BattlePlayer) GetId() string {
 	if x != nil {
-		return x.PlayerId
+		return x.Id
 	}
 	return ""
 }
 
-func (x *LivePlayerState) GetHp() int32 {
+func (x *BattlePlayer) GetHp() int32 {
 	if x != nil {
 		return x.Hp
 	}
 	return 0
 }
 
-func (x *LivePlayerState) GetMp() int32 {
+func (x *BattlePlayer) GetMp() int32 {
 	if x != nil {
 		return x.Mp
 	}
 	return 0
 }
 
-func (x *LivePlayerState) GetPosition() *Position {
+func (x *BattlePlayer) GetDamage() int32 {
 	if x != nil {
-		return x.Position
+		return x.Damage
 	}
-	return nil
+	return 0
 }
 
-func (x *LivePlayerState) GetState() int32 {
+func (x *BattlePlayer) GetDefense() int32 {
 	if x != nil {
-		return x.State
+		return x.Defense
 	}
 	return 0
 }
 
-func (x *LivePlayerState) GetRespawnTime() int64 {
+func (x *BattlePlayer) GetPosition() *Position {
 	if x != nil {
-		return x.RespawnTime
+		return x.Position
 	}
-	return 0
+	return nil
 }
 
-type Rewards struct {
+type Position struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Exp           int32                  `protobuf:"varint,1,opt,name=exp,proto3" json:"exp,omitempty"`     // 经验奖励
-	Coins         int32                  `protobuf:"varint,2,opt,name=coins,proto3" json:"coins,omitempty"` // 金币奖励
-	Items         []*RewardItem          `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`  // 物品奖励
+	X             float32                `protobuf:"fixed32,1,opt,name=x,proto3" json:"x,omitempty"` // X坐标
+	Y             float32                `protobuf:"fixed32,2,opt,name=y,proto3" json:"y,omitempty"` // Y坐标
+	Z             float32                `protobuf:"fixed32,3,opt,name=z,proto3" json:"z,omitempty"` // Z坐标
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Rewards) Reset() {
-	*x = Rewards{}
+func (x *Position) Reset() {
+	*x = Position{}
 	mi := &file_game_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Rewards) String() string {
+func (x *Position) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Rewards) ProtoMessage() {}
+func (*Position) ProtoMessage() {}
 
-func (x *Rewards) ProtoReflect() protoreflect.Message {
+func (x *Position) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2145,54 +2005,58 @@ func (x *Rewards) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Rewards.ProtoReflect.Descriptor instead.
-func (*Rewards) Descriptor() ([]byte, []int) {
+// Deprecated: Use Position.ProtoReflect.Descriptor instead.
+func (*Position) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *Rewards) GetExp() int32 {
+func (x *Position) GetX() float32 {
 	if x != nil {
-		return x.Exp
+		return x.X
 	}
 	return 0
 }
 
-func (x *Rewards) GetCoins() int32 {
+func (x *Position) GetY() float32 {
 	if x != nil {
-		return x.Coins
+		return x.Y
 	}
 	return 0
 }
 
-func (x *Rewards) GetItems() []*RewardItem {
+func (x *Position) GetZ() float32 {
 	if x != nil {
-		return x.Items
+		return x.Z
 	}
-	return nil
+	return 0
 }
 
-type RewardItem struct {
+type BattleAction struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ItemId        int32                  `protobuf:"varint,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"` // 物品ID
-	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`                 // 数量
+	Type          int32                  `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`                        // 动作类型：1移动，2攻击，3技能，4拾取
+	Position      *Position              `protobuf:"bytes,2,opt,name=position,proto3" json:"position,omitempty"`                 // 位置（移动时）
+	TargetId      string                 `protobuf:"bytes,3,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"` // 目标ID（攻击时）
+	SkillId       int32                  `protobuf:"varint,4,opt,name=skill_id,json=skillId,proto3" json:"skill_id,omitempty"`   // 技能ID（技能时）
+	ItemId        int32                  `protobuf:"varint,5,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`      // 物品ID（拾取时）
+	Timestamp     int64                  `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`              // 时间戳
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RewardItem) Reset() {
-	*x = RewardItem{}
+func (x *BattleAction) Reset() {
+	*x = BattleAction{}
 	mi := &file_game_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RewardItem) String() string {
+func (x *BattleAction) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RewardItem) ProtoMessage() {}
+func (*BattleAction) ProtoMessage() {}
 
-func (x *RewardItem) ProtoReflect() protoreflect.Message {
+func (x *BattleAction) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2204,46 +2068,76 @@ func (x *RewardItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RewardItem.ProtoReflect.Descriptor instead.
-func (*RewardItem) Descriptor() ([]byte, []int) {
+// Deprecated: Use BattleAction.ProtoReflect.Descriptor instead.
+func (*BattleAction) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *RewardItem) GetItemId() int32 {
+func (x *BattleAction) GetType() int32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *BattleAction) GetPosition() *Position {
+	if x != nil {
+		return x.Position
+	}
+	return nil
+}
+
+func (x *BattleAction) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *BattleAction) GetSkillId() int32 {
+	if x != nil {
+		return x.SkillId
+	}
+	return 0
+}
+
+func (x *BattleAction) GetItemId() int32 {
 	if x != nil {
 		return x.ItemId
 	}
 	return 0
 }
 
-func (x *RewardItem) GetCount() int32 {
+func (x *BattleAction) GetTimestamp() int64 {
 	if x != nil {
-		return x.Count
+		return x.Timestamp
 	}
 	return 0
 }
 
-type GetBagRequest struct {
+type BattleResult struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID
+	IsWin         bool                   `protobuf:"varint,1,opt,name=is_win,json=isWin,proto3" json:"is_win,omitempty"`                  // 是否胜利
+	BossHp        int32                  `protobuf:"varint,2,opt,name=boss_hp,json=bossHp,proto3" json:"boss_hp,omitempty"`               // BOSS剩余血量
+	PlayerStats   []*PlayerBattleStats   `protobuf:"bytes,3,rep,name=player_stats,json=playerStats,proto3" json:"player_stats,omitempty"` // 玩家战斗统计
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetBagRequest) Reset() {
-	*x = GetBagRequest{}
+func (x *BattleResult) Reset() {
+	*x = BattleResult{}
 	mi := &file_game_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetBagRequest) String() string {
+func (x *BattleResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetBagRequest) ProtoMessage() {}
+func (*BattleResult) ProtoMessage() {}
 
-func (x *GetBagRequest) ProtoReflect() protoreflect.Message {
+func (x *BattleResult) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2255,42 +2149,59 @@ func (x *GetBagRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetBagRequest.ProtoReflect.Descriptor instead.
-func (*GetBagRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BattleResult.ProtoReflect.Descriptor instead.
+func (*BattleResult) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *GetBagRequest) GetPlayerId() string {
+func (x *BattleResult) GetIsWin() bool {
 	if x != nil {
-		return x.PlayerId
+		return x.IsWin
 	}
-	return ""
+	return false
 }
 
-type BagResponse struct {
+func (x *BattleResult) GetBossHp() int32 {
+	if x != nil {
+		return x.BossHp
+	}
+	return 0
+}
+
+func (x *BattleResult) GetPlayerStats() []*PlayerBattleStats {
+	if x != nil {
+		return x.PlayerStats
+	}
+	return nil
+}
+
+type PlayerBattleStats struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Items         []*BagItem             `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`        // 背包物品列表
-	Capacity      int32                  `protobuf:"varint,4,opt,name=capacity,proto3" json:"capacity,omitempty"` // 背包容量
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`           // 玩家ID
+	DamageDealt   int32                  `protobuf:"varint,2,opt,name=damage_dealt,json=damageDealt,proto3" json:"damage_dealt,omitempty"` // 造成伤害
+	DamageTaken   int32                  `protobuf:"varint,3,opt,name=damage_taken,json=damageTaken,proto3" json:"damage_taken,omitempty"` // 承受伤害
+	Kills         int32                  `protobuf:"varint,4,opt,name=kills,proto3" json:"kills,omitempty"`                                // 击杀数
+	Deaths        int32                  `protobuf:"varint,5,opt,name=deaths,proto3" json:"deaths,omitempty"`                              // 死亡数
+	Heals         int32                  `protobuf:"varint,6,opt,name=heals,proto3" json:"heals,omitempty"`                                // 治疗量
+	ReviveCount   int32                  `protobuf:"varint,7,opt,name=revive_count,json=reviveCount,proto3" json:"revive_count,omitempty"` // 被复活次数
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BagResponse) Reset() {
-	*x = BagResponse{}
+func (x *PlayerBattleStats) Reset() {
+	*x = PlayerBattleStats{}
 	mi := &file_game_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BagResponse) String() string {
+func (x *PlayerBattleStats) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BagResponse) ProtoMessage() {}
+func (*PlayerBattleStats) ProtoMessage() {}
 
-func (x *BagResponse) ProtoReflect() protoreflect.Message {
+func (x *PlayerBattleStats) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2302,64 +2213,86 @@ func (x *BagResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BagResponse.ProtoReflect.Descriptor instead.
-func (*BagResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use PlayerBattleStats.ProtoReflect.Descriptor instead.
+func (*PlayerBattleStats) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *BagResponse) GetCode() int32 {
+func (x *PlayerBattleStats) GetPlayerId() string {
 	if x != nil {
-		return x.Code
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *PlayerBattleStats) GetDamageDealt() int32 {
+	if x != nil {
+		return x.DamageDealt
 	}
 	return 0
 }
 
-func (x *BagResponse) GetMessage() string {
+func (x *PlayerBattleStats) GetDamageTaken() int32 {
 	if x != nil {
-		return x.Message
+		return x.DamageTaken
 	}
-	return ""
+	return 0
 }
 
-func (x *BagResponse) GetItems() []*BagItem {
+func (x *PlayerBattleStats) GetKills() int32 {
 	if x != nil {
-		return x.Items
+		return x.Kills
 	}
-	return nil
+	return 0
 }
 
-func (x *BagResponse) GetCapacity() int32 {
+func (x *PlayerBattleStats) GetDeaths() int32 {
 	if x != nil {
-		return x.Capacity
+		return x.Deaths
 	}
 	return 0
 }
 
-type BagItem struct {
+func (x *PlayerBattleStats) GetHeals() int32 {
+	if x != nil {
+		return x.Heals
+	}
+	return 0
+}
+
+func (x *PlayerBattleStats) GetReviveCount() int32 {
+	if x != nil {
+		return x.ReviveCount
+	}
+	return 0
+}
+
+type BattleState struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                                                 // 物品实例ID
-	ItemId        int32                  `protobuf:"varint,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`                                                          // 物品ID
-	Count         int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`                                                                          // 数量
-	CreateTime    int64                  `protobuf:"varint,4,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`                                              // 获得时间
-	Attrs         map[string]string      `protobuf:"bytes,5,rep,name=attrs,proto3" json:"attrs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 物品属性
+	BattleId      string                 `protobuf:"bytes,1,opt,name=battle_id,json=battleId,proto3" json:"battle_id,omitempty"`           // 战斗ID
+	Status        int32                  `protobuf:"varint,2,opt,name=status,proto3" json:"status,omitempty"`                              // 状态：1进行中，2已结束
+	StartTime     int64                  `protobuf:"varint,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`       // 开始时间
+	CurrentTime   int64                  `protobuf:"varint,4,opt,name=current_time,json=currentTime,proto3" json:"current_time,omitempty"` // 当前时间
+	BossHp        int32                  `protobuf:"varint,5,opt,name=boss_hp,json=bossHp,proto3" json:"boss_hp,omitempty"`                // BOSS当前血量
+	Players       []*LivePlayerState     `protobuf:"bytes,6,rep,name=players,proto3" json:"players,omitempty"`                             // 存活玩家状态
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BagItem) Reset() {
-	*x = BagItem{}
+func (x *BattleState) Reset() {
+	*x = BattleState{}
 	mi := &file_game_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BagItem) String() string {
+func (x *BattleState) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BagItem) ProtoMessage() {}
+func (*BattleState) ProtoMessage() {}
 
-func (x *BagItem) ProtoReflect() protoreflect.Message {
+func (x *BattleState) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2371,69 +2304,79 @@ func (x *BagItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BagItem.ProtoReflect.Descriptor instead.
-func (*BagItem) Descriptor() ([]byte, []int) {
+// Deprecated: Use BattleState.ProtoReflect.Descriptor instead.
+func (*BattleState) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *BagItem) GetId() string {
+func (x *BattleState) GetBattleId() string {
 	if x != nil {
-		return x.Id
+		return x.BattleId
 	}
 	return ""
 }
 
-func (x *BagItem) GetItemId() int32 {
+func (x *BattleState) GetStatus() int32 {
 	if x != nil {
-		return x.ItemId
+		return x.Status
 	}
 	return 0
 }
 
-func (x *BagItem) GetCount() int32 {
+func (x *BattleState) GetStartTime() int64 {
 	if x != nil {
-		return x.Count
+		return x.StartTime
 	}
 	return 0
 }
 
-func (x *BagItem) GetCreateTime() int64 {
+func (x *BattleState) GetCurrentTime() int64 {
 	if x != nil {
-		return x.CreateTime
+		return x.CurrentTime
 	}
 	return 0
 }
 
-func (x *BagItem) GetAttrs() map[string]string {
+func (x *BattleState) GetBossHp() int32 {
 	if x != nil {
-		return x.Attrs
+		return x.BossHp
 	}
-	return nil
+	return 0
 }
 
-type UseItemRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	PlayerId       string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`                     // 玩家ID
-	ItemInstanceId string                 `protobuf:"bytes,2,opt,name=item_instance_id,json=itemInstanceId,proto3" json:"item_instance_id,omitempty"` // 物品实例ID
-	Count          int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`                                          // 使用数量
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+func (x *BattleState) GetPlayers() []*LivePlayerState {
+	if x != nil {
+		return x.Players
+	}
+	return nil
 }
 
-func (x *UseItemRequest) Reset() {
-	*x = UseItemRequest{}
-	mi := &file_game_proto_msgTypes[37]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+type LivePlayerState struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`           // 玩家ID
+	Hp            int32                  `protobuf:"varint,2,opt,name=hp,proto3" json:"hp,omitempty"`                                      // 当前血量
+	Mp            int32                  `protobuf:"varint,3,opt,name=mp,proto3" json:"mp,omitempty"`                                      // 当前魔法值
+	Position      *Position              `protobuf:"bytes,4,opt,name=position,proto3" json:"position,omitempty"`                           // 当前位置
+	State         int32                  `protobuf:"varint,5,opt,name=state,proto3" json:"state,omitempty"`                                // 状态：1存活，2死亡
+	RespawnTime   int64                  `protobuf:"varint,6,opt,name=respawn_time,json=respawnTime,proto3" json:"respawn_time,omitempty"` // 复活时间
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UseItemRequest) String() string {
+func (x *LivePlayerState) Reset() {
+	*x = LivePlayerState{}
+	mi := &file_game_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LivePlayerState) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UseItemRequest) ProtoMessage() {}
+func (*LivePlayerState) ProtoMessage() {}
 
-func (x *UseItemRequest) ProtoReflect() protoreflect.Message {
+func (x *LivePlayerState) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2445,55 +2388,76 @@ func (x *UseItemRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UseItemRequest.ProtoReflect.Descriptor instead.
-func (*UseItemRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use LivePlayerState.ProtoReflect.Descriptor instead.
+func (*LivePlayerState) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *UseItemRequest) GetPlayerId() string {
+func (x *LivePlayerState) GetPlayerId() string {
 	if x != nil {
 		return x.PlayerId
 	}
 	return ""
 }
 
-func (x *UseItemRequest) GetItemInstanceId() string {
+func (x *LivePlayerState) GetHp() int32 {
 	if x != nil {
-		return x.ItemInstanceId
+		return x.Hp
 	}
-	return ""
+	return 0
 }
 
-func (x *UseItemRequest) GetCount() int32 {
+func (x *LivePlayerState) GetMp() int32 {
 	if x != nil {
-		return x.Count
+		return x.Mp
 	}
 	return 0
 }
 
-type UseItemResponse struct {
+func (x *LivePlayerState) GetPosition() *Position {
+	if x != nil {
+		return x.Position
+	}
+	return nil
+}
+
+func (x *LivePlayerState) GetState() int32 {
+	if x != nil {
+		return x.State
+	}
+	return 0
+}
+
+func (x *LivePlayerState) GetRespawnTime() int64 {
+	if x != nil {
+		return x.RespawnTime
+	}
+	return 0
+}
+
+type Rewards struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Effects       map[string]int32       `protobuf:"bytes,3,rep,name=effects,proto3" json:"effects,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // 效果，如HP+100
+	Exp           int32                  `protobuf:"varint,1,opt,name=exp,proto3" json:"exp,omitempty"`     // 经验奖励
+	Coins         int32                  `protobuf:"varint,2,opt,name=coins,proto3" json:"coins,omitempty"` // 金币奖励
+	Items         []*RewardItem          `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`  // 物品奖励
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UseItemResponse) Reset() {
-	*x = UseItemResponse{}
+func (x *Rewards) Reset() {
+	*x = Rewards{}
 	mi := &file_game_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UseItemResponse) String() string {
+func (x *Rewards) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UseItemResponse) ProtoMessage() {}
+func (*Rewards) ProtoMessage() {}
 
-func (x *UseItemResponse) ProtoReflect() protoreflect.Message {
+func (x *Rewards) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2505,55 +2469,54 @@ func (x *UseItemResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UseItemResponse.ProtoReflect.Descriptor instead.
-func (*UseItemResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use Rewards.ProtoReflect.Descriptor instead.
+func (*Rewards) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *UseItemResponse) GetCode() int32 {
+func (x *Rewards) GetExp() int32 {
 	if x != nil {
-		return x.Code
+		return x.Exp
 	}
 	return 0
 }
 
-func (x *UseItemResponse) GetMessage() string {
+func (x *Rewards) GetCoins() int32 {
 	if x != nil {
-		return x.Message
+		return x.Coins
 	}
-	return ""
+	return 0
 }
 
-func (x *UseItemResponse) GetEffects() map[string]int32 {
+func (x *Rewards) GetItems() []*RewardItem {
 	if x != nil {
-		return x.Effects
+		return x.Items
 	}
 	return nil
 }
 
-type DropItemRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	PlayerId       string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`                     // 玩家ID
-	ItemInstanceId string                 `protobuf:"bytes,2,opt,name=item_instance_id,json=itemInstanceId,proto3" json:"item_instance_id,omitempty"` // 物品实例ID
-	Count          int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`                                          // 丢弃数量
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+type RewardItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        int32                  `protobuf:"varint,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"` // 物品ID
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`                 // 数量
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DropItemRequest) Reset() {
-	*x = DropItemRequest{}
+func (x *RewardItem) Reset() {
+	*x = RewardItem{}
 	mi := &file_game_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DropItemRequest) String() string {
+func (x *RewardItem) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DropItemRequest) ProtoMessage() {}
+func (*RewardItem) ProtoMessage() {}
 
-func (x *DropItemRequest) ProtoReflect() protoreflect.Message {
+func (x *RewardItem) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2565,54 +2528,46 @@ func (x *DropItemRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DropItemRequest.ProtoReflect.Descriptor instead.
-func (*DropItemRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use RewardItem.ProtoReflect.Descriptor instead.
+func (*RewardItem) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *DropItemRequest) GetPlayerId() string {
-	if x != nil {
-		return x.PlayerId
-	}
-	return ""
-}
-
-func (x *DropItemRequest) GetItemInstanceId() string {
+func (x *RewardItem) GetItemId() int32 {
 	if x != nil {
-		return x.ItemInstanceId
+		return x.ItemId
 	}
-	return ""
+	return 0
 }
 
-func (x *DropItemRequest) GetCount() int32 {
+func (x *RewardItem) GetCount() int32 {
 	if x != nil {
 		return x.Count
 	}
 	return 0
 }
 
-type CombineItemsRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	PlayerId        string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`                        // 玩家ID
-	ItemInstanceIds []string               `protobuf:"bytes,2,rep,name=item_instance_ids,json=itemInstanceIds,proto3" json:"item_instance_ids,omitempty"` // 用于合成的物品实例ID列表
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+type GetBagRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CombineItemsRequest) Reset() {
-	*x = CombineItemsRequest{}
+func (x *GetBagRequest) Reset() {
+	*x = GetBagRequest{}
 	mi := &file_game_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CombineItemsRequest) String() string {
+func (x *GetBagRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CombineItemsRequest) ProtoMessage() {}
+func (*GetBagRequest) ProtoMessage() {}
 
-func (x *CombineItemsRequest) ProtoReflect() protoreflect.Message {
+func (x *GetBagRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2624,48 +2579,42 @@ func (x *CombineItemsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CombineItemsRequest.ProtoReflect.Descriptor instead.
-func (*CombineItemsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetBagRequest.ProtoReflect.Descriptor instead.
+func (*GetBagRequest) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{40}
 }
 
-func (x *CombineItemsRequest) GetPlayerId() string {
+func (x *GetBagRequest) GetPlayerId() string {
 	if x != nil {
 		return x.PlayerId
 	}
 	return ""
 }
 
-func (x *CombineItemsRequest) GetItemInstanceIds() []string {
-	if x != nil {
-		return x.ItemInstanceIds
-	}
-	return nil
-}
-
-type CombineItemsResponse struct {
+type BagResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	ResultItem    *BagItem               `protobuf:"bytes,3,opt,name=result_item,json=resultItem,proto3" json:"result_item,omitempty"` // 合成结果
+	Items         []*BagItem             `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`        // 背包物品列表
+	Capacity      int32                  `protobuf:"varint,4,opt,name=capacity,proto3" json:"capacity,omitempty"` // 背包容量
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CombineItemsResponse) Reset() {
-	*x = CombineItemsResponse{}
+func (x *BagResponse) Reset() {
+	*x = BagResponse{}
 	mi := &file_game_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CombineItemsResponse) String() string {
+func (x *BagResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CombineItemsResponse) ProtoMessage() {}
+func (*BagResponse) ProtoMessage() {}
 
-func (x *CombineItemsResponse) ProtoReflect() protoreflect.Message {
+func (x *BagResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2677,55 +2626,64 @@ func (x *CombineItemsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CombineItemsResponse.ProtoReflect.Descriptor instead.
-func (*CombineItemsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use BagResponse.ProtoReflect.Descriptor instead.
+func (*BagResponse) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{41}
 }
 
-func (x *CombineItemsResponse) GetCode() int32 {
+func (x *BagResponse) GetCode() int32 {
 	if x != nil {
 		return x.Code
 	}
 	return 0
 }
 
-func (x *CombineItemsResponse) GetMessage() string {
+func (x *BagResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *CombineItemsResponse) GetResultItem() *BagItem {
+func (x *BagResponse) GetItems() []*BagItem {
 	if x != nil {
-		return x.ResultItem
+		return x.Items
 	}
 	return nil
 }
 
-type GetMailListRequest struct {
+func (x *BagResponse) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+type BagItem struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`  // 玩家ID
-	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`                         // 页码
-	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // 每页数量
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                                                 // 物品实例ID
+	ItemId        int32                  `protobuf:"varint,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`                                                          // 物品ID
+	Count         int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`                                                                          // 数量
+	CreateTime    int64                  `protobuf:"varint,4,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`                                              // 获得时间
+	Attrs         map[string]string      `protobuf:"bytes,5,rep,name=attrs,proto3" json:"attrs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 物品属性
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetMailListRequest) Reset() {
-	*x = GetMailListRequest{}
+func (x *BagItem) Reset() {
+	*x = BagItem{}
 	mi := &file_game_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMailListRequest) String() string {
+func (x *BagItem) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMailListRequest) ProtoMessage() {}
+func (*BagItem) ProtoMessage() {}
 
-func (x *GetMailListRequest) ProtoReflect() protoreflect.Message {
+func (x *BagItem) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2737,56 +2695,69 @@ func (x *GetMailListRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMailListRequest.ProtoReflect.Descriptor instead.
-func (*GetMailListRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BagItem.ProtoReflect.Descriptor instead.
+func (*BagItem) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *GetMailListRequest) GetPlayerId() string {
+func (x *BagItem) GetId() string {
 	if x != nil {
-		return x.PlayerId
+		return x.Id
 	}
 	return ""
 }
 
-func (x *GetMailListRequest) GetPage() int32 {
+func (x *BagItem) GetItemId() int32 {
 	if x != nil {
-		return x.Page
+		return x.ItemId
 	}
 	return 0
 }
 
-func (x *GetMailListRequest) GetPageSize() int32 {
+func (x *BagItem) GetCount() int32 {
 	if x != nil {
-		return x.PageSize
+		return x.Count
 	}
 	return 0
 }
 
-type GetMailListResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Mails         []*MailBrief           `protobuf:"bytes,3,rep,name=mails,proto3" json:"mails,omitempty"`  // 邮件简要列表
-	Total         int32                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"` // 总数量
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *BagItem) GetCreateTime() int64 {
+	if x != nil {
+		return x.CreateTime
+	}
+	return 0
 }
 
-func (x *GetMailListResponse) Reset() {
-	*x = GetMailListResponse{}
+func (x *BagItem) GetAttrs() map[string]string {
+	if x != nil {
+		return x.Attrs
+	}
+	return nil
+}
+
+type UseItemRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId       string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`                     // 玩家ID
+	ItemInstanceId string                 `protobuf:"bytes,2,opt,name=item_instance_id,json=itemInstanceId,proto3" json:"item_instance_id,omitempty"` // 物品实例ID
+	Count          int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`                                          // 使用数量
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UseItemRequest) Reset() {
+	*x = UseItemRequest{}
 	mi := &file_game_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMailListResponse) String() string {
+func (x *UseItemRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMailListResponse) ProtoMessage() {}
+func (*UseItemRequest) ProtoMessage() {}
 
-func (x *GetMailListResponse) ProtoReflect() protoreflect.Message {
+func (x *UseItemRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2798,65 +2769,55 @@ func (x *GetMailListResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMailListResponse.ProtoReflect.Descriptor instead.
-func (*GetMailListResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use UseItemRequest.ProtoReflect.Descriptor instead.
+func (*UseItemRequest) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{43}
 }
 
-func (x *GetMailListResponse) GetCode() int32 {
-	if x != nil {
-		return x.Code
-	}
-	return 0
-}
-
-func (x *GetMailListResponse) GetMessage() string {
+func (x *UseItemRequest) GetPlayerId() string {
 	if x != nil {
-		return x.Message
+		return x.PlayerId
 	}
 	return ""
 }
 
-func (x *GetMailListResponse) GetMails() []*MailBrief {
+func (x *UseItemRequest) GetItemInstanceId() string {
 	if x != nil {
-		return x.Mails
+		return x.ItemInstanceId
 	}
-	return nil
+	return ""
 }
 
-func (x *GetMailListResponse) GetTotal() int32 {
+func (x *UseItemRequest) GetCount() int32 {
 	if x != nil {
-		return x.Total
+		return x.Count
 	}
 	return 0
 }
 
-type MailBrief struct {
+type UseItemResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	MailId        string                 `protobuf:"bytes,1,opt,name=mail_id,json=mailId,proto3" json:"mail_id,omitempty"`                       // 邮件ID
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`                                       // 标题
-	SendTime      int64                  `protobuf:"varint,3,opt,name=send_time,json=sendTime,proto3" json:"send_time,omitempty"`                // 发送时间
-	HasAttachment bool                   `protobuf:"varint,4,opt,name=has_attachment,json=hasAttachment,proto3" json:"has_attachment,omitempty"` // 是否有附件
-	IsRead        bool                   `protobuf:"varint,5,opt,name=is_read,json=isRead,proto3" json:"is_read,omitempty"`                      // 是否已读
-	IsReceived    bool                   `protobuf:"varint,6,opt,name=is_received,json=isReceived,proto3" json:"is_received,omitempty"`          // 是否已领取附件
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Effects       map[string]int32       `protobuf:"bytes,3,rep,name=effects,proto3" json:"effects,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // 效果，如HP+100
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MailBrief) Reset() {
-	*x = MailBrief{}
+func (x *UseItemResponse) Reset() {
+	*x = UseItemResponse{}
 	mi := &file_game_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MailBrief) String() string {
+func (x *UseItemResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MailBrief) ProtoMessage() {}
+func (*UseItemResponse) ProtoMessage() {}
 
-func (x *MailBrief) ProtoReflect() protoreflect.Message {
+func (x *UseItemResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2868,75 +2829,55 @@ func (x *MailBrief) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MailBrief.ProtoReflect.Descriptor instead.
-func (*MailBrief) Descriptor() ([]byte, []int) {
+// Deprecated: Use UseItemResponse.ProtoReflect.Descriptor instead.
+func (*UseItemResponse) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{44}
 }
 
-func (x *MailBrief) GetMailId() string {
-	if x != nil {
-		return x.MailId
-	}
-	return ""
-}
-
-func (x *MailBrief) GetTitle() string {
-	if x != nil {
-		return x.Title
-	}
-	return ""
-}
-
-func (x *MailBrief) GetSendTime() int64 {
+func (x *UseItemResponse) GetCode() int32 {
 	if x != nil {
-		return x.SendTime
+		return x.Code
 	}
 	return 0
 }
 
-func (x *MailBrief) GetHasAttachment() bool {
-	if x != nil {
-		return x.HasAttachment
-	}
-	return false
-}
-
-func (x *MailBrief) GetIsRead() bool {
+func (x *UseItemResponse) GetMessage() string {
 	if x != nil {
-		return x.IsRead
+		return x.Message
 	}
-	return false
+	return ""
 }
 
-func (x *MailBrief) GetIsReceived() bool {
+func (x *UseItemResponse) GetEffects() map[string]int32 {
 	if x != nil {
-		return x.IsReceived
+		return x.Effects
 	}
-	return false
+	return nil
 }
 
-type GetMailDetailRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID
-	MailId        string                 `protobuf:"bytes,2,opt,name=mail_id,json=mailId,proto3" json:"mail_id,omitempty"`       // 邮件ID
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type DropItemRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId       string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`                     // 玩家ID
+	ItemInstanceId string                 `protobuf:"bytes,2,opt,name=item_instance_id,json=itemInstanceId,proto3" json:"item_instance_id,omitempty"` // 物品实例ID
+	Count          int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`                                          // 丢弃数量
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *GetMailDetailRequest) Reset() {
-	*x = GetMailDetailRequest{}
+func (x *DropItemRequest) Reset() {
+	*x = DropItemRequest{}
 	mi := &file_game_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMailDetailRequest) String() string {
+func (x *DropItemRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMailDetailRequest) ProtoMessage() {}
+func (*DropItemRequest) ProtoMessage() {}
 
-func (x *GetMailDetailRequest) ProtoReflect() protoreflect.Message {
+func (x *DropItemRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2948,48 +2889,54 @@ func (x *GetMailDetailRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMailDetailRequest.ProtoReflect.Descriptor instead.
-func (*GetMailDetailRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DropItemRequest.ProtoReflect.Descriptor instead.
+func (*DropItemRequest) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *GetMailDetailRequest) GetPlayerId() string {
+func (x *DropItemRequest) GetPlayerId() string {
 	if x != nil {
 		return x.PlayerId
 	}
 	return ""
 }
 
-func (x *GetMailDetailRequest) GetMailId() string {
+func (x *DropItemRequest) GetItemInstanceId() string {
 	if x != nil {
-		return x.MailId
+		return x.ItemInstanceId
 	}
 	return ""
 }
 
-type GetMailDetailResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Mail          *Mail                  `protobuf:"bytes,3,opt,name=mail,proto3" json:"mail,omitempty"` // 邮件详情
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *DropItemRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
 }
 
-func (x *GetMailDetailResponse) Reset() {
-	*x = GetMailDetailResponse{}
+type CombineItemsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId        string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`                        // 玩家ID
+	ItemInstanceIds []string               `protobuf:"bytes,2,rep,name=item_instance_ids,json=itemInstanceIds,proto3" json:"item_instance_ids,omitempty"` // 用于合成的物品实例ID列表
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CombineItemsRequest) Reset() {
+	*x = CombineItemsRequest{}
 	mi := &file_game_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMailDetailResponse) String() string {
+func (x *CombineItemsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMailDetailResponse) ProtoMessage() {}
+func (*CombineItemsRequest) ProtoMessage() {}
 
-func (x *GetMailDetailResponse) ProtoReflect() protoreflect.Message {
+func (x *CombineItemsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3001,60 +2948,48 @@ func (x *GetMailDetailResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMailDetailResponse.ProtoReflect.Descriptor instead.
-func (*GetMailDetailResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CombineItemsRequest.ProtoReflect.Descriptor instead.
+func (*CombineItemsRequest) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *GetMailDetailResponse) GetCode() int32 {
-	if x != nil {
-		return x.Code
-	}
-	return 0
-}
-
-func (x *GetMailDetailResponse) GetMessage() string {
+func (x *CombineItemsRequest) GetPlayerId() string {
 	if x != nil {
-		return x.Message
+		return x.PlayerId
 	}
 	return ""
 }
 
-func (x *GetMailDetailResponse) GetMail() *Mail {
+func (x *CombineItemsRequest) GetItemInstanceIds() []string {
 	if x != nil {
-		return x.Mail
+		return x.ItemInstanceIds
 	}
 	return nil
 }
 
-type Mail struct {
+type CombineItemsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                    // 邮件ID
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`                              // 标题
-	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`                          // 内容
-	SendTime      int64                  `protobuf:"varint,4,opt,name=send_time,json=sendTime,proto3" json:"send_time,omitempty"`       // 发送时间
-	ExpireTime    int64                  `protobuf:"varint,5,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"` // 过期时间
-	IsRead        bool                   `protobuf:"varint,6,opt,name=is_read,json=isRead,proto3" json:"is_read,omitempty"`             // 是否已读
-	IsReceived    bool                   `protobuf:"varint,7,opt,name=is_received,json=isReceived,proto3" json:"is_received,omitempty"` // 是否已领取附件
-	Attachments   []*MailAttachment      `protobuf:"bytes,8,rep,name=attachments,proto3" json:"attachments,omitempty"`                  // 附件列表
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ResultItem    *BagItem               `protobuf:"bytes,3,opt,name=result_item,json=resultItem,proto3" json:"result_item,omitempty"` // 合成结果
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Mail) Reset() {
-	*x = Mail{}
+func (x *CombineItemsResponse) Reset() {
+	*x = CombineItemsResponse{}
 	mi := &file_game_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Mail) String() string {
+func (x *CombineItemsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Mail) ProtoMessage() {}
+func (*CombineItemsResponse) ProtoMessage() {}
 
-func (x *Mail) ProtoReflect() protoreflect.Message {
+func (x *CombineItemsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3066,90 +3001,55 @@ func (x *Mail) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Mail.ProtoReflect.Descriptor instead.
-func (*Mail) Descriptor() ([]byte, []int) {
+// Deprecated: Use CombineItemsResponse.ProtoReflect.Descriptor instead.
+func (*CombineItemsResponse) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *Mail) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *Mail) GetTitle() string {
+func (x *CombineItemsResponse) GetCode() int32 {
 	if x != nil {
-		return x.Title
+		return x.Code
 	}
-	return ""
+	return 0
 }
 
-func (x *Mail) GetContent() string {
+func (x *CombineItemsResponse) GetMessage() string {
 	if x != nil {
-		return x.Content
+		return x.Message
 	}
 	return ""
 }
 
-func (x *Mail) GetSendTime() int64 {
+func (x *CombineItemsResponse) GetResultItem() *BagItem {
 	if x != nil {
-		return x.SendTime
+		return x.ResultItem
 	}
-	return 0
+	return nil
 }
 
-func (x *Mail) GetExpireTime() int64 {
-	if x != nil {
-		return x.ExpireTime
-	}
-	return 0
+type GetMailListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`  // 玩家ID
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`                         // 页码
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // 每页数量
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Mail) GetIsRead() bool {
-	if x != nil {
-		return x.IsRead
-	}
-	return false
-}
-
-func (x *Mail) GetIsReceived() bool {
-	if x != nil {
-		return x.IsReceived
-	}
-	return false
-}
-
-func (x *Mail) GetAttachments() []*MailAttachment {
-	if x != nil {
-		return x.Attachments
-	}
-	return nil
-}
-
-type MailAttachment struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Type          int32                  `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`                   // 附件类型：1物品，2金币，3钻石
-	ItemId        int32                  `protobuf:"varint,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"` // 物品ID
-	Count         int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`                 // 数量
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *MailAttachment) Reset() {
-	*x = MailAttachment{}
+func (x *GetMailListRequest) Reset() {
+	*x = GetMailListRequest{}
 	mi := &file_game_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MailAttachment) String() string {
+func (x *GetMailListRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MailAttachment) ProtoMessage() {}
+func (*GetMailListRequest) ProtoMessage() {}
 
-func (x *MailAttachment) ProtoReflect() protoreflect.Message {
+func (x *GetMailListRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3161,54 +3061,56 @@ func (x *MailAttachment) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MailAttachment.ProtoReflect.Descriptor instead.
-func (*MailAttachment) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetMailListRequest.ProtoReflect.Descriptor instead.
+func (*GetMailListRequest) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *MailAttachment) GetType() int32 {
+func (x *GetMailListRequest) GetPlayerId() string {
 	if x != nil {
-		return x.Type
+		return x.PlayerId
 	}
-	return 0
+	return ""
 }
 
-func (x *MailAttachment) GetItemId() int32 {
+func (x *GetMailListRequest) GetPage() int32 {
 	if x != nil {
-		return x.ItemId
+		return x.Page
 	}
 	return 0
 }
 
-func (x *MailAttachment) GetCount() int32 {
+func (x *GetMailListRequest) GetPageSize() int32 {
 	if x != nil {
-		return x.Count
+		return x.PageSize
 	}
 	return 0
 }
 
-type ReceiveMailAttachmentRequest struct {
+type GetMailListResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID
-	MailId        string                 `protobuf:"bytes,2,opt,name=mail_id,json=mailId,proto3" json:"mail_id,omitempty"`       // 邮件ID
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Mails         []*MailBrief           `protobuf:"bytes,3,rep,name=mails,proto3" json:"mails,omitempty"`  // 邮件简要列表
+	Total         int32                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"` // 总数量
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReceiveMailAttachmentRequest) Reset() {
-	*x = ReceiveMailAttachmentRequest{}
+func (x *GetMailListResponse) Reset() {
+	*x = GetMailListResponse{}
 	mi := &file_game_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReceiveMailAttachmentRequest) String() string {
+func (x *GetMailListResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReceiveMailAttachmentRequest) ProtoMessage() {}
+func (*GetMailListResponse) ProtoMessage() {}
 
-func (x *ReceiveMailAttachmentRequest) ProtoReflect() protoreflect.Message {
+func (x *GetMailListResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3220,48 +3122,65 @@ func (x *ReceiveMailAttachmentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReceiveMailAttachmentRequest.ProtoReflect.Descriptor instead.
-func (*ReceiveMailAttachmentRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetMailListResponse.ProtoReflect.Descriptor instead.
+func (*GetMailListResponse) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *ReceiveMailAttachmentRequest) GetPlayerId() string {
+func (x *GetMailListResponse) GetCode() int32 {
 	if x != nil {
-		return x.PlayerId
+		return x.Code
 	}
-	return ""
+	return 0
 }
 
-func (x *ReceiveMailAttachmentRequest) GetMailId() string {
+func (x *GetMailListResponse) GetMessage() string {
 	if x != nil {
-		return x.MailId
+		return x.Message
 	}
 	return ""
 }
 
-type ReceiveMailAttachmentResponse struct {
+func (x *GetMailListResponse) GetMails() []*MailBrief {
+	if x != nil {
+		return x.Mails
+	}
+	return nil
+}
+
+func (x *GetMailListResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type MailBrief struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Attachments   []*MailAttachment      `protobuf:"bytes,3,rep,name=attachments,proto3" json:"attachments,omitempty"` // 领取的附件
+	MailId        string                 `protobuf:"bytes,1,opt,name=mail_id,json=mailId,proto3" json:"mail_id,omitempty"`                       // 邮件ID
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`                                       // 标题
+	SendTime      int64                  `protobuf:"varint,3,opt,name=send_time,json=sendTime,proto3" json:"send_time,omitempty"`                // 发送时间
+	HasAttachment bool                   `protobuf:"varint,4,opt,name=has_attachment,json=hasAttachment,proto3" json:"has_attachment,omitempty"` // 是否有附件
+	IsRead        bool                   `protobuf:"varint,5,opt,name=is_read,json=isRead,proto3" json:"is_read,omitempty"`                      // 是否已读
+	IsReceived    bool                   `protobuf:"varint,6,opt,name=is_received,json=isReceived,proto3" json:"is_received,omitempty"`          // 是否已领取附件
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReceiveMailAttachmentResponse) Reset() {
-	*x = ReceiveMailAttachmentResponse{}
+func (x *MailBrief) Reset() {
+	*x = MailBrief{}
 	mi := &file_game_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReceiveMailAttachmentResponse) String() string {
+func (x *MailBrief) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReceiveMailAttachmentResponse) ProtoMessage() {}
+func (*MailBrief) ProtoMessage() {}
 
-func (x *ReceiveMailAttachmentResponse) ProtoReflect() protoreflect.Message {
+func (x *MailBrief) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3273,54 +3192,75 @@ func (x *ReceiveMailAttachmentResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReceiveMailAttachmentResponse.ProtoReflect.Descriptor instead.
-func (*ReceiveMailAttachmentResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use MailBrief.ProtoReflect.Descriptor instead.
+func (*MailBrief) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{50}
 }
 
-func (x *ReceiveMailAttachmentResponse) GetCode() int32 {
+func (x *MailBrief) GetMailId() string {
 	if x != nil {
-		return x.Code
+		return x.MailId
 	}
-	return 0
+	return ""
 }
 
-func (x *ReceiveMailAttachmentResponse) GetMessage() string {
+func (x *MailBrief) GetTitle() string {
 	if x != nil {
-		return x.Message
+		return x.Title
 	}
 	return ""
 }
 
-func (x *ReceiveMailAttachmentResponse) GetAttachments() []*MailAttachment {
+func (x *MailBrief) GetSendTime() int64 {
 	if x != nil {
-		return x.Attachments
+		return x.SendTime
 	}
-	return nil
+	return 0
 }
 
-type DeleteMailRequest struct {
+func (x *MailBrief) GetHasAttachment() bool {
+	if x != nil {
+		return x.HasAttachment
+	}
+	return false
+}
+
+func (x *MailBrief) GetIsRead() bool {
+	if x != nil {
+		return x.IsRead
+	}
+	return false
+}
+
+func (x *MailBrief) GetIsReceived() bool {
+	if x != nil {
+		return x.IsReceived
+	}
+	return false
+}
+
+type GetMailDetailRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID
-	MailIds       []string               `protobuf:"bytes,2,rep,name=mail_ids,json=mailIds,proto3" json:"mail_ids,omitempty"`    // 邮件ID列表
+	MailId        string                 `protobuf:"bytes,2,opt,name=mail_id,json=mailId,proto3" json:"mail_id,omitempty"`       // 邮件ID
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteMailRequest) Reset() {
-	*x = DeleteMailRequest{}
+func (x *GetMailDetailRequest) Reset() {
+	*x = GetMailDetailRequest{}
 	mi := &file_game_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteMailRequest) String() string {
+func (x *GetMailDetailRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteMailRequest) ProtoMessage() {}
+func (*GetMailDetailRequest) ProtoMessage() {}
 
-func (x *DeleteMailRequest) ProtoReflect() protoreflect.Message {
+func (x *GetMailDetailRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3332,47 +3272,48 @@ func (x *DeleteMailRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteMailRequest.ProtoReflect.Descriptor instead.
-func (*DeleteMailRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetMailDetailRequest.ProtoReflect.Descriptor instead.
+func (*GetMailDetailRequest) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{51}
 }
 
-func (x *DeleteMailRequest) GetPlayerId() string {
+func (x *GetMailDetailRequest) GetPlayerId() string {
 	if x != nil {
 		return x.PlayerId
 	}
 	return ""
 }
 
-func (x *DeleteMailRequest) GetMailIds() []string {
+func (x *GetMailDetailRequest) GetMailId() string {
 	if x != nil {
-		return x.MailIds
+		return x.MailId
 	}
-	return nil
+	return ""
 }
 
-type GetTaskListRequest struct {
+type GetMailDetailResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`  // 玩家ID
-	TaskType      int32                  `protobuf:"varint,2,opt,name=task_type,json=taskType,proto3" json:"task_type,omitempty"` // 任务类型：0全部，1主线，2支线，3日常，4成就
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Mail          *Mail                  `protobuf:"bytes,3,opt,name=mail,proto3" json:"mail,omitempty"` // 邮件详情
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTaskListRequest) Reset() {
-	*x = GetTaskListRequest{}
+func (x *GetMailDetailResponse) Reset() {
+	*x = GetMailDetailResponse{}
 	mi := &file_game_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTaskListRequest) String() string {
+func (x *GetMailDetailResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTaskListRequest) ProtoMessage() {}
+func (*GetMailDetailResponse) ProtoMessage() {}
 
-func (x *GetTaskListRequest) ProtoReflect() protoreflect.Message {
+func (x *GetMailDetailResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3384,48 +3325,60 @@ func (x *GetTaskListRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTaskListRequest.ProtoReflect.Descriptor instead.
-func (*GetTaskListRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetMailDetailResponse.ProtoReflect.Descriptor instead.
+func (*GetMailDetailResponse) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{52}
 }
 
-func (x *GetTaskListRequest) GetPlayerId() string {
+func (x *GetMailDetailResponse) GetCode() int32 {
 	if x != nil {
-		return x.PlayerId
+		return x.Code
+	}
+	return 0
+}
+
+func (x *GetMailDetailResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
 	}
 	return ""
 }
 
-func (x *GetTaskListRequest) GetTaskType() int32 {
+func (x *GetMailDetailResponse) GetMail() *Mail {
 	if x != nil {
-		return x.TaskType
+		return x.Mail
 	}
-	return 0
+	return nil
 }
 
-type GetTaskListResponse struct {
+type Mail struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Tasks         []*TaskBrief           `protobuf:"bytes,3,rep,name=tasks,proto3" json:"tasks,omitempty"` // 任务简要列表
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                    // 邮件ID
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`                              // 标题
+	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`                          // 内容
+	SendTime      int64                  `protobuf:"varint,4,opt,name=send_time,json=sendTime,proto3" json:"send_time,omitempty"`       // 发送时间
+	ExpireTime    int64                  `protobuf:"varint,5,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"` // 过期时间
+	IsRead        bool                   `protobuf:"varint,6,opt,name=is_read,json=isRead,proto3" json:"is_read,omitempty"`             // 是否已读
+	IsReceived    bool                   `protobuf:"varint,7,opt,name=is_received,json=isReceived,proto3" json:"is_received,omitempty"` // 是否已领取附件
+	Attachments   []*MailAttachment      `protobuf:"bytes,8,rep,name=attachments,proto3" json:"attachments,omitempty"`                  // 附件列表
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTaskListResponse) Reset() {
-	*x = GetTaskListResponse{}
+func (x *Mail) Reset() {
+	*x = Mail{}
 	mi := &file_game_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTaskListResponse) String() string {
+func (x *Mail) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTaskListResponse) ProtoMessage() {}
+func (*Mail) ProtoMessage() {}
 
-func (x *GetTaskListResponse) ProtoReflect() protoreflect.Message {
+func (x *Mail) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3437,58 +3390,90 @@ func (x *GetTaskListResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTaskListResponse.ProtoReflect.Descriptor instead.
-func (*GetTaskListResponse) Descriptor() ([]byte, []int) {
-	return file_game_proto_rawDescGZIP(), []int{53}
+// Deprecated: Use Mail.ProtoReflect.Descriptor instead.
+func (*Mail) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{53}
 }
 
-func (x *GetTaskListResponse) GetCode() int32 {
+func (x *Mail) GetId() string {
 	if x != nil {
-		return x.Code
+		return x.Id
 	}
-	return 0
+	return ""
 }
 
-func (x *GetTaskListResponse) GetMessage() string {
+func (x *Mail) GetTitle() string {
 	if x != nil {
-		return x.Message
+		return x.Title
 	}
 	return ""
 }
 
-func (x *GetTaskListResponse) GetTasks() []*TaskBrief {
+func (x *Mail) GetContent() string {
 	if x != nil {
-		return x.Tasks
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Mail) GetSendTime() int64 {
+	if x != nil {
+		return x.SendTime
+	}
+	return 0
+}
+
+func (x *Mail) GetExpireTime() int64 {
+	if x != nil {
+		return x.ExpireTime
+	}
+	return 0
+}
+
+func (x *Mail) GetIsRead() bool {
+	if x != nil {
+		return x.IsRead
+	}
+	return false
+}
+
+func (x *Mail) GetIsReceived() bool {
+	if x != nil {
+		return x.IsReceived
+	}
+	return false
+}
+
+func (x *Mail) GetAttachments() []*MailAttachment {
+	if x != nil {
+		return x.Attachments
 	}
 	return nil
 }
 
-type TaskBrief struct {
+type MailAttachment struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TaskId        int32                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"` // 任务ID
-	Type          int32                  `protobuf:"varint,2,opt,name=type,proto3" json:"type,omitempty"`                   // 任务类型
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`                    // 任务名称
-	Status        int32                  `protobuf:"varint,4,opt,name=status,proto3" json:"status,omitempty"`               // 任务状态：1未接取，2进行中，3已完成，4已提交
-	Progress      int32                  `protobuf:"varint,5,opt,name=progress,proto3" json:"progress,omitempty"`           // 进度
-	Target        int32                  `protobuf:"varint,6,opt,name=target,proto3" json:"target,omitempty"`               // 目标值
+	Type          int32                  `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`                   // 附件类型：1物品，2金币，3钻石
+	ItemId        int32                  `protobuf:"varint,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"` // 物品ID
+	Count         int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`                 // 数量
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TaskBrief) Reset() {
-	*x = TaskBrief{}
+func (x *MailAttachment) Reset() {
+	*x = MailAttachment{}
 	mi := &file_game_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TaskBrief) String() string {
+func (x *MailAttachment) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TaskBrief) ProtoMessage() {}
+func (*MailAttachment) ProtoMessage() {}
 
-func (x *TaskBrief) ProtoReflect() protoreflect.Message {
+func (x *MailAttachment) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3500,75 +3485,54 @@ func (x *TaskBrief) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TaskBrief.ProtoReflect.Descriptor instead.
-func (*TaskBrief) Descriptor() ([]byte, []int) {
+// Deprecated: Use MailAttachment.ProtoReflect.Descriptor instead.
+func (*MailAttachment) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *TaskBrief) GetTaskId() int32 {
-	if x != nil {
-		return x.TaskId
-	}
-	return 0
-}
-
-func (x *TaskBrief) GetType() int32 {
+func (x *MailAttachment) GetType() int32 {
 	if x != nil {
 		return x.Type
 	}
 	return 0
 }
 
-func (x *TaskBrief) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *TaskBrief) GetStatus() int32 {
-	if x != nil {
-		return x.Status
-	}
-	return 0
-}
-
-func (x *TaskBrief) GetProgress() int32 {
+func (x *MailAttachment) GetItemId() int32 {
 	if x != nil {
-		return x.Progress
+		return x.ItemId
 	}
 	return 0
 }
 
-func (x *TaskBrief) GetTarget() int32 {
+func (x *MailAttachment) GetCount() int32 {
 	if x != nil {
-		return x.Target
+		return x.Count
 	}
 	return 0
 }
 
-type GetTaskDetailRequest struct {
+type ReceiveMailAttachmentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID
-	TaskId        int32                  `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`      // 任务ID
+	MailId        string                 `protobuf:"bytes,2,opt,name=mail_id,json=mailId,proto3" json:"mail_id,omitempty"`       // 邮件ID
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTaskDetailRequest) Reset() {
-	*x = GetTaskDetailRequest{}
+func (x *ReceiveMailAttachmentRequest) Reset() {
+	*x = ReceiveMailAttachmentRequest{}
 	mi := &file_game_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTaskDetailRequest) String() string {
+func (x *ReceiveMailAttachmentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTaskDetailRequest) ProtoMessage() {}
+func (*ReceiveMailAttachmentRequest) ProtoMessage() {}
 
-func (x *GetTaskDetailRequest) ProtoReflect() protoreflect.Message {
+func (x *ReceiveMailAttachmentRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3580,48 +3544,48 @@ func (x *GetTaskDetailRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTaskDetailRequest.ProtoReflect.Descriptor instead.
-func (*GetTaskDetailRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReceiveMailAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*ReceiveMailAttachmentRequest) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{55}
 }
 
-func (x *GetTaskDetailRequest) GetPlayerId() string {
+func (x *ReceiveMailAttachmentRequest) GetPlayerId() string {
 	if x != nil {
 		return x.PlayerId
 	}
 	return ""
 }
 
-func (x *GetTaskDetailRequest) GetTaskId() int32 {
+func (x *ReceiveMailAttachmentRequest) GetMailId() string {
 	if x != nil {
-		return x.TaskId
+		return x.MailId
 	}
-	return 0
+	return ""
 }
 
-type GetTaskDetailResponse struct {
+type ReceiveMailAttachmentResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Task          *TaskDetail            `protobuf:"bytes,3,opt,name=task,proto3" json:"task,omitempty"` // 任务详情
+	Attachments   []*MailAttachment      `protobuf:"bytes,3,rep,name=attachments,proto3" json:"attachments,omitempty"` // 领取的附件
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTaskDetailResponse) Reset() {
-	*x = GetTaskDetailResponse{}
+func (x *ReceiveMailAttachmentResponse) Reset() {
+	*x = ReceiveMailAttachmentResponse{}
 	mi := &file_game_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTaskDetailResponse) String() string {
+func (x *ReceiveMailAttachmentResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTaskDetailResponse) ProtoMessage() {}
+func (*ReceiveMailAttachmentResponse) ProtoMessage() {}
 
-func (x *GetTaskDetailResponse) ProtoReflect() protoreflect.Message {
+func (x *ReceiveMailAttachmentResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3633,62 +3597,53 @@ func (x *GetTaskDetailResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTaskDetailResponse.ProtoReflect.Descriptor instead.
-func (*GetTaskDetailResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReceiveMailAttachmentResponse.ProtoReflect.Descriptor instead.
+func (*ReceiveMailAttachmentResponse) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{56}
 }
 
-func (x *GetTaskDetailResponse) GetCode() int32 {
+func (x *ReceiveMailAttachmentResponse) GetCode() int32 {
 	if x != nil {
 		return x.Code
 	}
 	return 0
 }
 
-func (x *GetTaskDetailResponse) GetMessage() string {
+func (x *ReceiveMailAttachmentResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *GetTaskDetailResponse) GetTask() *TaskDetail {
+func (x *ReceiveMailAttachmentResponse) GetAttachments() []*MailAttachment {
 	if x != nil {
-		return x.Task
+		return x.Attachments
 	}
 	return nil
 }
 
-type TaskDetail struct {
+type ReceiveAllMailAttachmentsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`                                                                                       // 任务ID
-	Type          int32                  `protobuf:"varint,2,opt,name=type,proto3" json:"type,omitempty"`                                                                                   // 任务类型
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`                                                                                    // 任务名称
-	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`                                                                      // 任务描述
-	Status        int32                  `protobuf:"varint,5,opt,name=status,proto3" json:"status,omitempty"`                                                                               // 任务状态
-	Targets       map[string]int32       `protobuf:"bytes,6,rep,name=targets,proto3" json:"targets,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`   // 目标条件
-	Progress      map[string]int32       `protobuf:"bytes,7,rep,name=progress,proto3" json:"progress,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // 当前进度
-	Rewards       []*TaskReward          `protobuf:"bytes,8,rep,name=rewards,proto3" json:"rewards,omitempty"`                                                                              // 奖励列表
-	AcceptLevel   int32                  `protobuf:"varint,9,opt,name=accept_level,json=acceptLevel,proto3" json:"accept_level,omitempty"`                                                  // 接取等级
-	NextTaskId    int32                  `protobuf:"varint,10,opt,name=next_task_id,json=nextTaskId,proto3" json:"next_task_id,omitempty"`                                                  // 后续任务ID
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TaskDetail) Reset() {
-	*x = TaskDetail{}
+func (x *ReceiveAllMailAttachmentsRequest) Reset() {
+	*x = ReceiveAllMailAttachmentsRequest{}
 	mi := &file_game_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TaskDetail) String() string {
+func (x *ReceiveAllMailAttachmentsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TaskDetail) ProtoMessage() {}
+func (*ReceiveAllMailAttachmentsRequest) ProtoMessage() {}
 
-func (x *TaskDetail) ProtoReflect() protoreflect.Message {
+func (x *ReceiveAllMailAttachmentsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3700,105 +3655,109 @@ func (x *TaskDetail) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TaskDetail.ProtoReflect.Descriptor instead.
-func (*TaskDetail) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReceiveAllMailAttachmentsRequest.ProtoReflect.Descriptor instead.
+func (*ReceiveAllMailAttachmentsRequest) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{57}
 }
 
-func (x *TaskDetail) GetId() int32 {
+func (x *ReceiveAllMailAttachmentsRequest) GetPlayerId() string {
 	if x != nil {
-		return x.Id
+		return x.PlayerId
 	}
-	return 0
+	return ""
 }
 
-func (x *TaskDetail) GetType() int32 {
-	if x != nil {
-		return x.Type
-	}
-	return 0
+type ReceiveAllMailAttachmentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	MailIds       []string               `protobuf:"bytes,3,rep,name=mail_ids,json=mailIds,proto3" json:"mail_ids,omitempty"` // 已领取的邮件ID列表
+	Attachments   []*MailAttachment      `protobuf:"bytes,4,rep,name=attachments,proto3" json:"attachments,omitempty"`        // 领取的附件汇总
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TaskDetail) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
+func (x *ReceiveAllMailAttachmentsResponse) Reset() {
+	*x = ReceiveAllMailAttachmentsResponse{}
+	mi := &file_game_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *TaskDetail) GetDescription() string {
-	if x != nil {
-		return x.Description
-	}
-	return ""
+func (x *ReceiveAllMailAttachmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *TaskDetail) GetStatus() int32 {
+func (*ReceiveAllMailAttachmentsResponse) ProtoMessage() {}
+
+func (x *ReceiveAllMailAttachmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[58]
 	if x != nil {
-		return x.Status
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *TaskDetail) GetTargets() map[string]int32 {
-	if x != nil {
-		return x.Targets
-	}
-	return nil
+// Deprecated: Use ReceiveAllMailAttachmentsResponse.ProtoReflect.Descriptor instead.
+func (*ReceiveAllMailAttachmentsResponse) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{58}
 }
 
-func (x *TaskDetail) GetProgress() map[string]int32 {
+func (x *ReceiveAllMailAttachmentsResponse) GetCode() int32 {
 	if x != nil {
-		return x.Progress
+		return x.Code
 	}
-	return nil
+	return 0
 }
 
-func (x *TaskDetail) GetRewards() []*TaskReward {
+func (x *ReceiveAllMailAttachmentsResponse) GetMessage() string {
 	if x != nil {
-		return x.Rewards
+		return x.Message
 	}
-	return nil
+	return ""
 }
 
-func (x *TaskDetail) GetAcceptLevel() int32 {
+func (x *ReceiveAllMailAttachmentsResponse) GetMailIds() []string {
 	if x != nil {
-		return x.AcceptLevel
+		return x.MailIds
 	}
-	return 0
+	return nil
 }
 
-func (x *TaskDetail) GetNextTaskId() int32 {
+func (x *ReceiveAllMailAttachmentsResponse) GetAttachments() []*MailAttachment {
 	if x != nil {
-		return x.NextTaskId
+		return x.Attachments
 	}
-	return 0
+	return nil
 }
 
-type TaskReward struct {
+type DeleteMailRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Type          int32                  `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`                   // 奖励类型：1经验，2金币，3物品
-	ItemId        int32                  `protobuf:"varint,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"` // 物品ID
-	Count         int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`                 // 数量
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID
+	MailIds       []string               `protobuf:"bytes,2,rep,name=mail_ids,json=mailIds,proto3" json:"mail_ids,omitempty"`    // 邮件ID列表
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TaskReward) Reset() {
-	*x = TaskReward{}
-	mi := &file_game_proto_msgTypes[58]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+func (x *DeleteMailRequest) Reset() {
+	*x = DeleteMailRequest{}
+	mi := &file_game_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TaskReward) String() string {
+func (x *DeleteMailRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TaskReward) ProtoMessage() {}
+func (*DeleteMailRequest) ProtoMessage() {}
 
-func (x *TaskReward) ProtoReflect() protoreflect.Message {
-	mi := &file_game_proto_msgTypes[58]
+func (x *DeleteMailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3809,55 +3768,52 @@ func (x *TaskReward) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TaskReward.ProtoReflect.Descriptor instead.
-func (*TaskReward) Descriptor() ([]byte, []int) {
-	return file_game_proto_rawDescGZIP(), []int{58}
-}
-
-func (x *TaskReward) GetType() int32 {
-	if x != nil {
-		return x.Type
-	}
-	return 0
+// Deprecated: Use DeleteMailRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMailRequest) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{59}
 }
 
-func (x *TaskReward) GetItemId() int32 {
+func (x *DeleteMailRequest) GetPlayerId() string {
 	if x != nil {
-		return x.ItemId
+		return x.PlayerId
 	}
-	return 0
+	return ""
 }
 
-func (x *TaskReward) GetCount() int32 {
+func (x *DeleteMailRequest) GetMailIds() []string {
 	if x != nil {
-		return x.Count
+		return x.MailIds
 	}
-	return 0
+	return nil
 }
 
-type AcceptTaskRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID
-	TaskId        int32                  `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`      // 任务ID
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type SendMailRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PlayerIds      []string               `protobuf:"bytes,1,rep,name=player_ids,json=playerIds,proto3" json:"player_ids,omitempty"`                // 收件玩家ID列表
+	Title          string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`                                         // 标题
+	Content        string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`                                     // 内容
+	Attachments    []*MailAttachment      `protobuf:"bytes,4,rep,name=attachments,proto3" json:"attachments,omitempty"`                             // 附件列表
+	ExpireTime     int64                  `protobuf:"varint,5,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`            // 过期时间，0表示使用默认有效期
+	IdempotencyKey string                 `protobuf:"bytes,6,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"` // 幂等键，不为空时邮件ID由幂等键和玩家ID生成，重复发送不会产生重复邮件
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *AcceptTaskRequest) Reset() {
-	*x = AcceptTaskRequest{}
-	mi := &file_game_proto_msgTypes[59]
+func (x *SendMailRequest) Reset() {
+	*x = SendMailRequest{}
+	mi := &file_game_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AcceptTaskRequest) String() string {
+func (x *SendMailRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AcceptTaskRequest) ProtoMessage() {}
+func (*SendMailRequest) ProtoMessage() {}
 
-func (x *AcceptTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_game_proto_msgTypes[59]
+func (x *SendMailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3868,100 +3824,76 @@ func (x *AcceptTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AcceptTaskRequest.ProtoReflect.Descriptor instead.
-func (*AcceptTaskRequest) Descriptor() ([]byte, []int) {
-	return file_game_proto_rawDescGZIP(), []int{59}
+// Deprecated: Use SendMailRequest.ProtoReflect.Descriptor instead.
+func (*SendMailRequest) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{60}
 }
 
-func (x *AcceptTaskRequest) GetPlayerId() string {
+func (x *SendMailRequest) GetPlayerIds() []string {
 	if x != nil {
-		return x.PlayerId
+		return x.PlayerIds
 	}
-	return ""
+	return nil
 }
 
-func (x *AcceptTaskRequest) GetTaskId() int32 {
+func (x *SendMailRequest) GetTitle() string {
 	if x != nil {
-		return x.TaskId
+		return x.Title
 	}
-	return 0
-}
-
-type SubmitTaskRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID
-	TaskId        int32                  `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`      // 任务ID
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *SubmitTaskRequest) Reset() {
-	*x = SubmitTaskRequest{}
-	mi := &file_game_proto_msgTypes[60]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *SubmitTaskRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+	return ""
 }
 
-func (*SubmitTaskRequest) ProtoMessage() {}
-
-func (x *SubmitTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_game_proto_msgTypes[60]
+func (x *SendMailRequest) GetContent() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Content
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use SubmitTaskRequest.ProtoReflect.Descriptor instead.
-func (*SubmitTaskRequest) Descriptor() ([]byte, []int) {
-	return file_game_proto_rawDescGZIP(), []int{60}
+func (x *SendMailRequest) GetAttachments() []*MailAttachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
 }
 
-func (x *SubmitTaskRequest) GetPlayerId() string {
+func (x *SendMailRequest) GetExpireTime() int64 {
 	if x != nil {
-		return x.PlayerId
+		return x.ExpireTime
 	}
-	return ""
+	return 0
 }
 
-func (x *SubmitTaskRequest) GetTaskId() int32 {
+func (x *SendMailRequest) GetIdempotencyKey() string {
 	if x != nil {
-		return x.TaskId
+		return x.IdempotencyKey
 	}
-	return 0
+	return ""
 }
 
-type SubmitTaskResponse struct {
+type SendMailResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Rewards       []*TaskReward          `protobuf:"bytes,3,rep,name=rewards,proto3" json:"rewards,omitempty"` // 获得的奖励
+	MailIds       []string               `protobuf:"bytes,3,rep,name=mail_ids,json=mailIds,proto3" json:"mail_ids,omitempty"` // 邮件ID列表，全服邮件为全服邮件ID
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SubmitTaskResponse) Reset() {
-	*x = SubmitTaskResponse{}
+func (x *SendMailResponse) Reset() {
+	*x = SendMailResponse{}
 	mi := &file_game_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SubmitTaskResponse) String() string {
+func (x *SendMailResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubmitTaskResponse) ProtoMessage() {}
+func (*SendMailResponse) ProtoMessage() {}
 
-func (x *SubmitTaskResponse) ProtoReflect() protoreflect.Message {
+func (x *SendMailResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3973,54 +3905,60 @@ func (x *SubmitTaskResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubmitTaskResponse.ProtoReflect.Descriptor instead.
-func (*SubmitTaskResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SendMailResponse.ProtoReflect.Descriptor instead.
+func (*SendMailResponse) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{61}
 }
 
-func (x *SubmitTaskResponse) GetCode() int32 {
+func (x *SendMailResponse) GetCode() int32 {
 	if x != nil {
 		return x.Code
 	}
 	return 0
 }
 
-func (x *SubmitTaskResponse) GetMessage() string {
+func (x *SendMailResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *SubmitTaskResponse) GetRewards() []*TaskReward {
+func (x *SendMailResponse) GetMailIds() []string {
 	if x != nil {
-		return x.Rewards
+		return x.MailIds
 	}
 	return nil
 }
 
-type GiveUpTaskRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID
-	TaskId        int32                  `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`      // 任务ID
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type SendBroadcastMailRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Title          string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`                                          // 标题
+	Content        string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`                                      // 内容
+	Attachments    []*MailAttachment      `protobuf:"bytes,3,rep,name=attachments,proto3" json:"attachments,omitempty"`                              // 附件列表
+	ExpireTime     int64                  `protobuf:"varint,4,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`             // 过期时间，0表示使用默认有效期
+	MinLevel       int32                  `protobuf:"varint,5,opt,name=min_level,json=minLevel,proto3" json:"min_level,omitempty"`                   // 最低等级，0表示不限
+	MaxLevel       int32                  `protobuf:"varint,6,opt,name=max_level,json=maxLevel,proto3" json:"max_level,omitempty"`                   // 最高等级，0表示不限
+	RegisterAfter  int64                  `protobuf:"varint,7,opt,name=register_after,json=registerAfter,proto3" json:"register_after,omitempty"`    // 注册时间不早于，0表示不限
+	RegisterBefore int64                  `protobuf:"varint,8,opt,name=register_before,json=registerBefore,proto3" json:"register_before,omitempty"` // 注册时间不晚于，0表示不限
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *GiveUpTaskRequest) Reset() {
-	*x = GiveUpTaskRequest{}
+func (x *SendBroadcastMailRequest) Reset() {
+	*x = SendBroadcastMailRequest{}
 	mi := &file_game_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GiveUpTaskRequest) String() string {
+func (x *SendBroadcastMailRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GiveUpTaskRequest) ProtoMessage() {}
+func (*SendBroadcastMailRequest) ProtoMessage() {}
 
-func (x *GiveUpTaskRequest) ProtoReflect() protoreflect.Message {
+func (x *SendBroadcastMailRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -4032,47 +3970,88 @@ func (x *GiveUpTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GiveUpTaskRequest.ProtoReflect.Descriptor instead.
-func (*GiveUpTaskRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SendBroadcastMailRequest.ProtoReflect.Descriptor instead.
+func (*SendBroadcastMailRequest) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{62}
 }
 
-func (x *GiveUpTaskRequest) GetPlayerId() string {
+func (x *SendBroadcastMailRequest) GetTitle() string {
 	if x != nil {
-		return x.PlayerId
+		return x.Title
 	}
 	return ""
 }
 
-func (x *GiveUpTaskRequest) GetTaskId() int32 {
+func (x *SendBroadcastMailRequest) GetContent() string {
 	if x != nil {
-		return x.TaskId
+		return x.Content
+	}
+	return ""
+}
+
+func (x *SendBroadcastMailRequest) GetAttachments() []*MailAttachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+func (x *SendBroadcastMailRequest) GetExpireTime() int64 {
+	if x != nil {
+		return x.ExpireTime
 	}
 	return 0
 }
 
-type GetShopListRequest struct {
+func (x *SendBroadcastMailRequest) GetMinLevel() int32 {
+	if x != nil {
+		return x.MinLevel
+	}
+	return 0
+}
+
+func (x *SendBroadcastMailRequest) GetMaxLevel() int32 {
+	if x != nil {
+		return x.MaxLevel
+	}
+	return 0
+}
+
+func (x *SendBroadcastMailRequest) GetRegisterAfter() int64 {
+	if x != nil {
+		return x.RegisterAfter
+	}
+	return 0
+}
+
+func (x *SendBroadcastMailRequest) GetRegisterBefore() int64 {
+	if x != nil {
+		return x.RegisterBefore
+	}
+	return 0
+}
+
+type GetMailBadgeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`  // 玩家ID
-	ShopType      int32                  `protobuf:"varint,2,opt,name=shop_type,json=shopType,proto3" json:"shop_type,omitempty"` // 商店类型：1普通，2限时，3活动
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetShopListRequest) Reset() {
-	*x = GetShopListRequest{}
+func (x *GetMailBadgeRequest) Reset() {
+	*x = GetMailBadgeRequest{}
 	mi := &file_game_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetShopListRequest) String() string {
+func (x *GetMailBadgeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetShopListRequest) ProtoMessage() {}
+func (*GetMailBadgeRequest) ProtoMessage() {}
 
-func (x *GetShopListRequest) ProtoReflect() protoreflect.Message {
+func (x *GetMailBadgeRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -4084,48 +4063,42 @@ func (x *GetShopListRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetShopListRequest.ProtoReflect.Descriptor instead.
-func (*GetShopListRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetMailBadgeRequest.ProtoReflect.Descriptor instead.
+func (*GetMailBadgeRequest) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{63}
 }
 
-func (x *GetShopListRequest) GetPlayerId() string {
+func (x *GetMailBadgeRequest) GetPlayerId() string {
 	if x != nil {
 		return x.PlayerId
 	}
 	return ""
 }
 
-func (x *GetShopListRequest) GetShopType() int32 {
-	if x != nil {
-		return x.ShopType
-	}
-	return 0
-}
-
-type GetShopListResponse struct {
+type GetMailBadgeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Items         []*ShopItem            `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"` // 商店物品列表
+	Unread        int32                  `protobuf:"varint,3,opt,name=unread,proto3" json:"unread,omitempty"`       // 未读邮件数量
+	Unclaimed     int32                  `protobuf:"varint,4,opt,name=unclaimed,proto3" json:"unclaimed,omitempty"` // 未领取附件的邮件数量
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetShopListResponse) Reset() {
-	*x = GetShopListResponse{}
+func (x *GetMailBadgeResponse) Reset() {
+	*x = GetMailBadgeResponse{}
 	mi := &file_game_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetShopListResponse) String() string {
+func (x *GetMailBadgeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetShopListResponse) ProtoMessage() {}
+func (*GetMailBadgeResponse) ProtoMessage() {}
 
-func (x *GetShopListResponse) ProtoReflect() protoreflect.Message {
+func (x *GetMailBadgeResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -4137,62 +4110,61 @@ func (x *GetShopListResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetShopListResponse.ProtoReflect.Descriptor instead.
-func (*GetShopListResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetMailBadgeResponse.ProtoReflect.Descriptor instead.
+func (*GetMailBadgeResponse) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{64}
 }
 
-func (x *GetShopListResponse) GetCode() int32 {
+func (x *GetMailBadgeResponse) GetCode() int32 {
 	if x != nil {
 		return x.Code
 	}
 	return 0
 }
 
-func (x *GetShopListResponse) GetMessage() string {
+func (x *GetMailBadgeResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *GetShopListResponse) GetItems() []*ShopItem {
+func (x *GetMailBadgeResponse) GetUnread() int32 {
 	if x != nil {
-		return x.Items
+		return x.Unread
 	}
-	return nil
+	return 0
 }
 
-type ShopItem struct {
+func (x *GetMailBadgeResponse) GetUnclaimed() int32 {
+	if x != nil {
+		return x.Unclaimed
+	}
+	return 0
+}
+
+// 新邮件通知，客户端收到后调用GetMailBadge刷新红点
+type NewMailNotify struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ItemId        int32                  `protobuf:"varint,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`                      // 物品ID
-	OriginalPrice int32                  `protobuf:"varint,2,opt,name=original_price,json=originalPrice,proto3" json:"original_price,omitempty"` // 原价
-	CurrentPrice  int32                  `protobuf:"varint,3,opt,name=current_price,json=currentPrice,proto3" json:"current_price,omitempty"`    // 当前价格
-	CurrencyType  int32                  `protobuf:"varint,4,opt,name=currency_type,json=currencyType,proto3" json:"currency_type,omitempty"`    // 货币类型：1金币，2钻石
-	Stock         int32                  `protobuf:"varint,5,opt,name=stock,proto3" json:"stock,omitempty"`                                      // 库存
-	MaxBuyCount   int32                  `protobuf:"varint,6,opt,name=max_buy_count,json=maxBuyCount,proto3" json:"max_buy_count,omitempty"`     // 最大购买数量
-	BoughtCount   int32                  `protobuf:"varint,7,opt,name=bought_count,json=boughtCount,proto3" json:"bought_count,omitempty"`       // 已购买数量
-	ExpireTime    int64                  `protobuf:"varint,8,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`          // 过期时间
-	IsDiscount    bool                   `protobuf:"varint,9,opt,name=is_discount,json=isDiscount,proto3" json:"is_discount,omitempty"`          // 是否折扣
-	DiscountRate  float32                `protobuf:"fixed32,10,opt,name=discount_rate,json=discountRate,proto3" json:"discount_rate,omitempty"`  // 折扣率
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"` // 邮件标题
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ShopItem) Reset() {
-	*x = ShopItem{}
+func (x *NewMailNotify) Reset() {
+	*x = NewMailNotify{}
 	mi := &file_game_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ShopItem) String() string {
+func (x *NewMailNotify) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ShopItem) ProtoMessage() {}
+func (*NewMailNotify) ProtoMessage() {}
 
-func (x *ShopItem) ProtoReflect() protoreflect.Message {
+func (x *NewMailNotify) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -4204,105 +4176,94 @@ func (x *ShopItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ShopItem.ProtoReflect.Descriptor instead.
-func (*ShopItem) Descriptor() ([]byte, []int) {
+// Deprecated: Use NewMailNotify.ProtoReflect.Descriptor instead.
+func (*NewMailNotify) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{65}
 }
 
-func (x *ShopItem) GetItemId() int32 {
+func (x *NewMailNotify) GetTitle() string {
 	if x != nil {
-		return x.ItemId
+		return x.Title
 	}
-	return 0
+	return ""
 }
 
-func (x *ShopItem) GetOriginalPrice() int32 {
-	if x != nil {
-		return x.OriginalPrice
-	}
-	return 0
+type GetTaskListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`  // 玩家ID
+	TaskType      int32                  `protobuf:"varint,2,opt,name=task_type,json=taskType,proto3" json:"task_type,omitempty"` // 任务类型：0全部，1主线，2支线，3日常，4成就
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ShopItem) GetCurrentPrice() int32 {
-	if x != nil {
-		return x.CurrentPrice
-	}
-	return 0
+func (x *GetTaskListRequest) Reset() {
+	*x = GetTaskListRequest{}
+	mi := &file_game_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *ShopItem) GetCurrencyType() int32 {
-	if x != nil {
-		return x.CurrencyType
-	}
-	return 0
-}
-
-func (x *ShopItem) GetStock() int32 {
-	if x != nil {
-		return x.Stock
-	}
-	return 0
+func (x *GetTaskListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *ShopItem) GetMaxBuyCount() int32 {
-	if x != nil {
-		return x.MaxBuyCount
-	}
-	return 0
-}
+func (*GetTaskListRequest) ProtoMessage() {}
 
-func (x *ShopItem) GetBoughtCount() int32 {
+func (x *GetTaskListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[66]
 	if x != nil {
-		return x.BoughtCount
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *ShopItem) GetExpireTime() int64 {
-	if x != nil {
-		return x.ExpireTime
-	}
-	return 0
+// Deprecated: Use GetTaskListRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskListRequest) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{66}
 }
 
-func (x *ShopItem) GetIsDiscount() bool {
+func (x *GetTaskListRequest) GetPlayerId() string {
 	if x != nil {
-		return x.IsDiscount
+		return x.PlayerId
 	}
-	return false
+	return ""
 }
 
-func (x *ShopItem) GetDiscountRate() float32 {
+func (x *GetTaskListRequest) GetTaskType() int32 {
 	if x != nil {
-		return x.DiscountRate
+		return x.TaskType
 	}
 	return 0
 }
 
-type BuyItemRequest struct {
+type GetTaskListResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID
-	ItemId        int32                  `protobuf:"varint,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`      // 物品ID
-	Count         int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`                      // 购买数量
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Tasks         []*TaskBrief           `protobuf:"bytes,3,rep,name=tasks,proto3" json:"tasks,omitempty"` // 任务简要列表
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BuyItemRequest) Reset() {
-	*x = BuyItemRequest{}
-	mi := &file_game_proto_msgTypes[66]
+func (x *GetTaskListResponse) Reset() {
+	*x = GetTaskListResponse{}
+	mi := &file_game_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BuyItemRequest) String() string {
+func (x *GetTaskListResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BuyItemRequest) ProtoMessage() {}
+func (*GetTaskListResponse) ProtoMessage() {}
 
-func (x *BuyItemRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_game_proto_msgTypes[66]
+func (x *GetTaskListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4313,58 +4274,59 @@ func (x *BuyItemRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BuyItemRequest.ProtoReflect.Descriptor instead.
-func (*BuyItemRequest) Descriptor() ([]byte, []int) {
-	return file_game_proto_rawDescGZIP(), []int{66}
+// Deprecated: Use GetTaskListResponse.ProtoReflect.Descriptor instead.
+func (*GetTaskListResponse) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{67}
 }
 
-func (x *BuyItemRequest) GetPlayerId() string {
+func (x *GetTaskListResponse) GetCode() int32 {
 	if x != nil {
-		return x.PlayerId
+		return x.Code
 	}
-	return ""
+	return 0
 }
 
-func (x *BuyItemRequest) GetItemId() int32 {
+func (x *GetTaskListResponse) GetMessage() string {
 	if x != nil {
-		return x.ItemId
+		return x.Message
 	}
-	return 0
+	return ""
 }
 
-func (x *BuyItemRequest) GetCount() int32 {
+func (x *GetTaskListResponse) GetTasks() []*TaskBrief {
 	if x != nil {
-		return x.Count
+		return x.Tasks
 	}
-	return 0
+	return nil
 }
 
-type BuyItemResponse struct {
+type TaskBrief struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	BoughtItems   []*BagItem             `protobuf:"bytes,3,rep,name=bought_items,json=boughtItems,proto3" json:"bought_items,omitempty"`        // 购买的物品
-	SpentCurrency int32                  `protobuf:"varint,4,opt,name=spent_currency,json=spentCurrency,proto3" json:"spent_currency,omitempty"` // 花费的货币
-	CurrencyType  int32                  `protobuf:"varint,5,opt,name=currency_type,json=currencyType,proto3" json:"currency_type,omitempty"`    // 货币类型
+	TaskId        int32                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"` // 任务ID
+	Type          int32                  `protobuf:"varint,2,opt,name=type,proto3" json:"type,omitempty"`                   // 任务类型
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`                    // 任务名称
+	Status        int32                  `protobuf:"varint,4,opt,name=status,proto3" json:"status,omitempty"`               // 任务状态：1未接取，2进行中，3已完成，4已提交
+	Progress      int32                  `protobuf:"varint,5,opt,name=progress,proto3" json:"progress,omitempty"`           // 进度
+	Target        int32                  `protobuf:"varint,6,opt,name=target,proto3" json:"target,omitempty"`               // 目标值
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BuyItemResponse) Reset() {
-	*x = BuyItemResponse{}
-	mi := &file_game_proto_msgTypes[67]
+func (x *TaskBrief) Reset() {
+	*x = TaskBrief{}
+	mi := &file_game_proto_msgTypes[68]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BuyItemResponse) String() string {
+func (x *TaskBrief) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BuyItemResponse) ProtoMessage() {}
+func (*TaskBrief) ProtoMessage() {}
 
-func (x *BuyItemResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_game_proto_msgTypes[67]
+func (x *TaskBrief) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[68]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4375,68 +4337,76 @@ func (x *BuyItemResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BuyItemResponse.ProtoReflect.Descriptor instead.
-func (*BuyItemResponse) Descriptor() ([]byte, []int) {
-	return file_game_proto_rawDescGZIP(), []int{67}
+// Deprecated: Use TaskBrief.ProtoReflect.Descriptor instead.
+func (*TaskBrief) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{68}
 }
 
-func (x *BuyItemResponse) GetCode() int32 {
+func (x *TaskBrief) GetTaskId() int32 {
 	if x != nil {
-		return x.Code
+		return x.TaskId
 	}
 	return 0
 }
 
-func (x *BuyItemResponse) GetMessage() string {
+func (x *TaskBrief) GetType() int32 {
 	if x != nil {
-		return x.Message
+		return x.Type
+	}
+	return 0
+}
+
+func (x *TaskBrief) GetName() string {
+	if x != nil {
+		return x.Name
 	}
 	return ""
 }
 
-func (x *BuyItemResponse) GetBoughtItems() []*BagItem {
+func (x *TaskBrief) GetStatus() int32 {
 	if x != nil {
-		return x.BoughtItems
+		return x.Status
 	}
-	return nil
+	return 0
 }
 
-func (x *BuyItemResponse) GetSpentCurrency() int32 {
+func (x *TaskBrief) GetProgress() int32 {
 	if x != nil {
-		return x.SpentCurrency
+		return x.Progress
 	}
 	return 0
 }
 
-func (x *BuyItemResponse) GetCurrencyType() int32 {
+func (x *TaskBrief) GetTarget() int32 {
 	if x != nil {
-		return x.CurrencyType
+		return x.Target
 	}
 	return 0
 }
 
-type GetDiscountInfoRequest struct {
+type GetTaskDetailRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID
+	TaskId        int32                  `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`      // 任务ID
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetDiscountInfoRequest) Reset() {
-	*x = GetDiscountInfoRequest{}
-	mi := &file_game_proto_msgTypes[68]
+func (x *GetTaskDetailRequest) Reset() {
+	*x = GetTaskDetailRequest{}
+	mi := &file_game_proto_msgTypes[69]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetDiscountInfoRequest) String() string {
+func (x *GetTaskDetailRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDiscountInfoRequest) ProtoMessage() {}
+func (*GetTaskDetailRequest) ProtoMessage() {}
 
-func (x *GetDiscountInfoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_game_proto_msgTypes[68]
+func (x *GetTaskDetailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[69]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4447,42 +4417,49 @@ func (x *GetDiscountInfoRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDiscountInfoRequest.ProtoReflect.Descriptor instead.
-func (*GetDiscountInfoRequest) Descriptor() ([]byte, []int) {
-	return file_game_proto_rawDescGZIP(), []int{68}
+// Deprecated: Use GetTaskDetailRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskDetailRequest) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{69}
 }
 
-func (x *GetDiscountInfoRequest) GetPlayerId() string {
+func (x *GetTaskDetailRequest) GetPlayerId() string {
 	if x != nil {
 		return x.PlayerId
 	}
 	return ""
 }
 
-type GetDiscountInfoResponse struct {
+func (x *GetTaskDetailRequest) GetTaskId() int32 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+type GetTaskDetailResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Discounts     []*DiscountInfo        `protobuf:"bytes,3,rep,name=discounts,proto3" json:"discounts,omitempty"` // 折扣信息列表
+	Task          *TaskDetail            `protobuf:"bytes,3,opt,name=task,proto3" json:"task,omitempty"` // 任务详情
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetDiscountInfoResponse) Reset() {
-	*x = GetDiscountInfoResponse{}
-	mi := &file_game_proto_msgTypes[69]
+func (x *GetTaskDetailResponse) Reset() {
+	*x = GetTaskDetailResponse{}
+	mi := &file_game_proto_msgTypes[70]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetDiscountInfoResponse) String() string {
+func (x *GetTaskDetailResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDiscountInfoResponse) ProtoMessage() {}
+func (*GetTaskDetailResponse) ProtoMessage() {}
 
-func (x *GetDiscountInfoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_game_proto_msgTypes[69]
+func (x *GetTaskDetailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[70]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4493,57 +4470,63 @@ func (x *GetDiscountInfoResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDiscountInfoResponse.ProtoReflect.Descriptor instead.
-func (*GetDiscountInfoResponse) Descriptor() ([]byte, []int) {
-	return file_game_proto_rawDescGZIP(), []int{69}
+// Deprecated: Use GetTaskDetailResponse.ProtoReflect.Descriptor instead.
+func (*GetTaskDetailResponse) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{70}
 }
 
-func (x *GetDiscountInfoResponse) GetCode() int32 {
+func (x *GetTaskDetailResponse) GetCode() int32 {
 	if x != nil {
 		return x.Code
 	}
 	return 0
 }
 
-func (x *GetDiscountInfoResponse) GetMessage() string {
+func (x *GetTaskDetailResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *GetDiscountInfoResponse) GetDiscounts() []*DiscountInfo {
+func (x *GetTaskDetailResponse) GetTask() *TaskDetail {
 	if x != nil {
-		return x.Discounts
+		return x.Task
 	}
 	return nil
 }
 
-type DiscountInfo struct {
+type TaskDetail struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ShopType      int32                  `protobuf:"varint,1,opt,name=shop_type,json=shopType,proto3" json:"shop_type,omitempty"`              // 商店类型
-	DiscountRate  float32                `protobuf:"fixed32,2,opt,name=discount_rate,json=discountRate,proto3" json:"discount_rate,omitempty"` // 折扣率
-	StartTime     int64                  `protobuf:"varint,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`           // 开始时间
-	EndTime       int64                  `protobuf:"varint,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`                 // 结束时间
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`                                                                                       // 任务ID
+	Type          int32                  `protobuf:"varint,2,opt,name=type,proto3" json:"type,omitempty"`                                                                                   // 任务类型
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`                                                                                    // 任务名称
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`                                                                      // 任务描述
+	Status        int32                  `protobuf:"varint,5,opt,name=status,proto3" json:"status,omitempty"`                                                                               // 任务状态
+	Targets       map[string]int32       `protobuf:"bytes,6,rep,name=targets,proto3" json:"targets,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`   // 目标条件
+	Progress      map[string]int32       `protobuf:"bytes,7,rep,name=progress,proto3" json:"progress,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // 当前进度
+	Rewards       []*TaskReward          `protobuf:"bytes,8,rep,name=rewards,proto3" json:"rewards,omitempty"`                                                                              // 奖励列表
+	AcceptLevel   int32                  `protobuf:"varint,9,opt,name=accept_level,json=acceptLevel,proto3" json:"accept_level,omitempty"`                                                  // 接取等级
+	NextTaskId    int32                  `protobuf:"varint,10,opt,name=next_task_id,json=nextTaskId,proto3" json:"next_task_id,omitempty"`                                                  // 后续任务ID
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DiscountInfo) Reset() {
-	*x = DiscountInfo{}
-	mi := &file_game_proto_msgTypes[70]
+func (x *TaskDetail) Reset() {
+	*x = TaskDetail{}
+	mi := &file_game_proto_msgTypes[71]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DiscountInfo) String() string {
+func (x *TaskDetail) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DiscountInfo) ProtoMessage() {}
+func (*TaskDetail) ProtoMessage() {}
 
-func (x *DiscountInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_game_proto_msgTypes[70]
+func (x *TaskDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[71]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4554,124 +4537,104 @@ func (x *DiscountInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DiscountInfo.ProtoReflect.Descriptor instead.
-func (*DiscountInfo) Descriptor() ([]byte, []int) {
-	return file_game_proto_rawDescGZIP(), []int{70}
+// Deprecated: Use TaskDetail.ProtoReflect.Descriptor instead.
+func (*TaskDetail) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{71}
 }
 
-func (x *DiscountInfo) GetShopType() int32 {
+func (x *TaskDetail) GetId() int32 {
 	if x != nil {
-		return x.ShopType
+		return x.Id
 	}
 	return 0
 }
 
-func (x *DiscountInfo) GetDiscountRate() float32 {
+func (x *TaskDetail) GetType() int32 {
 	if x != nil {
-		return x.DiscountRate
+		return x.Type
 	}
 	return 0
 }
 
-func (x *DiscountInfo) GetStartTime() int64 {
+func (x *TaskDetail) GetName() string {
 	if x != nil {
-		return x.StartTime
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-func (x *DiscountInfo) GetEndTime() int64 {
+func (x *TaskDetail) GetDescription() string {
 	if x != nil {
-		return x.EndTime
+		return x.Description
 	}
-	return 0
-}
-
-type GetPlayerRecordsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`  // 玩家ID
-	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`                         // 页码
-	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // 每页数量
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetPlayerRecordsRequest) Reset() {
-	*x = GetPlayerRecordsRequest{}
-	mi := &file_game_proto_msgTypes[71]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+	return ""
 }
 
-func (x *GetPlayerRecordsRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *TaskDetail) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
 }
 
-func (*GetPlayerRecordsRequest) ProtoMessage() {}
-
-func (x *GetPlayerRecordsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_game_proto_msgTypes[71]
+func (x *TaskDetail) GetTargets() map[string]int32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Targets
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use GetPlayerRecordsRequest.ProtoReflect.Descriptor instead.
-func (*GetPlayerRecordsRequest) Descriptor() ([]byte, []int) {
-	return file_game_proto_rawDescGZIP(), []int{71}
+func (x *TaskDetail) GetProgress() map[string]int32 {
+	if x != nil {
+		return x.Progress
+	}
+	return nil
 }
 
-func (x *GetPlayerRecordsRequest) GetPlayerId() string {
+func (x *TaskDetail) GetRewards() []*TaskReward {
 	if x != nil {
-		return x.PlayerId
+		return x.Rewards
 	}
-	return ""
+	return nil
 }
 
-func (x *GetPlayerRecordsRequest) GetPage() int32 {
+func (x *TaskDetail) GetAcceptLevel() int32 {
 	if x != nil {
-		return x.Page
+		return x.AcceptLevel
 	}
 	return 0
 }
 
-func (x *GetPlayerRecordsRequest) GetPageSize() int32 {
+func (x *TaskDetail) GetNextTaskId() int32 {
 	if x != nil {
-		return x.PageSize
+		return x.NextTaskId
 	}
 	return 0
 }
 
-type GetPlayerRecordsResponse struct {
+type TaskReward struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Records       []*BattleRecord        `protobuf:"bytes,3,rep,name=records,proto3" json:"records,omitempty"` // 战绩列表
-	Total         int32                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`    // 总数量
-	Stats         *PlayerStats           `protobuf:"bytes,5,opt,name=stats,proto3" json:"stats,omitempty"`     // 玩家统计
+	Type          int32                  `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`                   // 奖励类型：1经验，2金币，3物品
+	ItemId        int32                  `protobuf:"varint,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"` // 物品ID
+	Count         int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`                 // 数量
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetPlayerRecordsResponse) Reset() {
-	*x = GetPlayerRecordsResponse{}
+func (x *TaskReward) Reset() {
+	*x = TaskReward{}
 	mi := &file_game_proto_msgTypes[72]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetPlayerRecordsResponse) String() string {
+func (x *TaskReward) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPlayerRecordsResponse) ProtoMessage() {}
+func (*TaskReward) ProtoMessage() {}
 
-func (x *GetPlayerRecordsResponse) ProtoReflect() protoreflect.Message {
+func (x *TaskReward) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[72]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -4683,73 +4646,54 @@ func (x *GetPlayerRecordsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPlayerRecordsResponse.ProtoReflect.Descriptor instead.
-func (*GetPlayerRecordsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use TaskReward.ProtoReflect.Descriptor instead.
+func (*TaskReward) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{72}
 }
 
-func (x *GetPlayerRecordsResponse) GetCode() int32 {
+func (x *TaskReward) GetType() int32 {
 	if x != nil {
-		return x.Code
+		return x.Type
 	}
 	return 0
 }
 
-func (x *GetPlayerRecordsResponse) GetMessage() string {
-	if x != nil {
-		return x.Message
-	}
-	return ""
-}
-
-func (x *GetPlayerRecordsResponse) GetRecords() []*BattleRecord {
-	if x != nil {
-		return x.Records
-	}
-	return nil
-}
-
-func (x *GetPlayerRecordsResponse) GetTotal() int32 {
+func (x *TaskReward) GetItemId() int32 {
 	if x != nil {
-		return x.Total
+		return x.ItemId
 	}
 	return 0
 }
 
-func (x *GetPlayerRecordsResponse) GetStats() *PlayerStats {
+func (x *TaskReward) GetCount() int32 {
 	if x != nil {
-		return x.Stats
+		return x.Count
 	}
-	return nil
+	return 0
 }
 
-type BattleRecord struct {
+type AcceptTaskRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	BattleId      string                 `protobuf:"bytes,1,opt,name=battle_id,json=battleId,proto3" json:"battle_id,omitempty"`           // 战斗ID
-	LevelId       int32                  `protobuf:"varint,2,opt,name=level_id,json=levelId,proto3" json:"level_id,omitempty"`             // 关卡ID
-	IsWin         bool                   `protobuf:"varint,3,opt,name=is_win,json=isWin,proto3" json:"is_win,omitempty"`                   // 是否胜利
-	StartTime     int64                  `protobuf:"varint,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`       // 开始时间
-	Duration      int64                  `protobuf:"varint,5,opt,name=duration,proto3" json:"duration,omitempty"`                          // 持续时间
-	DamageDealt   int32                  `protobuf:"varint,6,opt,name=damage_dealt,json=damageDealt,proto3" json:"damage_dealt,omitempty"` // 造成伤害
-	Ranking       int32                  `protobuf:"varint,7,opt,name=ranking,proto3" json:"ranking,omitempty"`                            // 排名
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID
+	TaskId        int32                  `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`      // 任务ID
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BattleRecord) Reset() {
-	*x = BattleRecord{}
+func (x *AcceptTaskRequest) Reset() {
+	*x = AcceptTaskRequest{}
 	mi := &file_game_proto_msgTypes[73]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BattleRecord) String() string {
+func (x *AcceptTaskRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BattleRecord) ProtoMessage() {}
+func (*AcceptTaskRequest) ProtoMessage() {}
 
-func (x *BattleRecord) ProtoReflect() protoreflect.Message {
+func (x *AcceptTaskRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[73]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -4761,82 +4705,47 @@ func (x *BattleRecord) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BattleRecord.ProtoReflect.Descriptor instead.
-func (*BattleRecord) Descriptor() ([]byte, []int) {
+// Deprecated: Use AcceptTaskRequest.ProtoReflect.Descriptor instead.
+func (*AcceptTaskRequest) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{73}
 }
 
-func (x *BattleRecord) GetBattleId() string {
+func (x *AcceptTaskRequest) GetPlayerId() string {
 	if x != nil {
-		return x.BattleId
+		return x.PlayerId
 	}
 	return ""
 }
 
-func (x *BattleRecord) GetLevelId() int32 {
-	if x != nil {
-		return x.LevelId
-	}
-	return 0
-}
-
-func (x *BattleRecord) GetIsWin() bool {
-	if x != nil {
-		return x.IsWin
-	}
-	return false
-}
-
-func (x *BattleRecord) GetStartTime() int64 {
-	if x != nil {
-		return x.StartTime
-	}
-	return 0
-}
-
-func (x *BattleRecord) GetDuration() int64 {
-	if x != nil {
-		return x.Duration
-	}
-	return 0
-}
-
-func (x *BattleRecord) GetDamageDealt() int32 {
-	if x != nil {
-		return x.DamageDealt
-	}
-	return 0
-}
-
-func (x *BattleRecord) GetRanking() int32 {
+func (x *AcceptTaskRequest) GetTaskId() int32 {
 	if x != nil {
-		return x.Ranking
+		return x.TaskId
 	}
 	return 0
 }
 
-type GetBattleDetailRequest struct {
+type SubmitTaskRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID
-	BattleId      string                 `protobuf:"bytes,2,opt,name=battle_id,json=battleId,proto3" json:"battle_id,omitempty"` // 战斗ID
+	TaskId        int32                  `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`      // 任务ID
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetBattleDetailRequest) Reset() {
-	*x = GetBattleDetailRequest{}
+func (x *SubmitTaskRequest) Reset() {
+	*x = SubmitTaskRequest{}
 	mi := &file_game_proto_msgTypes[74]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetBattleDetailRequest) String() string {
+func (x *SubmitTaskRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetBattleDetailRequest) ProtoMessage() {}
+func (*SubmitTaskRequest) ProtoMessage() {}
 
-func (x *GetBattleDetailRequest) ProtoReflect() protoreflect.Message {
+func (x *SubmitTaskRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[74]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -4848,48 +4757,48 @@ func (x *GetBattleDetailRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetBattleDetailRequest.ProtoReflect.Descriptor instead.
-func (*GetBattleDetailRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SubmitTaskRequest.ProtoReflect.Descriptor instead.
+func (*SubmitTaskRequest) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{74}
 }
 
-func (x *GetBattleDetailRequest) GetPlayerId() string {
+func (x *SubmitTaskRequest) GetPlayerId() string {
 	if x != nil {
 		return x.PlayerId
 	}
 	return ""
 }
 
-func (x *GetBattleDetailRequest) GetBattleId() string {
+func (x *SubmitTaskRequest) GetTaskId() int32 {
 	if x != nil {
-		return x.BattleId
+		return x.TaskId
 	}
-	return ""
+	return 0
 }
 
-type GetBattleDetailResponse struct {
+type SubmitTaskResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Detail        *BattleDetail          `protobuf:"bytes,3,opt,name=detail,proto3" json:"detail,omitempty"` // 战斗详情
+	Rewards       []*TaskReward          `protobuf:"bytes,3,rep,name=rewards,proto3" json:"rewards,omitempty"` // 获得的奖励
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetBattleDetailResponse) Reset() {
-	*x = GetBattleDetailResponse{}
+func (x *SubmitTaskResponse) Reset() {
+	*x = SubmitTaskResponse{}
 	mi := &file_game_proto_msgTypes[75]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetBattleDetailResponse) String() string {
+func (x *SubmitTaskResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetBattleDetailResponse) ProtoMessage() {}
+func (*SubmitTaskResponse) ProtoMessage() {}
 
-func (x *GetBattleDetailResponse) ProtoReflect() protoreflect.Message {
+func (x *SubmitTaskResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[75]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -4901,59 +4810,54 @@ func (x *GetBattleDetailResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetBattleDetailResponse.ProtoReflect.Descriptor instead.
-func (*GetBattleDetailResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SubmitTaskResponse.ProtoReflect.Descriptor instead.
+func (*SubmitTaskResponse) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{75}
 }
 
-func (x *GetBattleDetailResponse) GetCode() int32 {
+func (x *SubmitTaskResponse) GetCode() int32 {
 	if x != nil {
 		return x.Code
 	}
 	return 0
 }
 
-func (x *GetBattleDetailResponse) GetMessage() string {
+func (x *SubmitTaskResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *GetBattleDetailResponse) GetDetail() *BattleDetail {
+func (x *SubmitTaskResponse) GetRewards() []*TaskReward {
 	if x != nil {
-		return x.Detail
+		return x.Rewards
 	}
 	return nil
 }
 
-type BattleDetail struct {
+type GiveUpTaskRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	BattleId      string                 `protobuf:"bytes,1,opt,name=battle_id,json=battleId,proto3" json:"battle_id,omitempty"`     // 战斗ID
-	LevelId       int32                  `protobuf:"varint,2,opt,name=level_id,json=levelId,proto3" json:"level_id,omitempty"`       // 关卡ID
-	IsWin         bool                   `protobuf:"varint,3,opt,name=is_win,json=isWin,proto3" json:"is_win,omitempty"`             // 是否胜利
-	StartTime     int64                  `protobuf:"varint,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"` // 开始时间
-	EndTime       int64                  `protobuf:"varint,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`       // 结束时间
-	Players       []*DetailedPlayerStats `protobuf:"bytes,6,rep,name=players,proto3" json:"players,omitempty"`                       // 玩家详细统计
-	Boss          *BossStats             `protobuf:"bytes,7,opt,name=boss,proto3" json:"boss,omitempty"`                             // BOSS统计
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID
+	TaskId        int32                  `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`      // 任务ID
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BattleDetail) Reset() {
-	*x = BattleDetail{}
+func (x *GiveUpTaskRequest) Reset() {
+	*x = GiveUpTaskRequest{}
 	mi := &file_game_proto_msgTypes[76]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BattleDetail) String() string {
+func (x *GiveUpTaskRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BattleDetail) ProtoMessage() {}
+func (*GiveUpTaskRequest) ProtoMessage() {}
 
-func (x *BattleDetail) ProtoReflect() protoreflect.Message {
+func (x *GiveUpTaskRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[76]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -4965,92 +4869,102 @@ func (x *BattleDetail) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BattleDetail.ProtoReflect.Descriptor instead.
-func (*BattleDetail) Descriptor() ([]byte, []int) {
+// Deprecated: Use GiveUpTaskRequest.ProtoReflect.Descriptor instead.
+func (*GiveUpTaskRequest) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{76}
 }
 
-func (x *BattleDetail) GetBattleId() string {
+func (x *GiveUpTaskRequest) GetPlayerId() string {
 	if x != nil {
-		return x.BattleId
+		return x.PlayerId
 	}
 	return ""
 }
 
-func (x *BattleDetail) GetLevelId() int32 {
+func (x *GiveUpTaskRequest) GetTaskId() int32 {
 	if x != nil {
-		return x.LevelId
+		return x.TaskId
 	}
 	return 0
 }
 
-func (x *BattleDetail) GetIsWin() bool {
-	if x != nil {
-		return x.IsWin
-	}
-	return false
+type GetShopListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`  // 玩家ID
+	ShopType      int32                  `protobuf:"varint,2,opt,name=shop_type,json=shopType,proto3" json:"shop_type,omitempty"` // 商店类型：1普通，2限时，3活动，4黑市
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BattleDetail) GetStartTime() int64 {
-	if x != nil {
-		return x.StartTime
-	}
-	return 0
+func (x *GetShopListRequest) Reset() {
+	*x = GetShopListRequest{}
+	mi := &file_game_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *BattleDetail) GetEndTime() int64 {
+func (x *GetShopListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShopListRequest) ProtoMessage() {}
+
+func (x *GetShopListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[77]
 	if x != nil {
-		return x.EndTime
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *BattleDetail) GetPlayers() []*DetailedPlayerStats {
+// Deprecated: Use GetShopListRequest.ProtoReflect.Descriptor instead.
+func (*GetShopListRequest) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *GetShopListRequest) GetPlayerId() string {
 	if x != nil {
-		return x.Players
+		return x.PlayerId
 	}
-	return nil
+	return ""
 }
 
-func (x *BattleDetail) GetBoss() *BossStats {
+func (x *GetShopListRequest) GetShopType() int32 {
 	if x != nil {
-		return x.Boss
+		return x.ShopType
 	}
-	return nil
+	return 0
 }
 
-type DetailedPlayerStats struct {
+type GetShopListResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`               // 玩家ID
-	Nickname      string                 `protobuf:"bytes,2,opt,name=nickname,proto3" json:"nickname,omitempty"`                               // 昵称
-	Level         int32                  `protobuf:"varint,3,opt,name=level,proto3" json:"level,omitempty"`                                    // 等级
-	DamageDealt   int32                  `protobuf:"varint,4,opt,name=damage_dealt,json=damageDealt,proto3" json:"damage_dealt,omitempty"`     // 造成伤害
-	DamageTaken   int32                  `protobuf:"varint,5,opt,name=damage_taken,json=damageTaken,proto3" json:"damage_taken,omitempty"`     // 承受伤害
-	Kills         int32                  `protobuf:"varint,6,opt,name=kills,proto3" json:"kills,omitempty"`                                    // 击杀数
-	Deaths        int32                  `protobuf:"varint,7,opt,name=deaths,proto3" json:"deaths,omitempty"`                                  // 死亡数
-	Heals         int32                  `protobuf:"varint,8,opt,name=heals,proto3" json:"heals,omitempty"`                                    // 治疗量
-	ReviveCount   int32                  `protobuf:"varint,9,opt,name=revive_count,json=reviveCount,proto3" json:"revive_count,omitempty"`     // 被复活次数
-	HitRate       int32                  `protobuf:"varint,10,opt,name=hit_rate,json=hitRate,proto3" json:"hit_rate,omitempty"`                // 命中率
-	CriticalRate  int32                  `protobuf:"varint,11,opt,name=critical_rate,json=criticalRate,proto3" json:"critical_rate,omitempty"` // 暴击率
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Items         []*ShopItem            `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`       // 商店物品列表
+	Rotation      *RotationInfo          `protobuf:"bytes,4,opt,name=rotation,proto3" json:"rotation,omitempty"` // 轮换商店刷新信息，普通商店为空
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DetailedPlayerStats) Reset() {
-	*x = DetailedPlayerStats{}
-	mi := &file_game_proto_msgTypes[77]
+func (x *GetShopListResponse) Reset() {
+	*x = GetShopListResponse{}
+	mi := &file_game_proto_msgTypes[78]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DetailedPlayerStats) String() string {
+func (x *GetShopListResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DetailedPlayerStats) ProtoMessage() {}
+func (*GetShopListResponse) ProtoMessage() {}
 
-func (x *DetailedPlayerStats) ProtoReflect() protoreflect.Message {
-	mi := &file_game_proto_msgTypes[77]
+func (x *GetShopListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[78]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5061,118 +4975,139 @@ func (x *DetailedPlayerStats) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DetailedPlayerStats.ProtoReflect.Descriptor instead.
-func (*DetailedPlayerStats) Descriptor() ([]byte, []int) {
-	return file_game_proto_rawDescGZIP(), []int{77}
+// Deprecated: Use GetShopListResponse.ProtoReflect.Descriptor instead.
+func (*GetShopListResponse) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{78}
 }
 
-func (x *DetailedPlayerStats) GetPlayerId() string {
+func (x *GetShopListResponse) GetCode() int32 {
 	if x != nil {
-		return x.PlayerId
+		return x.Code
 	}
-	return ""
+	return 0
 }
 
-func (x *DetailedPlayerStats) GetNickname() string {
+func (x *GetShopListResponse) GetMessage() string {
 	if x != nil {
-		return x.Nickname
+		return x.Message
 	}
 	return ""
 }
 
-func (x *DetailedPlayerStats) GetLevel() int32 {
+func (x *GetShopListResponse) GetItems() []*ShopItem {
 	if x != nil {
-		return x.Level
+		return x.Items
 	}
-	return 0
+	return nil
 }
 
-func (x *DetailedPlayerStats) GetDamageDealt() int32 {
+func (x *GetShopListResponse) GetRotation() *RotationInfo {
 	if x != nil {
-		return x.DamageDealt
+		return x.Rotation
 	}
-	return 0
+	return nil
 }
 
-func (x *DetailedPlayerStats) GetDamageTaken() int32 {
-	if x != nil {
-		return x.DamageTaken
-	}
-	return 0
+type RotationInfo struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	NextRefreshTime     int64                  `protobuf:"varint,1,opt,name=next_refresh_time,json=nextRefreshTime,proto3" json:"next_refresh_time,omitempty"`             // 下次自动刷新时间
+	RefreshCount        int32                  `protobuf:"varint,2,opt,name=refresh_count,json=refreshCount,proto3" json:"refresh_count,omitempty"`                        // 今日已手动刷新次数
+	MaxRefreshCount     int32                  `protobuf:"varint,3,opt,name=max_refresh_count,json=maxRefreshCount,proto3" json:"max_refresh_count,omitempty"`             // 每日手动刷新次数上限
+	RefreshCost         int32                  `protobuf:"varint,4,opt,name=refresh_cost,json=refreshCost,proto3" json:"refresh_cost,omitempty"`                           // 下次手动刷新价格
+	RefreshCurrencyType int32                  `protobuf:"varint,5,opt,name=refresh_currency_type,json=refreshCurrencyType,proto3" json:"refresh_currency_type,omitempty"` // 刷新货币类型：1金币，2钻石
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
-func (x *DetailedPlayerStats) GetKills() int32 {
+func (x *RotationInfo) Reset() {
+	*x = RotationInfo{}
+	mi := &file_game_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotationInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotationInfo) ProtoMessage() {}
+
+func (x *RotationInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[79]
 	if x != nil {
-		return x.Kills
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *DetailedPlayerStats) GetDeaths() int32 {
+// Deprecated: Use RotationInfo.ProtoReflect.Descriptor instead.
+func (*RotationInfo) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *RotationInfo) GetNextRefreshTime() int64 {
 	if x != nil {
-		return x.Deaths
+		return x.NextRefreshTime
 	}
 	return 0
 }
 
-func (x *DetailedPlayerStats) GetHeals() int32 {
+func (x *RotationInfo) GetRefreshCount() int32 {
 	if x != nil {
-		return x.Heals
+		return x.RefreshCount
 	}
 	return 0
 }
 
-func (x *DetailedPlayerStats) GetReviveCount() int32 {
+func (x *RotationInfo) GetMaxRefreshCount() int32 {
 	if x != nil {
-		return x.ReviveCount
+		return x.MaxRefreshCount
 	}
 	return 0
 }
 
-func (x *DetailedPlayerStats) GetHitRate() int32 {
+func (x *RotationInfo) GetRefreshCost() int32 {
 	if x != nil {
-		return x.HitRate
+		return x.RefreshCost
 	}
 	return 0
 }
 
-func (x *DetailedPlayerStats) GetCriticalRate() int32 {
+func (x *RotationInfo) GetRefreshCurrencyType() int32 {
 	if x != nil {
-		return x.CriticalRate
+		return x.RefreshCurrencyType
 	}
 	return 0
 }
 
-type PlayerStats struct {
+type RefreshShopRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TotalBattles  int32                  `protobuf:"varint,1,opt,name=total_battles,json=totalBattles,proto3" json:"total_battles,omitempty"`    // 总战斗场次
-	Wins          int32                  `protobuf:"varint,2,opt,name=wins,proto3" json:"wins,omitempty"`                                        // 胜利场次
-	Losses        int32                  `protobuf:"varint,3,opt,name=losses,proto3" json:"losses,omitempty"`                                    // 失败场次
-	WinRate       float32                `protobuf:"fixed32,4,opt,name=win_rate,json=winRate,proto3" json:"win_rate,omitempty"`                  // 胜率
-	TotalDamage   int32                  `protobuf:"varint,5,opt,name=total_damage,json=totalDamage,proto3" json:"total_damage,omitempty"`       // 总伤害
-	AverageDamage int32                  `protobuf:"varint,6,opt,name=average_damage,json=averageDamage,proto3" json:"average_damage,omitempty"` // 平均伤害
-	TotalKills    int32                  `protobuf:"varint,7,opt,name=total_kills,json=totalKills,proto3" json:"total_kills,omitempty"`          // 总击杀
-	TotalDeaths   int32                  `protobuf:"varint,8,opt,name=total_deaths,json=totalDeaths,proto3" json:"total_deaths,omitempty"`       // 总死亡
-	KdRatio       float32                `protobuf:"fixed32,9,opt,name=kd_ratio,json=kdRatio,proto3" json:"kd_ratio,omitempty"`                  // K/D比率
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`              // 玩家ID
+	ShopType      int32                  `protobuf:"varint,2,opt,name=shop_type,json=shopType,proto3" json:"shop_type,omitempty"`             // 轮换商店类型
+	ExpectedCost  int32                  `protobuf:"varint,3,opt,name=expected_cost,json=expectedCost,proto3" json:"expected_cost,omitempty"` // 客户端展示的刷新价格，不为0时与当前价格不一致则刷新失败
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PlayerStats) Reset() {
-	*x = PlayerStats{}
-	mi := &file_game_proto_msgTypes[78]
+func (x *RefreshShopRequest) Reset() {
+	*x = RefreshShopRequest{}
+	mi := &file_game_proto_msgTypes[80]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PlayerStats) String() string {
+func (x *RefreshShopRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PlayerStats) ProtoMessage() {}
+func (*RefreshShopRequest) ProtoMessage() {}
 
-func (x *PlayerStats) ProtoReflect() protoreflect.Message {
-	mi := &file_game_proto_msgTypes[78]
+func (x *RefreshShopRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[80]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5183,75 +5118,1637 @@ func (x *PlayerStats) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PlayerStats.ProtoReflect.Descriptor instead.
-func (*PlayerStats) Descriptor() ([]byte, []int) {
-	return file_game_proto_rawDescGZIP(), []int{78}
+// Deprecated: Use RefreshShopRequest.ProtoReflect.Descriptor instead.
+func (*RefreshShopRequest) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{80}
 }
 
-func (x *PlayerStats) GetTotalBattles() int32 {
+func (x *RefreshShopRequest) GetPlayerId() string {
 	if x != nil {
-		return x.TotalBattles
+		return x.PlayerId
 	}
-	return 0
+	return ""
 }
 
-func (x *PlayerStats) GetWins() int32 {
+func (x *RefreshShopRequest) GetShopType() int32 {
 	if x != nil {
-		return x.Wins
+		return x.ShopType
 	}
 	return 0
 }
 
-func (x *PlayerStats) GetLosses() int32 {
+func (x *RefreshShopRequest) GetExpectedCost() int32 {
 	if x != nil {
-		return x.Losses
+		return x.ExpectedCost
 	}
 	return 0
 }
 
-func (x *PlayerStats) GetWinRate() float32 {
-	if x != nil {
-		return x.WinRate
-	}
-	return 0
+type RefreshShopResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Items         []*ShopItem            `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`       // 刷新后的商店物品列表
+	Rotation      *RotationInfo          `protobuf:"bytes,4,opt,name=rotation,proto3" json:"rotation,omitempty"` // 刷新后的刷新信息
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PlayerStats) GetTotalDamage() int32 {
+func (x *RefreshShopResponse) Reset() {
+	*x = RefreshShopResponse{}
+	mi := &file_game_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshShopResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshShopResponse) ProtoMessage() {}
+
+func (x *RefreshShopResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[81]
 	if x != nil {
-		return x.TotalDamage
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *PlayerStats) GetAverageDamage() int32 {
+// Deprecated: Use RefreshShopResponse.ProtoReflect.Descriptor instead.
+func (*RefreshShopResponse) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *RefreshShopResponse) GetCode() int32 {
 	if x != nil {
-		return x.AverageDamage
+		return x.Code
 	}
 	return 0
 }
 
-func (x *PlayerStats) GetTotalKills() int32 {
+func (x *RefreshShopResponse) GetMessage() string {
 	if x != nil {
-		return x.TotalKills
+		return x.Message
 	}
-	return 0
+	return ""
 }
 
-func (x *PlayerStats) GetTotalDeaths() int32 {
+func (x *RefreshShopResponse) GetItems() []*ShopItem {
 	if x != nil {
-		return x.TotalDeaths
+		return x.Items
 	}
-	return 0
+	return nil
 }
 
-func (x *PlayerStats) GetKdRatio() float32 {
+func (x *RefreshShopResponse) GetRotation() *RotationInfo {
 	if x != nil {
-		return x.KdRatio
+		return x.Rotation
 	}
-	return 0
+	return nil
 }
 
-type BossStats struct {
+type ShopItem struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ItemId         int32                  `protobuf:"varint,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`                            // 物品ID
+	OriginalPrice  int32                  `protobuf:"varint,2,opt,name=original_price,json=originalPrice,proto3" json:"original_price,omitempty"`       // 原价
+	CurrentPrice   int32                  `protobuf:"varint,3,opt,name=current_price,json=currentPrice,proto3" json:"current_price,omitempty"`          // 当前价格
+	CurrencyType   int32                  `protobuf:"varint,4,opt,name=currency_type,json=currencyType,proto3" json:"currency_type,omitempty"`          // 货币类型：1金币，2钻石
+	Stock          int32                  `protobuf:"varint,5,opt,name=stock,proto3" json:"stock,omitempty"`                                            // 库存
+	MaxBuyCount    int32                  `protobuf:"varint,6,opt,name=max_buy_count,json=maxBuyCount,proto3" json:"max_buy_count,omitempty"`           // 最大购买数量
+	BoughtCount    int32                  `protobuf:"varint,7,opt,name=bought_count,json=boughtCount,proto3" json:"bought_count,omitempty"`             // 已购买数量
+	ExpireTime     int64                  `protobuf:"varint,8,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`                // 过期时间
+	IsDiscount     bool                   `protobuf:"varint,9,opt,name=is_discount,json=isDiscount,proto3" json:"is_discount,omitempty"`                // 是否折扣
+	DiscountRate   float32                `protobuf:"fixed32,10,opt,name=discount_rate,json=discountRate,proto3" json:"discount_rate,omitempty"`        // 折扣率
+	LimitType      int32                  `protobuf:"varint,11,opt,name=limit_type,json=limitType,proto3" json:"limit_type,omitempty"`                  // 限购周期：0永久，1每日，2每周，3活动期间
+	LimitResetTime int64                  `protobuf:"varint,12,opt,name=limit_reset_time,json=limitResetTime,proto3" json:"limit_reset_time,omitempty"` // 限购重置时间，0表示不重置
+	Contents       *ShopGrant             `protobuf:"bytes,13,opt,name=contents,proto3" json:"contents,omitempty"`                                      // 购买获得的内容，单个物品为物品本身
+	FirstBonus     *ShopGrant             `protobuf:"bytes,14,opt,name=first_bonus,json=firstBonus,proto3" json:"first_bonus,omitempty"`                // 首购奖励，为空表示没有
+	FirstAvailable bool                   `protobuf:"varint,15,opt,name=first_available,json=firstAvailable,proto3" json:"first_available,omitempty"`   // 是否可获得首购奖励
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ShopItem) Reset() {
+	*x = ShopItem{}
+	mi := &file_game_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShopItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShopItem) ProtoMessage() {}
+
+func (x *ShopItem) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShopItem.ProtoReflect.Descriptor instead.
+func (*ShopItem) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *ShopItem) GetItemId() int32 {
+	if x != nil {
+		return x.ItemId
+	}
+	return 0
+}
+
+func (x *ShopItem) GetOriginalPrice() int32 {
+	if x != nil {
+		return x.OriginalPrice
+	}
+	return 0
+}
+
+func (x *ShopItem) GetCurrentPrice() int32 {
+	if x != nil {
+		return x.CurrentPrice
+	}
+	return 0
+}
+
+func (x *ShopItem) GetCurrencyType() int32 {
+	if x != nil {
+		return x.CurrencyType
+	}
+	return 0
+}
+
+func (x *ShopItem) GetStock() int32 {
+	if x != nil {
+		return x.Stock
+	}
+	return 0
+}
+
+func (x *ShopItem) GetMaxBuyCount() int32 {
+	if x != nil {
+		return x.MaxBuyCount
+	}
+	return 0
+}
+
+func (x *ShopItem) GetBoughtCount() int32 {
+	if x != nil {
+		return x.BoughtCount
+	}
+	return 0
+}
+
+func (x *ShopItem) GetExpireTime() int64 {
+	if x != nil {
+		return x.ExpireTime
+	}
+	return 0
+}
+
+func (x *ShopItem) GetIsDiscount() bool {
+	if x != nil {
+		return x.IsDiscount
+	}
+	return false
+}
+
+func (x *ShopItem) GetDiscountRate() float32 {
+	if x != nil {
+		return x.DiscountRate
+	}
+	return 0
+}
+
+func (x *ShopItem) GetLimitType() int32 {
+	if x != nil {
+		return x.LimitType
+	}
+	return 0
+}
+
+func (x *ShopItem) GetLimitResetTime() int64 {
+	if x != nil {
+		return x.LimitResetTime
+	}
+	return 0
+}
+
+func (x *ShopItem) GetContents() *ShopGrant {
+	if x != nil {
+		return x.Contents
+	}
+	return nil
+}
+
+func (x *ShopItem) GetFirstBonus() *ShopGrant {
+	if x != nil {
+		return x.FirstBonus
+	}
+	return nil
+}
+
+func (x *ShopItem) GetFirstAvailable() bool {
+	if x != nil {
+		return x.FirstAvailable
+	}
+	return false
+}
+
+type ShopGrant struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*RewardItem          `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`      // 物品
+	Coin          int64                  `protobuf:"varint,2,opt,name=coin,proto3" json:"coin,omitempty"`       // 金币
+	Diamond       int64                  `protobuf:"varint,3,opt,name=diamond,proto3" json:"diamond,omitempty"` // 钻石
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShopGrant) Reset() {
+	*x = ShopGrant{}
+	mi := &file_game_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShopGrant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShopGrant) ProtoMessage() {}
+
+func (x *ShopGrant) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShopGrant.ProtoReflect.Descriptor instead.
+func (*ShopGrant) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *ShopGrant) GetItems() []*RewardItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ShopGrant) GetCoin() int64 {
+	if x != nil {
+		return x.Coin
+	}
+	return 0
+}
+
+func (x *ShopGrant) GetDiamond() int64 {
+	if x != nil {
+		return x.Diamond
+	}
+	return 0
+}
+
+type BuyItemRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId       string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`                   // 玩家ID
+	ItemId         int32                  `protobuf:"varint,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`                        // 物品ID
+	Count          int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`                                        // 购买数量
+	ExpectedPrice  int32                  `protobuf:"varint,4,opt,name=expected_price,json=expectedPrice,proto3" json:"expected_price,omitempty"`   // 客户端展示的单价，不为0时与当前价格不一致则购买失败
+	ShopType       int32                  `protobuf:"varint,5,opt,name=shop_type,json=shopType,proto3" json:"shop_type,omitempty"`                  // 商店类型，购买轮换商店物品时必填
+	IdempotencyKey string                 `protobuf:"bytes,6,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"` // 幂等键，重试时携带相同的键只购买一次
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *BuyItemRequest) Reset() {
+	*x = BuyItemRequest{}
+	mi := &file_game_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuyItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuyItemRequest) ProtoMessage() {}
+
+func (x *BuyItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuyItemRequest.ProtoReflect.Descriptor instead.
+func (*BuyItemRequest) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *BuyItemRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *BuyItemRequest) GetItemId() int32 {
+	if x != nil {
+		return x.ItemId
+	}
+	return 0
+}
+
+func (x *BuyItemRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *BuyItemRequest) GetExpectedPrice() int32 {
+	if x != nil {
+		return x.ExpectedPrice
+	}
+	return 0
+}
+
+func (x *BuyItemRequest) GetShopType() int32 {
+	if x != nil {
+		return x.ShopType
+	}
+	return 0
+}
+
+func (x *BuyItemRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type BuyItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	BoughtItems   []*BagItem             `protobuf:"bytes,3,rep,name=bought_items,json=boughtItems,proto3" json:"bought_items,omitempty"`        // 购买的物品
+	SpentCurrency int32                  `protobuf:"varint,4,opt,name=spent_currency,json=spentCurrency,proto3" json:"spent_currency,omitempty"` // 花费的货币
+	CurrencyType  int32                  `protobuf:"varint,5,opt,name=currency_type,json=currencyType,proto3" json:"currency_type,omitempty"`    // 货币类型
+	GainedCoin    int64                  `protobuf:"varint,6,opt,name=gained_coin,json=gainedCoin,proto3" json:"gained_coin,omitempty"`          // 获得的金币
+	GainedDiamond int64                  `protobuf:"varint,7,opt,name=gained_diamond,json=gainedDiamond,proto3" json:"gained_diamond,omitempty"` // 获得的钻石
+	FirstBonus    bool                   `protobuf:"varint,8,opt,name=first_bonus,json=firstBonus,proto3" json:"first_bonus,omitempty"`          // 是否获得首购奖励
+	OrderId       string                 `protobuf:"bytes,9,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`                    // 订单ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BuyItemResponse) Reset() {
+	*x = BuyItemResponse{}
+	mi := &file_game_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuyItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuyItemResponse) ProtoMessage() {}
+
+func (x *BuyItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuyItemResponse.ProtoReflect.Descriptor instead.
+func (*BuyItemResponse) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *BuyItemResponse) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *BuyItemResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *BuyItemResponse) GetBoughtItems() []*BagItem {
+	if x != nil {
+		return x.BoughtItems
+	}
+	return nil
+}
+
+func (x *BuyItemResponse) GetSpentCurrency() int32 {
+	if x != nil {
+		return x.SpentCurrency
+	}
+	return 0
+}
+
+func (x *BuyItemResponse) GetCurrencyType() int32 {
+	if x != nil {
+		return x.CurrencyType
+	}
+	return 0
+}
+
+func (x *BuyItemResponse) GetGainedCoin() int64 {
+	if x != nil {
+		return x.GainedCoin
+	}
+	return 0
+}
+
+func (x *BuyItemResponse) GetGainedDiamond() int64 {
+	if x != nil {
+		return x.GainedDiamond
+	}
+	return 0
+}
+
+func (x *BuyItemResponse) GetFirstBonus() bool {
+	if x != nil {
+		return x.FirstBonus
+	}
+	return false
+}
+
+func (x *BuyItemResponse) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type RefundShopOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"` // 订单ID
+	Operator      string                 `protobuf:"bytes,2,opt,name=operator,proto3" json:"operator,omitempty"`              // 操作人
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`                  // 退款原因
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundShopOrderRequest) Reset() {
+	*x = RefundShopOrderRequest{}
+	mi := &file_game_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundShopOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundShopOrderRequest) ProtoMessage() {}
+
+func (x *RefundShopOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundShopOrderRequest.ProtoReflect.Descriptor instead.
+func (*RefundShopOrderRequest) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *RefundShopOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *RefundShopOrderRequest) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *RefundShopOrderRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type RefundShopOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Order         *ShopOrder             `protobuf:"bytes,3,opt,name=order,proto3" json:"order,omitempty"` // 退款后的订单
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundShopOrderResponse) Reset() {
+	*x = RefundShopOrderResponse{}
+	mi := &file_game_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundShopOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundShopOrderResponse) ProtoMessage() {}
+
+func (x *RefundShopOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundShopOrderResponse.ProtoReflect.Descriptor instead.
+func (*RefundShopOrderResponse) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *RefundShopOrderResponse) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *RefundShopOrderResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RefundShopOrderResponse) GetOrder() *ShopOrder {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type ShopOrder struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`                 // 订单ID
+	PlayerId      string                 `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`              // 玩家ID
+	ShopType      int32                  `protobuf:"varint,3,opt,name=shop_type,json=shopType,proto3" json:"shop_type,omitempty"`             // 商店类型
+	ItemId        int32                  `protobuf:"varint,4,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`                   // 物品或礼包ID
+	Count         int32                  `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"`                                   // 购买数量
+	TotalPrice    int64                  `protobuf:"varint,6,opt,name=total_price,json=totalPrice,proto3" json:"total_price,omitempty"`       // 实付金额
+	CurrencyType  int32                  `protobuf:"varint,7,opt,name=currency_type,json=currencyType,proto3" json:"currency_type,omitempty"` // 货币类型：1金币，2钻石
+	Grant         *ShopGrant             `protobuf:"bytes,8,opt,name=grant,proto3" json:"grant,omitempty"`                                    // 发放的内容，含首购奖励
+	FirstBonus    bool                   `protobuf:"varint,9,opt,name=first_bonus,json=firstBonus,proto3" json:"first_bonus,omitempty"`       // 是否发放了首购奖励
+	Status        int32                  `protobuf:"varint,10,opt,name=status,proto3" json:"status,omitempty"`                                // 状态：0处理中，1已完成，2失败，3退款中，4已退款
+	Remark        string                 `protobuf:"bytes,11,opt,name=remark,proto3" json:"remark,omitempty"`                                 // 失败或退款原因
+	CreateTime    int64                  `protobuf:"varint,12,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`      // 创建时间
+	UpdateTime    int64                  `protobuf:"varint,13,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`      // 更新时间
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShopOrder) Reset() {
+	*x = ShopOrder{}
+	mi := &file_game_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShopOrder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShopOrder) ProtoMessage() {}
+
+func (x *ShopOrder) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShopOrder.ProtoReflect.Descriptor instead.
+func (*ShopOrder) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *ShopOrder) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ShopOrder) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *ShopOrder) GetShopType() int32 {
+	if x != nil {
+		return x.ShopType
+	}
+	return 0
+}
+
+func (x *ShopOrder) GetItemId() int32 {
+	if x != nil {
+		return x.ItemId
+	}
+	return 0
+}
+
+func (x *ShopOrder) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *ShopOrder) GetTotalPrice() int64 {
+	if x != nil {
+		return x.TotalPrice
+	}
+	return 0
+}
+
+func (x *ShopOrder) GetCurrencyType() int32 {
+	if x != nil {
+		return x.CurrencyType
+	}
+	return 0
+}
+
+func (x *ShopOrder) GetGrant() *ShopGrant {
+	if x != nil {
+		return x.Grant
+	}
+	return nil
+}
+
+func (x *ShopOrder) GetFirstBonus() bool {
+	if x != nil {
+		return x.FirstBonus
+	}
+	return false
+}
+
+func (x *ShopOrder) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *ShopOrder) GetRemark() string {
+	if x != nil {
+		return x.Remark
+	}
+	return ""
+}
+
+func (x *ShopOrder) GetCreateTime() int64 {
+	if x != nil {
+		return x.CreateTime
+	}
+	return 0
+}
+
+func (x *ShopOrder) GetUpdateTime() int64 {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return 0
+}
+
+type GetDiscountInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDiscountInfoRequest) Reset() {
+	*x = GetDiscountInfoRequest{}
+	mi := &file_game_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDiscountInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDiscountInfoRequest) ProtoMessage() {}
+
+func (x *GetDiscountInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDiscountInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetDiscountInfoRequest) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *GetDiscountInfoRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+type GetDiscountInfoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Discounts     []*DiscountInfo        `protobuf:"bytes,3,rep,name=discounts,proto3" json:"discounts,omitempty"` // 折扣信息列表
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDiscountInfoResponse) Reset() {
+	*x = GetDiscountInfoResponse{}
+	mi := &file_game_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDiscountInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDiscountInfoResponse) ProtoMessage() {}
+
+func (x *GetDiscountInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDiscountInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetDiscountInfoResponse) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *GetDiscountInfoResponse) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *GetDiscountInfoResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetDiscountInfoResponse) GetDiscounts() []*DiscountInfo {
+	if x != nil {
+		return x.Discounts
+	}
+	return nil
+}
+
+type DiscountInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShopType      int32                  `protobuf:"varint,1,opt,name=shop_type,json=shopType,proto3" json:"shop_type,omitempty"`              // 商店类型，0表示全部商店
+	DiscountRate  float32                `protobuf:"fixed32,2,opt,name=discount_rate,json=discountRate,proto3" json:"discount_rate,omitempty"` // 折扣率
+	StartTime     int64                  `protobuf:"varint,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`           // 开始时间
+	EndTime       int64                  `protobuf:"varint,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`                 // 结束时间
+	CampaignId    string                 `protobuf:"bytes,5,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`         // 活动ID
+	Name          string                 `protobuf:"bytes,6,opt,name=name,proto3" json:"name,omitempty"`                                       // 活动名称
+	ItemIds       []int32                `protobuf:"varint,7,rep,packed,name=item_ids,json=itemIds,proto3" json:"item_ids,omitempty"`          // 生效的物品ID，为空表示商店内全部物品
+	Stacking      int32                  `protobuf:"varint,8,opt,name=stacking,proto3" json:"stacking,omitempty"`                              // 叠加方式：0独占，1与物品折扣及其他活动叠加
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiscountInfo) Reset() {
+	*x = DiscountInfo{}
+	mi := &file_game_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscountInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscountInfo) ProtoMessage() {}
+
+func (x *DiscountInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscountInfo.ProtoReflect.Descriptor instead.
+func (*DiscountInfo) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *DiscountInfo) GetShopType() int32 {
+	if x != nil {
+		return x.ShopType
+	}
+	return 0
+}
+
+func (x *DiscountInfo) GetDiscountRate() float32 {
+	if x != nil {
+		return x.DiscountRate
+	}
+	return 0
+}
+
+func (x *DiscountInfo) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *DiscountInfo) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *DiscountInfo) GetCampaignId() string {
+	if x != nil {
+		return x.CampaignId
+	}
+	return ""
+}
+
+func (x *DiscountInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DiscountInfo) GetItemIds() []int32 {
+	if x != nil {
+		return x.ItemIds
+	}
+	return nil
+}
+
+func (x *DiscountInfo) GetStacking() int32 {
+	if x != nil {
+		return x.Stacking
+	}
+	return 0
+}
+
+type GetPlayerRecordsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`  // 玩家ID
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`                         // 页码
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // 每页数量
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPlayerRecordsRequest) Reset() {
+	*x = GetPlayerRecordsRequest{}
+	mi := &file_game_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPlayerRecordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlayerRecordsRequest) ProtoMessage() {}
+
+func (x *GetPlayerRecordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlayerRecordsRequest.ProtoReflect.Descriptor instead.
+func (*GetPlayerRecordsRequest) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *GetPlayerRecordsRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *GetPlayerRecordsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetPlayerRecordsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type GetPlayerRecordsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Records       []*BattleRecord        `protobuf:"bytes,3,rep,name=records,proto3" json:"records,omitempty"` // 战绩列表
+	Total         int32                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`    // 总数量
+	Stats         *PlayerStats           `protobuf:"bytes,5,opt,name=stats,proto3" json:"stats,omitempty"`     // 玩家统计
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPlayerRecordsResponse) Reset() {
+	*x = GetPlayerRecordsResponse{}
+	mi := &file_game_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPlayerRecordsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlayerRecordsResponse) ProtoMessage() {}
+
+func (x *GetPlayerRecordsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlayerRecordsResponse.ProtoReflect.Descriptor instead.
+func (*GetPlayerRecordsResponse) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *GetPlayerRecordsResponse) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *GetPlayerRecordsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetPlayerRecordsResponse) GetRecords() []*BattleRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *GetPlayerRecordsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetPlayerRecordsResponse) GetStats() *PlayerStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type BattleRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BattleId      string                 `protobuf:"bytes,1,opt,name=battle_id,json=battleId,proto3" json:"battle_id,omitempty"`           // 战斗ID
+	LevelId       int32                  `protobuf:"varint,2,opt,name=level_id,json=levelId,proto3" json:"level_id,omitempty"`             // 关卡ID
+	IsWin         bool                   `protobuf:"varint,3,opt,name=is_win,json=isWin,proto3" json:"is_win,omitempty"`                   // 是否胜利
+	StartTime     int64                  `protobuf:"varint,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`       // 开始时间
+	Duration      int64                  `protobuf:"varint,5,opt,name=duration,proto3" json:"duration,omitempty"`                          // 持续时间
+	DamageDealt   int32                  `protobuf:"varint,6,opt,name=damage_dealt,json=damageDealt,proto3" json:"damage_dealt,omitempty"` // 造成伤害
+	Ranking       int32                  `protobuf:"varint,7,opt,name=ranking,proto3" json:"ranking,omitempty"`                            // 排名
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BattleRecord) Reset() {
+	*x = BattleRecord{}
+	mi := &file_game_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BattleRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BattleRecord) ProtoMessage() {}
+
+func (x *BattleRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BattleRecord.ProtoReflect.Descriptor instead.
+func (*BattleRecord) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *BattleRecord) GetBattleId() string {
+	if x != nil {
+		return x.BattleId
+	}
+	return ""
+}
+
+func (x *BattleRecord) GetLevelId() int32 {
+	if x != nil {
+		return x.LevelId
+	}
+	return 0
+}
+
+func (x *BattleRecord) GetIsWin() bool {
+	if x != nil {
+		return x.IsWin
+	}
+	return false
+}
+
+func (x *BattleRecord) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *BattleRecord) GetDuration() int64 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *BattleRecord) GetDamageDealt() int32 {
+	if x != nil {
+		return x.DamageDealt
+	}
+	return 0
+}
+
+func (x *BattleRecord) GetRanking() int32 {
+	if x != nil {
+		return x.Ranking
+	}
+	return 0
+}
+
+type GetBattleDetailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID
+	BattleId      string                 `protobuf:"bytes,2,opt,name=battle_id,json=battleId,proto3" json:"battle_id,omitempty"` // 战斗ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBattleDetailRequest) Reset() {
+	*x = GetBattleDetailRequest{}
+	mi := &file_game_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBattleDetailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBattleDetailRequest) ProtoMessage() {}
+
+func (x *GetBattleDetailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBattleDetailRequest.ProtoReflect.Descriptor instead.
+func (*GetBattleDetailRequest) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *GetBattleDetailRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *GetBattleDetailRequest) GetBattleId() string {
+	if x != nil {
+		return x.BattleId
+	}
+	return ""
+}
+
+type GetBattleDetailResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Detail        *BattleDetail          `protobuf:"bytes,3,opt,name=detail,proto3" json:"detail,omitempty"` // 战斗详情
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBattleDetailResponse) Reset() {
+	*x = GetBattleDetailResponse{}
+	mi := &file_game_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBattleDetailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBattleDetailResponse) ProtoMessage() {}
+
+func (x *GetBattleDetailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBattleDetailResponse.ProtoReflect.Descriptor instead.
+func (*GetBattleDetailResponse) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *GetBattleDetailResponse) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *GetBattleDetailResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetBattleDetailResponse) GetDetail() *BattleDetail {
+	if x != nil {
+		return x.Detail
+	}
+	return nil
+}
+
+type BattleDetail struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BattleId      string                 `protobuf:"bytes,1,opt,name=battle_id,json=battleId,proto3" json:"battle_id,omitempty"`     // 战斗ID
+	LevelId       int32                  `protobuf:"varint,2,opt,name=level_id,json=levelId,proto3" json:"level_id,omitempty"`       // 关卡ID
+	IsWin         bool                   `protobuf:"varint,3,opt,name=is_win,json=isWin,proto3" json:"is_win,omitempty"`             // 是否胜利
+	StartTime     int64                  `protobuf:"varint,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"` // 开始时间
+	EndTime       int64                  `protobuf:"varint,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`       // 结束时间
+	Players       []*DetailedPlayerStats `protobuf:"bytes,6,rep,name=players,proto3" json:"players,omitempty"`                       // 玩家详细统计
+	Boss          *BossStats             `protobuf:"bytes,7,opt,name=boss,proto3" json:"boss,omitempty"`                             // BOSS统计
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BattleDetail) Reset() {
+	*x = BattleDetail{}
+	mi := &file_game_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BattleDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BattleDetail) ProtoMessage() {}
+
+func (x *BattleDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BattleDetail.ProtoReflect.Descriptor instead.
+func (*BattleDetail) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *BattleDetail) GetBattleId() string {
+	if x != nil {
+		return x.BattleId
+	}
+	return ""
+}
+
+func (x *BattleDetail) GetLevelId() int32 {
+	if x != nil {
+		return x.LevelId
+	}
+	return 0
+}
+
+func (x *BattleDetail) GetIsWin() bool {
+	if x != nil {
+		return x.IsWin
+	}
+	return false
+}
+
+func (x *BattleDetail) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *BattleDetail) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *BattleDetail) GetPlayers() []*DetailedPlayerStats {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+func (x *BattleDetail) GetBoss() *BossStats {
+	if x != nil {
+		return x.Boss
+	}
+	return nil
+}
+
+type DetailedPlayerStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`               // 玩家ID
+	Nickname      string                 `protobuf:"bytes,2,opt,name=nickname,proto3" json:"nickname,omitempty"`                               // 昵称
+	Level         int32                  `protobuf:"varint,3,opt,name=level,proto3" json:"level,omitempty"`                                    // 等级
+	DamageDealt   int32                  `protobuf:"varint,4,opt,name=damage_dealt,json=damageDealt,proto3" json:"damage_dealt,omitempty"`     // 造成伤害
+	DamageTaken   int32                  `protobuf:"varint,5,opt,name=damage_taken,json=damageTaken,proto3" json:"damage_taken,omitempty"`     // 承受伤害
+	Kills         int32                  `protobuf:"varint,6,opt,name=kills,proto3" json:"kills,omitempty"`                                    // 击杀数
+	Deaths        int32                  `protobuf:"varint,7,opt,name=deaths,proto3" json:"deaths,omitempty"`                                  // 死亡数
+	Heals         int32                  `protobuf:"varint,8,opt,name=heals,proto3" json:"heals,omitempty"`                                    // 治疗量
+	ReviveCount   int32                  `protobuf:"varint,9,opt,name=revive_count,json=reviveCount,proto3" json:"revive_count,omitempty"`     // 被复活次数
+	HitRate       int32                  `protobuf:"varint,10,opt,name=hit_rate,json=hitRate,proto3" json:"hit_rate,omitempty"`                // 命中率
+	CriticalRate  int32                  `protobuf:"varint,11,opt,name=critical_rate,json=criticalRate,proto3" json:"critical_rate,omitempty"` // 暴击率
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DetailedPlayerStats) Reset() {
+	*x = DetailedPlayerStats{}
+	mi := &file_game_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DetailedPlayerStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetailedPlayerStats) ProtoMessage() {}
+
+func (x *DetailedPlayerStats) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetailedPlayerStats.ProtoReflect.Descriptor instead.
+func (*DetailedPlayerStats) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *DetailedPlayerStats) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *DetailedPlayerStats) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *DetailedPlayerStats) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *DetailedPlayerStats) GetDamageDealt() int32 {
+	if x != nil {
+		return x.DamageDealt
+	}
+	return 0
+}
+
+func (x *DetailedPlayerStats) GetDamageTaken() int32 {
+	if x != nil {
+		return x.DamageTaken
+	}
+	return 0
+}
+
+func (x *DetailedPlayerStats) GetKills() int32 {
+	if x != nil {
+		return x.Kills
+	}
+	return 0
+}
+
+func (x *DetailedPlayerStats) GetDeaths() int32 {
+	if x != nil {
+		return x.Deaths
+	}
+	return 0
+}
+
+func (x *DetailedPlayerStats) GetHeals() int32 {
+	if x != nil {
+		return x.Heals
+	}
+	return 0
+}
+
+func (x *DetailedPlayerStats) GetReviveCount() int32 {
+	if x != nil {
+		return x.ReviveCount
+	}
+	return 0
+}
+
+func (x *DetailedPlayerStats) GetHitRate() int32 {
+	if x != nil {
+		return x.HitRate
+	}
+	return 0
+}
+
+func (x *DetailedPlayerStats) GetCriticalRate() int32 {
+	if x != nil {
+		return x.CriticalRate
+	}
+	return 0
+}
+
+type PlayerStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TotalBattles  int32                  `protobuf:"varint,1,opt,name=total_battles,json=totalBattles,proto3" json:"total_battles,omitempty"`    // 总战斗场次
+	Wins          int32                  `protobuf:"varint,2,opt,name=wins,proto3" json:"wins,omitempty"`                                        // 胜利场次
+	Losses        int32                  `protobuf:"varint,3,opt,name=losses,proto3" json:"losses,omitempty"`                                    // 失败场次
+	WinRate       float32                `protobuf:"fixed32,4,opt,name=win_rate,json=winRate,proto3" json:"win_rate,omitempty"`                  // 胜率
+	TotalDamage   int32                  `protobuf:"varint,5,opt,name=total_damage,json=totalDamage,proto3" json:"total_damage,omitempty"`       // 总伤害
+	AverageDamage int32                  `protobuf:"varint,6,opt,name=average_damage,json=averageDamage,proto3" json:"average_damage,omitempty"` // 平均伤害
+	TotalKills    int32                  `protobuf:"varint,7,opt,name=total_kills,json=totalKills,proto3" json:"total_kills,omitempty"`          // 总击杀
+	TotalDeaths   int32                  `protobuf:"varint,8,opt,name=total_deaths,json=totalDeaths,proto3" json:"total_deaths,omitempty"`       // 总死亡
+	KdRatio       float32                `protobuf:"fixed32,9,opt,name=kd_ratio,json=kdRatio,proto3" json:"kd_ratio,omitempty"`                  // K/D比率
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayerStats) Reset() {
+	*x = PlayerStats{}
+	mi := &file_game_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerStats) ProtoMessage() {}
+
+func (x *PlayerStats) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerStats.ProtoReflect.Descriptor instead.
+func (*PlayerStats) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *PlayerStats) GetTotalBattles() int32 {
+	if x != nil {
+		return x.TotalBattles
+	}
+	return 0
+}
+
+func (x *PlayerStats) GetWins() int32 {
+	if x != nil {
+		return x.Wins
+	}
+	return 0
+}
+
+func (x *PlayerStats) GetLosses() int32 {
+	if x != nil {
+		return x.Losses
+	}
+	return 0
+}
+
+func (x *PlayerStats) GetWinRate() float32 {
+	if x != nil {
+		return x.WinRate
+	}
+	return 0
+}
+
+func (x *PlayerStats) GetTotalDamage() int32 {
+	if x != nil {
+		return x.TotalDamage
+	}
+	return 0
+}
+
+func (x *PlayerStats) GetAverageDamage() int32 {
+	if x != nil {
+		return x.AverageDamage
+	}
+	return 0
+}
+
+func (x *PlayerStats) GetTotalKills() int32 {
+	if x != nil {
+		return x.TotalKills
+	}
+	return 0
+}
+
+func (x *PlayerStats) GetTotalDeaths() int32 {
+	if x != nil {
+		return x.TotalDeaths
+	}
+	return 0
+}
+
+func (x *PlayerStats) GetKdRatio() float32 {
+	if x != nil {
+		return x.KdRatio
+	}
+	return 0
+}
+
+type BossStats struct {
 	state               protoimpl.MessageState `protogen:"open.v1"`
 	BossId              int32                  `protobuf:"varint,1,opt,name=boss_id,json=bossId,proto3" json:"boss_id,omitempty"`                                          // BOSS ID
 	BossName            string                 `protobuf:"bytes,2,opt,name=boss_name,json=bossName,proto3" json:"boss_name,omitempty"`                                     // BOSS名称
@@ -5262,21 +6759,415 @@ type BossStats struct {
 	sizeCache           protoimpl.SizeCache
 }
 
-func (x *BossStats) Reset() {
-	*x = BossStats{}
-	mi := &file_game_proto_msgTypes[79]
+func (x *BossStats) Reset() {
+	*x = BossStats{}
+	mi := &file_game_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BossStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BossStats) ProtoMessage() {}
+
+func (x *BossStats) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BossStats.ProtoReflect.Descriptor instead.
+func (*BossStats) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *BossStats) GetBossId() int32 {
+	if x != nil {
+		return x.BossId
+	}
+	return 0
+}
+
+func (x *BossStats) GetBossName() string {
+	if x != nil {
+		return x.BossName
+	}
+	return ""
+}
+
+func (x *BossStats) GetTotalDamageReceived() int32 {
+	if x != nil {
+		return x.TotalDamageReceived
+	}
+	return 0
+}
+
+func (x *BossStats) GetDamagePerSecond() int32 {
+	if x != nil {
+		return x.DamagePerSecond
+	}
+	return 0
+}
+
+func (x *BossStats) GetSkills() []*SkillUsage {
+	if x != nil {
+		return x.Skills
+	}
+	return nil
+}
+
+type SkillUsage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SkillId       int32                  `protobuf:"varint,1,opt,name=skill_id,json=skillId,proto3" json:"skill_id,omitempty"`             // 技能ID
+	UsageCount    int32                  `protobuf:"varint,2,opt,name=usage_count,json=usageCount,proto3" json:"usage_count,omitempty"`    // 使用次数
+	HitCount      int32                  `protobuf:"varint,3,opt,name=hit_count,json=hitCount,proto3" json:"hit_count,omitempty"`          // 命中次数
+	TotalDamage   int32                  `protobuf:"varint,4,opt,name=total_damage,json=totalDamage,proto3" json:"total_damage,omitempty"` // 总伤害
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SkillUsage) Reset() {
+	*x = SkillUsage{}
+	mi := &file_game_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SkillUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SkillUsage) ProtoMessage() {}
+
+func (x *SkillUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[101]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SkillUsage.ProtoReflect.Descriptor instead.
+func (*SkillUsage) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{101}
+}
+
+func (x *SkillUsage) GetSkillId() int32 {
+	if x != nil {
+		return x.SkillId
+	}
+	return 0
+}
+
+func (x *SkillUsage) GetUsageCount() int32 {
+	if x != nil {
+		return x.UsageCount
+	}
+	return 0
+}
+
+func (x *SkillUsage) GetHitCount() int32 {
+	if x != nil {
+		return x.HitCount
+	}
+	return 0
+}
+
+func (x *SkillUsage) GetTotalDamage() int32 {
+	if x != nil {
+		return x.TotalDamage
+	}
+	return 0
+}
+
+type GetRankingListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`           // 玩家ID
+	RankingType   int32                  `protobuf:"varint,2,opt,name=ranking_type,json=rankingType,proto3" json:"ranking_type,omitempty"` // 排行榜类型：1等级，2战斗力，3财富，4击杀，5胜率
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`                                  // 页码
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`          // 每页数量
+	Period        int32                  `protobuf:"varint,5,opt,name=period,proto3" json:"period,omitempty"`                              // 周期：0总榜，1日榜，2周榜，3赛季榜
+	PeriodId      string                 `protobuf:"bytes,6,opt,name=period_id,json=periodId,proto3" json:"period_id,omitempty"`           // 周期ID，为空表示当前周期；日榜如20260101，周榜如2026W01，赛季榜如S1
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRankingListRequest) Reset() {
+	*x = GetRankingListRequest{}
+	mi := &file_game_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRankingListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRankingListRequest) ProtoMessage() {}
+
+func (x *GetRankingListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[102]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRankingListRequest.ProtoReflect.Descriptor instead.
+func (*GetRankingListRequest) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *GetRankingListRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *GetRankingListRequest) GetRankingType() int32 {
+	if x != nil {
+		return x.RankingType
+	}
+	return 0
+}
+
+func (x *GetRankingListRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetRankingListRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetRankingListRequest) GetPeriod() int32 {
+	if x != nil {
+		return x.Period
+	}
+	return 0
+}
+
+func (x *GetRankingListRequest) GetPeriodId() string {
+	if x != nil {
+		return x.PeriodId
+	}
+	return ""
+}
+
+type GetRankingListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Items         []*RankingItem         `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`                             // 排行榜项列表
+	Total         int32                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`                            // 总数量
+	PlayerItem    *RankingItem           `protobuf:"bytes,5,opt,name=player_item,json=playerItem,proto3" json:"player_item,omitempty"` // 玩家自己的排名信息
+	PeriodId      string                 `protobuf:"bytes,6,opt,name=period_id,json=periodId,proto3" json:"period_id,omitempty"`       // 数据所属周期ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRankingListResponse) Reset() {
+	*x = GetRankingListResponse{}
+	mi := &file_game_proto_msgTypes[103]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRankingListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRankingListResponse) ProtoMessage() {}
+
+func (x *GetRankingListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[103]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRankingListResponse.ProtoReflect.Descriptor instead.
+func (*GetRankingListResponse) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{103}
+}
+
+func (x *GetRankingListResponse) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *GetRankingListResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetRankingListResponse) GetItems() []*RankingItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *GetRankingListResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetRankingListResponse) GetPlayerItem() *RankingItem {
+	if x != nil {
+		return x.PlayerItem
+	}
+	return nil
+}
+
+func (x *GetRankingListResponse) GetPeriodId() string {
+	if x != nil {
+		return x.PeriodId
+	}
+	return ""
+}
+
+type RankingItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rank          int32                  `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"`                        // 排名
+	PlayerId      string                 `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID
+	Nickname      string                 `protobuf:"bytes,3,opt,name=nickname,proto3" json:"nickname,omitempty"`                 // 昵称
+	Level         int32                  `protobuf:"varint,4,opt,name=level,proto3" json:"level,omitempty"`                      // 等级
+	Score         int32                  `protobuf:"varint,5,opt,name=score,proto3" json:"score,omitempty"`                      // 分数/数值
+	Avatar        string                 `protobuf:"bytes,6,opt,name=avatar,proto3" json:"avatar,omitempty"`                     // 头像
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RankingItem) Reset() {
+	*x = RankingItem{}
+	mi := &file_game_proto_msgTypes[104]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RankingItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankingItem) ProtoMessage() {}
+
+func (x *RankingItem) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[104]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RankingItem.ProtoReflect.Descriptor instead.
+func (*RankingItem) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *RankingItem) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *RankingItem) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *RankingItem) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *RankingItem) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *RankingItem) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *RankingItem) GetAvatar() string {
+	if x != nil {
+		return x.Avatar
+	}
+	return ""
+}
+
+type GetPlayerRankRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`           // 玩家ID
+	RankingType   int32                  `protobuf:"varint,2,opt,name=ranking_type,json=rankingType,proto3" json:"ranking_type,omitempty"` // 排行榜类型
+	Period        int32                  `protobuf:"varint,3,opt,name=period,proto3" json:"period,omitempty"`                              // 周期：0总榜，1日榜，2周榜，3赛季榜，查询当前周期
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPlayerRankRequest) Reset() {
+	*x = GetPlayerRankRequest{}
+	mi := &file_game_proto_msgTypes[105]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BossStats) String() string {
+func (x *GetPlayerRankRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BossStats) ProtoMessage() {}
+func (*GetPlayerRankRequest) ProtoMessage() {}
 
-func (x *BossStats) ProtoReflect() protoreflect.Message {
-	mi := &file_game_proto_msgTypes[79]
+func (x *GetPlayerRankRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[105]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5287,71 +7178,313 @@ func (x *BossStats) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BossStats.ProtoReflect.Descriptor instead.
-func (*BossStats) Descriptor() ([]byte, []int) {
-	return file_game_proto_rawDescGZIP(), []int{79}
+// Deprecated: Use GetPlayerRankRequest.ProtoReflect.Descriptor instead.
+func (*GetPlayerRankRequest) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{105}
 }
 
-func (x *BossStats) GetBossId() int32 {
+func (x *GetPlayerRankRequest) GetPlayerId() string {
 	if x != nil {
-		return x.BossId
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *GetPlayerRankRequest) GetRankingType() int32 {
+	if x != nil {
+		return x.RankingType
 	}
 	return 0
 }
 
-func (x *BossStats) GetBossName() string {
+func (x *GetPlayerRankRequest) GetPeriod() int32 {
 	if x != nil {
-		return x.BossName
+		return x.Period
+	}
+	return 0
+}
+
+type GetPlayerRankResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Item          *RankingItem           `protobuf:"bytes,3,opt,name=item,proto3" json:"item,omitempty"`                                      // 排名信息
+	TotalPlayers  int32                  `protobuf:"varint,4,opt,name=total_players,json=totalPlayers,proto3" json:"total_players,omitempty"` // 总玩家数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPlayerRankResponse) Reset() {
+	*x = GetPlayerRankResponse{}
+	mi := &file_game_proto_msgTypes[106]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPlayerRankResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlayerRankResponse) ProtoMessage() {}
+
+func (x *GetPlayerRankResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[106]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlayerRankResponse.ProtoReflect.Descriptor instead.
+func (*GetPlayerRankResponse) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{106}
+}
+
+func (x *GetPlayerRankResponse) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *GetPlayerRankResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
 	}
 	return ""
 }
 
-func (x *BossStats) GetTotalDamageReceived() int32 {
+func (x *GetPlayerRankResponse) GetItem() *RankingItem {
 	if x != nil {
-		return x.TotalDamageReceived
+		return x.Item
+	}
+	return nil
+}
+
+func (x *GetPlayerRankResponse) GetTotalPlayers() int32 {
+	if x != nil {
+		return x.TotalPlayers
 	}
 	return 0
 }
 
-func (x *BossStats) GetDamagePerSecond() int32 {
+type GetAroundRankingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`           // 玩家ID
+	RankingType   int32                  `protobuf:"varint,2,opt,name=ranking_type,json=rankingType,proto3" json:"ranking_type,omitempty"` // 排行榜类型
+	Period        int32                  `protobuf:"varint,3,opt,name=period,proto3" json:"period,omitempty"`                              // 周期：0总榜，1日榜，2周榜，3赛季榜，查询当前周期
+	Above         int32                  `protobuf:"varint,4,opt,name=above,proto3" json:"above,omitempty"`                                // 排在玩家之前的人数，0表示默认数量
+	Below         int32                  `protobuf:"varint,5,opt,name=below,proto3" json:"below,omitempty"`                                // 排在玩家之后的人数，0表示默认数量
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAroundRankingRequest) Reset() {
+	*x = GetAroundRankingRequest{}
+	mi := &file_game_proto_msgTypes[107]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAroundRankingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAroundRankingRequest) ProtoMessage() {}
+
+func (x *GetAroundRankingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[107]
 	if x != nil {
-		return x.DamagePerSecond
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAroundRankingRequest.ProtoReflect.Descriptor instead.
+func (*GetAroundRankingRequest) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{107}
+}
+
+func (x *GetAroundRankingRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *GetAroundRankingRequest) GetRankingType() int32 {
+	if x != nil {
+		return x.RankingType
+	}
+	return 0
+}
+
+func (x *GetAroundRankingRequest) GetPeriod() int32 {
+	if x != nil {
+		return x.Period
+	}
+	return 0
+}
+
+func (x *GetAroundRankingRequest) GetAbove() int32 {
+	if x != nil {
+		return x.Above
+	}
+	return 0
+}
+
+func (x *GetAroundRankingRequest) GetBelow() int32 {
+	if x != nil {
+		return x.Below
+	}
+	return 0
+}
+
+type GetGroupRankingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`           // 玩家ID，自动包含在范围内
+	RankingType   int32                  `protobuf:"varint,2,opt,name=ranking_type,json=rankingType,proto3" json:"ranking_type,omitempty"` // 排行榜类型
+	Period        int32                  `protobuf:"varint,3,opt,name=period,proto3" json:"period,omitempty"`                              // 周期：0总榜，1日榜，2周榜，3赛季榜，查询当前周期
+	PlayerIds     []string               `protobuf:"bytes,4,rep,name=player_ids,json=playerIds,proto3" json:"player_ids,omitempty"`        // 范围内的玩家ID列表，如好友或公会成员
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetGroupRankingRequest) Reset() {
+	*x = GetGroupRankingRequest{}
+	mi := &file_game_proto_msgTypes[108]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGroupRankingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGroupRankingRequest) ProtoMessage() {}
+
+func (x *GetGroupRankingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[108]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGroupRankingRequest.ProtoReflect.Descriptor instead.
+func (*GetGroupRankingRequest) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{108}
+}
+
+func (x *GetGroupRankingRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *GetGroupRankingRequest) GetRankingType() int32 {
+	if x != nil {
+		return x.RankingType
+	}
+	return 0
+}
+
+func (x *GetGroupRankingRequest) GetPeriod() int32 {
+	if x != nil {
+		return x.Period
 	}
 	return 0
 }
 
-func (x *BossStats) GetSkills() []*SkillUsage {
+func (x *GetGroupRankingRequest) GetPlayerIds() []string {
+	if x != nil {
+		return x.PlayerIds
+	}
+	return nil
+}
+
+type GetBalanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalanceRequest) Reset() {
+	*x = GetBalanceRequest{}
+	mi := &file_game_proto_msgTypes[109]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceRequest) ProtoMessage() {}
+
+func (x *GetBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[109]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceRequest.ProtoReflect.Descriptor instead.
+func (*GetBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{109}
+}
+
+func (x *GetBalanceRequest) GetPlayerId() string {
 	if x != nil {
-		return x.Skills
+		return x.PlayerId
 	}
-	return nil
+	return ""
 }
 
-type SkillUsage struct {
+type GetBalanceResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SkillId       int32                  `protobuf:"varint,1,opt,name=skill_id,json=skillId,proto3" json:"skill_id,omitempty"`             // 技能ID
-	UsageCount    int32                  `protobuf:"varint,2,opt,name=usage_count,json=usageCount,proto3" json:"usage_count,omitempty"`    // 使用次数
-	HitCount      int32                  `protobuf:"varint,3,opt,name=hit_count,json=hitCount,proto3" json:"hit_count,omitempty"`          // 命中次数
-	TotalDamage   int32                  `protobuf:"varint,4,opt,name=total_damage,json=totalDamage,proto3" json:"total_damage,omitempty"` // 总伤害
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Coin          int64                  `protobuf:"varint,3,opt,name=coin,proto3" json:"coin,omitempty"`       // 金币
+	Diamond       int64                  `protobuf:"varint,4,opt,name=diamond,proto3" json:"diamond,omitempty"` // 钻石
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SkillUsage) Reset() {
-	*x = SkillUsage{}
-	mi := &file_game_proto_msgTypes[80]
+func (x *GetBalanceResponse) Reset() {
+	*x = GetBalanceResponse{}
+	mi := &file_game_proto_msgTypes[110]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SkillUsage) String() string {
+func (x *GetBalanceResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SkillUsage) ProtoMessage() {}
+func (*GetBalanceResponse) ProtoMessage() {}
 
-func (x *SkillUsage) ProtoReflect() protoreflect.Message {
-	mi := &file_game_proto_msgTypes[80]
+func (x *GetBalanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[110]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5362,64 +7495,66 @@ func (x *SkillUsage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SkillUsage.ProtoReflect.Descriptor instead.
-func (*SkillUsage) Descriptor() ([]byte, []int) {
-	return file_game_proto_rawDescGZIP(), []int{80}
+// Deprecated: Use GetBalanceResponse.ProtoReflect.Descriptor instead.
+func (*GetBalanceResponse) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{110}
 }
 
-func (x *SkillUsage) GetSkillId() int32 {
+func (x *GetBalanceResponse) GetCode() int32 {
 	if x != nil {
-		return x.SkillId
+		return x.Code
 	}
 	return 0
 }
 
-func (x *SkillUsage) GetUsageCount() int32 {
+func (x *GetBalanceResponse) GetMessage() string {
 	if x != nil {
-		return x.UsageCount
+		return x.Message
 	}
-	return 0
+	return ""
 }
 
-func (x *SkillUsage) GetHitCount() int32 {
+func (x *GetBalanceResponse) GetCoin() int64 {
 	if x != nil {
-		return x.HitCount
+		return x.Coin
 	}
 	return 0
 }
 
-func (x *SkillUsage) GetTotalDamage() int32 {
+func (x *GetBalanceResponse) GetDiamond() int64 {
 	if x != nil {
-		return x.TotalDamage
+		return x.Diamond
 	}
 	return 0
 }
 
-type GetRankingListRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`           // 玩家ID
-	RankingType   int32                  `protobuf:"varint,2,opt,name=ranking_type,json=rankingType,proto3" json:"ranking_type,omitempty"` // 排行榜类型：1等级，2战斗力，3总伤害，4胜率
-	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`                                  // 页码
-	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`          // 每页数量
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type WalletChangeRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId       string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`                   // 玩家ID
+	CurrencyType   int32                  `protobuf:"varint,2,opt,name=currency_type,json=currencyType,proto3" json:"currency_type,omitempty"`      // 货币类型：1金币，2钻石
+	Amount         int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`                                      // 变化数量，必须大于0
+	Reason         int32                  `protobuf:"varint,4,opt,name=reason,proto3" json:"reason,omitempty"`                                      // 原因码
+	IdempotencyKey string                 `protobuf:"bytes,5,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"` // 幂等键，不为空时同一幂等键只生效一次
+	Remark         string                 `protobuf:"bytes,6,opt,name=remark,proto3" json:"remark,omitempty"`                                       // 备注
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *GetRankingListRequest) Reset() {
-	*x = GetRankingListRequest{}
-	mi := &file_game_proto_msgTypes[81]
+func (x *WalletChangeRequest) Reset() {
+	*x = WalletChangeRequest{}
+	mi := &file_game_proto_msgTypes[111]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetRankingListRequest) String() string {
+func (x *WalletChangeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetRankingListRequest) ProtoMessage() {}
+func (*WalletChangeRequest) ProtoMessage() {}
 
-func (x *GetRankingListRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_game_proto_msgTypes[81]
+func (x *WalletChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[111]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5430,65 +7565,79 @@ func (x *GetRankingListRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetRankingListRequest.ProtoReflect.Descriptor instead.
-func (*GetRankingListRequest) Descriptor() ([]byte, []int) {
-	return file_game_proto_rawDescGZIP(), []int{81}
+// Deprecated: Use WalletChangeRequest.ProtoReflect.Descriptor instead.
+func (*WalletChangeRequest) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{111}
 }
 
-func (x *GetRankingListRequest) GetPlayerId() string {
+func (x *WalletChangeRequest) GetPlayerId() string {
 	if x != nil {
 		return x.PlayerId
 	}
 	return ""
 }
 
-func (x *GetRankingListRequest) GetRankingType() int32 {
+func (x *WalletChangeRequest) GetCurrencyType() int32 {
 	if x != nil {
-		return x.RankingType
+		return x.CurrencyType
 	}
 	return 0
 }
 
-func (x *GetRankingListRequest) GetPage() int32 {
+func (x *WalletChangeRequest) GetAmount() int64 {
 	if x != nil {
-		return x.Page
+		return x.Amount
 	}
 	return 0
 }
 
-func (x *GetRankingListRequest) GetPageSize() int32 {
+func (x *WalletChangeRequest) GetReason() int32 {
 	if x != nil {
-		return x.PageSize
+		return x.Reason
 	}
 	return 0
 }
 
-type GetRankingListResponse struct {
+func (x *WalletChangeRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *WalletChangeRequest) GetRemark() string {
+	if x != nil {
+		return x.Remark
+	}
+	return ""
+}
+
+type WalletChangeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Items         []*RankingItem         `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`                             // 排行榜项列表
-	Total         int32                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`                            // 总数量
-	PlayerItem    *RankingItem           `protobuf:"bytes,5,opt,name=player_item,json=playerItem,proto3" json:"player_item,omitempty"` // 玩家自己的排名信息
+	TransactionId string                 `protobuf:"bytes,3,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"` // 流水ID
+	Balance       int64                  `protobuf:"varint,4,opt,name=balance,proto3" json:"balance,omitempty"`                                 // 变化后余额
+	Duplicated    bool                   `protobuf:"varint,5,opt,name=duplicated,proto3" json:"duplicated,omitempty"`                           // 是否为重复请求，重复请求返回首次处理的结果
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetRankingListResponse) Reset() {
-	*x = GetRankingListResponse{}
-	mi := &file_game_proto_msgTypes[82]
+func (x *WalletChangeResponse) Reset() {
+	*x = WalletChangeResponse{}
+	mi := &file_game_proto_msgTypes[112]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetRankingListResponse) String() string {
+func (x *WalletChangeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetRankingListResponse) ProtoMessage() {}
+func (*WalletChangeResponse) ProtoMessage() {}
 
-func (x *GetRankingListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_game_proto_msgTypes[82]
+func (x *WalletChangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[112]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5499,73 +7648,71 @@ func (x *GetRankingListResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetRankingListResponse.ProtoReflect.Descriptor instead.
-func (*GetRankingListResponse) Descriptor() ([]byte, []int) {
-	return file_game_proto_rawDescGZIP(), []int{82}
+// Deprecated: Use WalletChangeResponse.ProtoReflect.Descriptor instead.
+func (*WalletChangeResponse) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{112}
 }
 
-func (x *GetRankingListResponse) GetCode() int32 {
+func (x *WalletChangeResponse) GetCode() int32 {
 	if x != nil {
 		return x.Code
 	}
 	return 0
 }
 
-func (x *GetRankingListResponse) GetMessage() string {
+func (x *WalletChangeResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *GetRankingListResponse) GetItems() []*RankingItem {
+func (x *WalletChangeResponse) GetTransactionId() string {
 	if x != nil {
-		return x.Items
+		return x.TransactionId
 	}
-	return nil
+	return ""
 }
 
-func (x *GetRankingListResponse) GetTotal() int32 {
+func (x *WalletChangeResponse) GetBalance() int64 {
 	if x != nil {
-		return x.Total
+		return x.Balance
 	}
 	return 0
 }
 
-func (x *GetRankingListResponse) GetPlayerItem() *RankingItem {
+func (x *WalletChangeResponse) GetDuplicated() bool {
 	if x != nil {
-		return x.PlayerItem
+		return x.Duplicated
 	}
-	return nil
+	return false
 }
 
-type RankingItem struct {
+type GetTransactionsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Rank          int32                  `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"`                        // 排名
-	PlayerId      string                 `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID
-	Nickname      string                 `protobuf:"bytes,3,opt,name=nickname,proto3" json:"nickname,omitempty"`                 // 昵称
-	Level         int32                  `protobuf:"varint,4,opt,name=level,proto3" json:"level,omitempty"`                      // 等级
-	Score         int32                  `protobuf:"varint,5,opt,name=score,proto3" json:"score,omitempty"`                      // 分数/数值
-	Avatar        string                 `protobuf:"bytes,6,opt,name=avatar,proto3" json:"avatar,omitempty"`                     // 头像
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`              // 玩家ID
+	CurrencyType  int32                  `protobuf:"varint,2,opt,name=currency_type,json=currencyType,proto3" json:"currency_type,omitempty"` // 货币类型，0表示全部
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`                                     // 页码
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`             // 每页数量
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RankingItem) Reset() {
-	*x = RankingItem{}
-	mi := &file_game_proto_msgTypes[83]
+func (x *GetTransactionsRequest) Reset() {
+	*x = GetTransactionsRequest{}
+	mi := &file_game_proto_msgTypes[113]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RankingItem) String() string {
+func (x *GetTransactionsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RankingItem) ProtoMessage() {}
+func (*GetTransactionsRequest) ProtoMessage() {}
 
-func (x *RankingItem) ProtoReflect() protoreflect.Message {
-	mi := &file_game_proto_msgTypes[83]
+func (x *GetTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[113]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5576,76 +7723,64 @@ func (x *RankingItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RankingItem.ProtoReflect.Descriptor instead.
-func (*RankingItem) Descriptor() ([]byte, []int) {
-	return file_game_proto_rawDescGZIP(), []int{83}
-}
-
-func (x *RankingItem) GetRank() int32 {
-	if x != nil {
-		return x.Rank
-	}
-	return 0
+// Deprecated: Use GetTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*GetTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{113}
 }
 
-func (x *RankingItem) GetPlayerId() string {
+func (x *GetTransactionsRequest) GetPlayerId() string {
 	if x != nil {
 		return x.PlayerId
 	}
 	return ""
 }
 
-func (x *RankingItem) GetNickname() string {
-	if x != nil {
-		return x.Nickname
-	}
-	return ""
-}
-
-func (x *RankingItem) GetLevel() int32 {
+func (x *GetTransactionsRequest) GetCurrencyType() int32 {
 	if x != nil {
-		return x.Level
+		return x.CurrencyType
 	}
 	return 0
 }
 
-func (x *RankingItem) GetScore() int32 {
+func (x *GetTransactionsRequest) GetPage() int32 {
 	if x != nil {
-		return x.Score
+		return x.Page
 	}
 	return 0
 }
 
-func (x *RankingItem) GetAvatar() string {
+func (x *GetTransactionsRequest) GetPageSize() int32 {
 	if x != nil {
-		return x.Avatar
+		return x.PageSize
 	}
-	return ""
+	return 0
 }
 
-type GetPlayerRankRequest struct {
+type GetTransactionsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`           // 玩家ID
-	RankingType   int32                  `protobuf:"varint,2,opt,name=ranking_type,json=rankingType,proto3" json:"ranking_type,omitempty"` // 排行榜类型
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Transactions  []*WalletTransaction   `protobuf:"bytes,3,rep,name=transactions,proto3" json:"transactions,omitempty"` // 流水列表，按时间倒序
+	Total         int32                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`              // 总数量
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetPlayerRankRequest) Reset() {
-	*x = GetPlayerRankRequest{}
-	mi := &file_game_proto_msgTypes[84]
+func (x *GetTransactionsResponse) Reset() {
+	*x = GetTransactionsResponse{}
+	mi := &file_game_proto_msgTypes[114]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetPlayerRankRequest) String() string {
+func (x *GetTransactionsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPlayerRankRequest) ProtoMessage() {}
+func (*GetTransactionsResponse) ProtoMessage() {}
 
-func (x *GetPlayerRankRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_game_proto_msgTypes[84]
+func (x *GetTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[114]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5656,50 +7791,67 @@ func (x *GetPlayerRankRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPlayerRankRequest.ProtoReflect.Descriptor instead.
-func (*GetPlayerRankRequest) Descriptor() ([]byte, []int) {
-	return file_game_proto_rawDescGZIP(), []int{84}
+// Deprecated: Use GetTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*GetTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{114}
 }
 
-func (x *GetPlayerRankRequest) GetPlayerId() string {
+func (x *GetTransactionsResponse) GetCode() int32 {
 	if x != nil {
-		return x.PlayerId
+		return x.Code
+	}
+	return 0
+}
+
+func (x *GetTransactionsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
 	}
 	return ""
 }
 
-func (x *GetPlayerRankRequest) GetRankingType() int32 {
+func (x *GetTransactionsResponse) GetTransactions() []*WalletTransaction {
 	if x != nil {
-		return x.RankingType
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *GetTransactionsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
 	}
 	return 0
 }
 
-type GetPlayerRankResponse struct {
+type WalletTransaction struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Item          *RankingItem           `protobuf:"bytes,3,opt,name=item,proto3" json:"item,omitempty"`                                      // 排名信息
-	TotalPlayers  int32                  `protobuf:"varint,4,opt,name=total_players,json=totalPlayers,proto3" json:"total_players,omitempty"` // 总玩家数
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                          // 流水ID
+	CurrencyType  int32                  `protobuf:"varint,2,opt,name=currency_type,json=currencyType,proto3" json:"currency_type,omitempty"` // 货币类型
+	Amount        int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`                                 // 变化数量，扣除为负数
+	Balance       int64                  `protobuf:"varint,4,opt,name=balance,proto3" json:"balance,omitempty"`                               // 变化后余额
+	Reason        int32                  `protobuf:"varint,5,opt,name=reason,proto3" json:"reason,omitempty"`                                 // 原因码
+	Remark        string                 `protobuf:"bytes,6,opt,name=remark,proto3" json:"remark,omitempty"`                                  // 备注
+	CreateTime    int64                  `protobuf:"varint,7,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`       // 时间
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetPlayerRankResponse) Reset() {
-	*x = GetPlayerRankResponse{}
-	mi := &file_game_proto_msgTypes[85]
+func (x *WalletTransaction) Reset() {
+	*x = WalletTransaction{}
+	mi := &file_game_proto_msgTypes[115]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetPlayerRankResponse) String() string {
+func (x *WalletTransaction) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPlayerRankResponse) ProtoMessage() {}
+func (*WalletTransaction) ProtoMessage() {}
 
-func (x *GetPlayerRankResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_game_proto_msgTypes[85]
+func (x *WalletTransaction) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[115]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5710,35 +7862,56 @@ func (x *GetPlayerRankResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPlayerRankResponse.ProtoReflect.Descriptor instead.
-func (*GetPlayerRankResponse) Descriptor() ([]byte, []int) {
-	return file_game_proto_rawDescGZIP(), []int{85}
+// Deprecated: Use WalletTransaction.ProtoReflect.Descriptor instead.
+func (*WalletTransaction) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{115}
 }
 
-func (x *GetPlayerRankResponse) GetCode() int32 {
+func (x *WalletTransaction) GetId() string {
 	if x != nil {
-		return x.Code
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WalletTransaction) GetCurrencyType() int32 {
+	if x != nil {
+		return x.CurrencyType
 	}
 	return 0
 }
 
-func (x *GetPlayerRankResponse) GetMessage() string {
+func (x *WalletTransaction) GetAmount() int64 {
 	if x != nil {
-		return x.Message
+		return x.Amount
 	}
-	return ""
+	return 0
 }
 
-func (x *GetPlayerRankResponse) GetItem() *RankingItem {
+func (x *WalletTransaction) GetBalance() int64 {
 	if x != nil {
-		return x.Item
+		return x.Balance
 	}
-	return nil
+	return 0
 }
 
-func (x *GetPlayerRankResponse) GetTotalPlayers() int32 {
+func (x *WalletTransaction) GetReason() int32 {
 	if x != nil {
-		return x.TotalPlayers
+		return x.Reason
+	}
+	return 0
+}
+
+func (x *WalletTransaction) GetRemark() string {
+	if x != nil {
+		return x.Remark
+	}
+	return ""
+}
+
+func (x *WalletTransaction) GetCreateTime() int64 {
+	if x != nil {
+		return x.CreateTime
 	}
 	return 0
 }
@@ -5755,7 +7928,7 @@ type ProcessBattleDataRequest struct {
 
 func (x *ProcessBattleDataRequest) Reset() {
 	*x = ProcessBattleDataRequest{}
-	mi := &file_game_proto_msgTypes[86]
+	mi := &file_game_proto_msgTypes[116]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5767,7 +7940,7 @@ func (x *ProcessBattleDataRequest) String() string {
 func (*ProcessBattleDataRequest) ProtoMessage() {}
 
 func (x *ProcessBattleDataRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_game_proto_msgTypes[86]
+	mi := &file_game_proto_msgTypes[116]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5780,7 +7953,7 @@ func (x *ProcessBattleDataRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProcessBattleDataRequest.ProtoReflect.Descriptor instead.
 func (*ProcessBattleDataRequest) Descriptor() ([]byte, []int) {
-	return file_game_proto_rawDescGZIP(), []int{86}
+	return file_game_proto_rawDescGZIP(), []int{116}
 }
 
 func (x *ProcessBattleDataRequest) GetBattleId() string {
@@ -5824,7 +7997,7 @@ type BattleActionLog struct {
 
 func (x *BattleActionLog) Reset() {
 	*x = BattleActionLog{}
-	mi := &file_game_proto_msgTypes[87]
+	mi := &file_game_proto_msgTypes[117]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5836,7 +8009,7 @@ func (x *BattleActionLog) String() string {
 func (*BattleActionLog) ProtoMessage() {}
 
 func (x *BattleActionLog) ProtoReflect() protoreflect.Message {
-	mi := &file_game_proto_msgTypes[87]
+	mi := &file_game_proto_msgTypes[117]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5849,7 +8022,7 @@ func (x *BattleActionLog) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BattleActionLog.ProtoReflect.Descriptor instead.
 func (*BattleActionLog) Descriptor() ([]byte, []int) {
-	return file_game_proto_rawDescGZIP(), []int{87}
+	return file_game_proto_rawDescGZIP(), []int{117}
 }
 
 func (x *BattleActionLog) GetPlayerId() string {
@@ -5903,11 +8076,12 @@ const file_game_proto_rawDesc = "" +
 	"\bclientIP\x18\x04 \x01(\tR\bclientIP\"@\n" +
 	"\x10RegisterResponse\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"a\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"~\n" +
 	"\fLoginRequest\x12\x18\n" +
 	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x1a\n" +
 	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x1b\n" +
-	"\tdevice_id\x18\x03 \x01(\tR\bdeviceId\"\xb6\x01\n" +
+	"\tdevice_id\x18\x03 \x01(\tR\bdeviceId\x12\x1b\n" +
+	"\tclient_ip\x18\x04 \x01(\tR\bclientIp\"\xb6\x01\n" +
 	"\rLoginResponse\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x12\n" +
@@ -5928,7 +8102,26 @@ const file_game_proto_rawDesc = "" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1a\n" +
 	"\bposition\x18\x03 \x01(\x05R\bposition\x12\x14\n" +
 	"\x05total\x18\x04 \x01(\x05R\x05total\x12%\n" +
-	"\x0eestimated_time\x18\x05 \x01(\x05R\restimatedTime\"\x93\x01\n" +
+	"\x0eestimated_time\x18\x05 \x01(\x05R\restimatedTime\")\n" +
+	"\x11LeaveQueueRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"#\n" +
+	"\vAuthRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\x80\x01\n" +
+	"\fAuthResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1b\n" +
+	"\tplayer_id\x18\x03 \x01(\tR\bplayerId\x12%\n" +
+	"\x0equeue_position\x18\x04 \x01(\x05R\rqueuePosition\"H\n" +
+	"\x13QueueAdmittedNotify\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"C\n" +
+	"\x13QueueRejectedNotify\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\">\n" +
+	"\n" +
+	"KickNotify\x12\x16\n" +
+	"\x06reason\x18\x01 \x01(\x05R\x06reason\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x93\x01\n" +
 	"\n" +
 	"PlayerInfo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
@@ -6142,10 +8335,49 @@ const file_game_proto_rawDesc = "" +
 	"\x1dReceiveMailAttachmentResponse\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x124\n" +
-	"\vattachments\x18\x03 \x03(\v2\x12.pb.MailAttachmentR\vattachments\"K\n" +
+	"\vattachments\x18\x03 \x03(\v2\x12.pb.MailAttachmentR\vattachments\"?\n" +
+	" ReceiveAllMailAttachmentsRequest\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\"\xa2\x01\n" +
+	"!ReceiveAllMailAttachmentsResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x19\n" +
+	"\bmail_ids\x18\x03 \x03(\tR\amailIds\x124\n" +
+	"\vattachments\x18\x04 \x03(\v2\x12.pb.MailAttachmentR\vattachments\"K\n" +
 	"\x11DeleteMailRequest\x12\x1b\n" +
 	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x19\n" +
-	"\bmail_ids\x18\x02 \x03(\tR\amailIds\"N\n" +
+	"\bmail_ids\x18\x02 \x03(\tR\amailIds\"\xe0\x01\n" +
+	"\x0fSendMailRequest\x12\x1d\n" +
+	"\n" +
+	"player_ids\x18\x01 \x03(\tR\tplayerIds\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x124\n" +
+	"\vattachments\x18\x04 \x03(\v2\x12.pb.MailAttachmentR\vattachments\x12\x1f\n" +
+	"\vexpire_time\x18\x05 \x01(\x03R\n" +
+	"expireTime\x12'\n" +
+	"\x0fidempotency_key\x18\x06 \x01(\tR\x0eidempotencyKey\"[\n" +
+	"\x10SendMailResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x19\n" +
+	"\bmail_ids\x18\x03 \x03(\tR\amailIds\"\xab\x02\n" +
+	"\x18SendBroadcastMailRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x124\n" +
+	"\vattachments\x18\x03 \x03(\v2\x12.pb.MailAttachmentR\vattachments\x12\x1f\n" +
+	"\vexpire_time\x18\x04 \x01(\x03R\n" +
+	"expireTime\x12\x1b\n" +
+	"\tmin_level\x18\x05 \x01(\x05R\bminLevel\x12\x1b\n" +
+	"\tmax_level\x18\x06 \x01(\x05R\bmaxLevel\x12%\n" +
+	"\x0eregister_after\x18\a \x01(\x03R\rregisterAfter\x12'\n" +
+	"\x0fregister_before\x18\b \x01(\x03R\x0eregisterBefore\"2\n" +
+	"\x13GetMailBadgeRequest\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\"z\n" +
+	"\x14GetMailBadgeResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x16\n" +
+	"\x06unread\x18\x03 \x01(\x05R\x06unread\x12\x1c\n" +
+	"\tunclaimed\x18\x04 \x01(\x05R\tunclaimed\"%\n" +
+	"\rNewMailNotify\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\"N\n" +
 	"\x12GetTaskListRequest\x12\x1b\n" +
 	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x1b\n" +
 	"\ttask_type\x18\x02 \x01(\x05R\btaskType\"h\n" +
@@ -6207,11 +8439,27 @@ const file_game_proto_rawDesc = "" +
 	"\atask_id\x18\x02 \x01(\x05R\x06taskId\"N\n" +
 	"\x12GetShopListRequest\x12\x1b\n" +
 	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x1b\n" +
-	"\tshop_type\x18\x02 \x01(\x05R\bshopType\"g\n" +
+	"\tshop_type\x18\x02 \x01(\x05R\bshopType\"\x95\x01\n" +
 	"\x13GetShopListResponse\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\"\n" +
-	"\x05items\x18\x03 \x03(\v2\f.pb.ShopItemR\x05items\"\xd8\x02\n" +
+	"\x05items\x18\x03 \x03(\v2\f.pb.ShopItemR\x05items\x12,\n" +
+	"\brotation\x18\x04 \x01(\v2\x10.pb.RotationInfoR\brotation\"\xe2\x01\n" +
+	"\fRotationInfo\x12*\n" +
+	"\x11next_refresh_time\x18\x01 \x01(\x03R\x0fnextRefreshTime\x12#\n" +
+	"\rrefresh_count\x18\x02 \x01(\x05R\frefreshCount\x12*\n" +
+	"\x11max_refresh_count\x18\x03 \x01(\x05R\x0fmaxRefreshCount\x12!\n" +
+	"\frefresh_cost\x18\x04 \x01(\x05R\vrefreshCost\x122\n" +
+	"\x15refresh_currency_type\x18\x05 \x01(\x05R\x13refreshCurrencyType\"s\n" +
+	"\x12RefreshShopRequest\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x1b\n" +
+	"\tshop_type\x18\x02 \x01(\x05R\bshopType\x12#\n" +
+	"\rexpected_cost\x18\x03 \x01(\x05R\fexpectedCost\"\x95\x01\n" +
+	"\x13RefreshShopResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\"\n" +
+	"\x05items\x18\x03 \x03(\v2\f.pb.ShopItemR\x05items\x12,\n" +
+	"\brotation\x18\x04 \x01(\v2\x10.pb.RotationInfoR\brotation\"\xa5\x04\n" +
 	"\bShopItem\x12\x17\n" +
 	"\aitem_id\x18\x01 \x01(\x05R\x06itemId\x12%\n" +
 	"\x0eoriginal_price\x18\x02 \x01(\x05R\roriginalPrice\x12#\n" +
@@ -6225,29 +8473,81 @@ const file_game_proto_rawDesc = "" +
 	"\vis_discount\x18\t \x01(\bR\n" +
 	"isDiscount\x12#\n" +
 	"\rdiscount_rate\x18\n" +
-	" \x01(\x02R\fdiscountRate\"\\\n" +
+	" \x01(\x02R\fdiscountRate\x12\x1d\n" +
+	"\n" +
+	"limit_type\x18\v \x01(\x05R\tlimitType\x12(\n" +
+	"\x10limit_reset_time\x18\f \x01(\x03R\x0elimitResetTime\x12)\n" +
+	"\bcontents\x18\r \x01(\v2\r.pb.ShopGrantR\bcontents\x12.\n" +
+	"\vfirst_bonus\x18\x0e \x01(\v2\r.pb.ShopGrantR\n" +
+	"firstBonus\x12'\n" +
+	"\x0ffirst_available\x18\x0f \x01(\bR\x0efirstAvailable\"_\n" +
+	"\tShopGrant\x12$\n" +
+	"\x05items\x18\x01 \x03(\v2\x0e.pb.RewardItemR\x05items\x12\x12\n" +
+	"\x04coin\x18\x02 \x01(\x03R\x04coin\x12\x18\n" +
+	"\adiamond\x18\x03 \x01(\x03R\adiamond\"\xc9\x01\n" +
 	"\x0eBuyItemRequest\x12\x1b\n" +
 	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x17\n" +
 	"\aitem_id\x18\x02 \x01(\x05R\x06itemId\x12\x14\n" +
-	"\x05count\x18\x03 \x01(\x05R\x05count\"\xbb\x01\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\x12%\n" +
+	"\x0eexpected_price\x18\x04 \x01(\x05R\rexpectedPrice\x12\x1b\n" +
+	"\tshop_type\x18\x05 \x01(\x05R\bshopType\x12'\n" +
+	"\x0fidempotency_key\x18\x06 \x01(\tR\x0eidempotencyKey\"\xbf\x02\n" +
 	"\x0fBuyItemResponse\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12.\n" +
 	"\fbought_items\x18\x03 \x03(\v2\v.pb.BagItemR\vboughtItems\x12%\n" +
 	"\x0espent_currency\x18\x04 \x01(\x05R\rspentCurrency\x12#\n" +
-	"\rcurrency_type\x18\x05 \x01(\x05R\fcurrencyType\"5\n" +
+	"\rcurrency_type\x18\x05 \x01(\x05R\fcurrencyType\x12\x1f\n" +
+	"\vgained_coin\x18\x06 \x01(\x03R\n" +
+	"gainedCoin\x12%\n" +
+	"\x0egained_diamond\x18\a \x01(\x03R\rgainedDiamond\x12\x1f\n" +
+	"\vfirst_bonus\x18\b \x01(\bR\n" +
+	"firstBonus\x12\x19\n" +
+	"\border_id\x18\t \x01(\tR\aorderId\"g\n" +
+	"\x16RefundShopOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1a\n" +
+	"\boperator\x18\x02 \x01(\tR\boperator\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"l\n" +
+	"\x17RefundShopOrderResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12#\n" +
+	"\x05order\x18\x03 \x01(\v2\r.pb.ShopOrderR\x05order\"\x8d\x03\n" +
+	"\tShopOrder\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1b\n" +
+	"\tplayer_id\x18\x02 \x01(\tR\bplayerId\x12\x1b\n" +
+	"\tshop_type\x18\x03 \x01(\x05R\bshopType\x12\x17\n" +
+	"\aitem_id\x18\x04 \x01(\x05R\x06itemId\x12\x14\n" +
+	"\x05count\x18\x05 \x01(\x05R\x05count\x12\x1f\n" +
+	"\vtotal_price\x18\x06 \x01(\x03R\n" +
+	"totalPrice\x12#\n" +
+	"\rcurrency_type\x18\a \x01(\x05R\fcurrencyType\x12#\n" +
+	"\x05grant\x18\b \x01(\v2\r.pb.ShopGrantR\x05grant\x12\x1f\n" +
+	"\vfirst_bonus\x18\t \x01(\bR\n" +
+	"firstBonus\x12\x16\n" +
+	"\x06status\x18\n" +
+	" \x01(\x05R\x06status\x12\x16\n" +
+	"\x06remark\x18\v \x01(\tR\x06remark\x12\x1f\n" +
+	"\vcreate_time\x18\f \x01(\x03R\n" +
+	"createTime\x12\x1f\n" +
+	"\vupdate_time\x18\r \x01(\x03R\n" +
+	"updateTime\"5\n" +
 	"\x16GetDiscountInfoRequest\x12\x1b\n" +
 	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\"w\n" +
 	"\x17GetDiscountInfoResponse\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12.\n" +
-	"\tdiscounts\x18\x03 \x03(\v2\x10.pb.DiscountInfoR\tdiscounts\"\x8a\x01\n" +
+	"\tdiscounts\x18\x03 \x03(\v2\x10.pb.DiscountInfoR\tdiscounts\"\xf6\x01\n" +
 	"\fDiscountInfo\x12\x1b\n" +
 	"\tshop_type\x18\x01 \x01(\x05R\bshopType\x12#\n" +
 	"\rdiscount_rate\x18\x02 \x01(\x02R\fdiscountRate\x12\x1d\n" +
 	"\n" +
 	"start_time\x18\x03 \x01(\x03R\tstartTime\x12\x19\n" +
-	"\bend_time\x18\x04 \x01(\x03R\aendTime\"g\n" +
+	"\bend_time\x18\x04 \x01(\x03R\aendTime\x12\x1f\n" +
+	"\vcampaign_id\x18\x05 \x01(\tR\n" +
+	"campaignId\x12\x12\n" +
+	"\x04name\x18\x06 \x01(\tR\x04name\x12\x19\n" +
+	"\bitem_ids\x18\a \x03(\x05R\aitemIds\x12\x1a\n" +
+	"\bstacking\x18\b \x01(\x05R\bstacking\"g\n" +
 	"\x17GetPlayerRecordsRequest\x12\x1b\n" +
 	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x12\n" +
 	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
@@ -6319,34 +8619,91 @@ const file_game_proto_rawDesc = "" +
 	"\vusage_count\x18\x02 \x01(\x05R\n" +
 	"usageCount\x12\x1b\n" +
 	"\thit_count\x18\x03 \x01(\x05R\bhitCount\x12!\n" +
-	"\ftotal_damage\x18\x04 \x01(\x05R\vtotalDamage\"\x88\x01\n" +
+	"\ftotal_damage\x18\x04 \x01(\x05R\vtotalDamage\"\xbd\x01\n" +
 	"\x15GetRankingListRequest\x12\x1b\n" +
 	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12!\n" +
 	"\franking_type\x18\x02 \x01(\x05R\vrankingType\x12\x12\n" +
 	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\xb5\x01\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x16\n" +
+	"\x06period\x18\x05 \x01(\x05R\x06period\x12\x1b\n" +
+	"\tperiod_id\x18\x06 \x01(\tR\bperiodId\"\xd2\x01\n" +
 	"\x16GetRankingListResponse\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12%\n" +
 	"\x05items\x18\x03 \x03(\v2\x0f.pb.RankingItemR\x05items\x12\x14\n" +
 	"\x05total\x18\x04 \x01(\x05R\x05total\x120\n" +
 	"\vplayer_item\x18\x05 \x01(\v2\x0f.pb.RankingItemR\n" +
-	"playerItem\"\x9e\x01\n" +
+	"playerItem\x12\x1b\n" +
+	"\tperiod_id\x18\x06 \x01(\tR\bperiodId\"\x9e\x01\n" +
 	"\vRankingItem\x12\x12\n" +
 	"\x04rank\x18\x01 \x01(\x05R\x04rank\x12\x1b\n" +
 	"\tplayer_id\x18\x02 \x01(\tR\bplayerId\x12\x1a\n" +
 	"\bnickname\x18\x03 \x01(\tR\bnickname\x12\x14\n" +
 	"\x05level\x18\x04 \x01(\x05R\x05level\x12\x14\n" +
 	"\x05score\x18\x05 \x01(\x05R\x05score\x12\x16\n" +
-	"\x06avatar\x18\x06 \x01(\tR\x06avatar\"V\n" +
+	"\x06avatar\x18\x06 \x01(\tR\x06avatar\"n\n" +
 	"\x14GetPlayerRankRequest\x12\x1b\n" +
 	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12!\n" +
-	"\franking_type\x18\x02 \x01(\x05R\vrankingType\"\x8f\x01\n" +
+	"\franking_type\x18\x02 \x01(\x05R\vrankingType\x12\x16\n" +
+	"\x06period\x18\x03 \x01(\x05R\x06period\"\x8f\x01\n" +
 	"\x15GetPlayerRankResponse\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12#\n" +
 	"\x04item\x18\x03 \x01(\v2\x0f.pb.RankingItemR\x04item\x12#\n" +
-	"\rtotal_players\x18\x04 \x01(\x05R\ftotalPlayers\"\xba\x01\n" +
+	"\rtotal_players\x18\x04 \x01(\x05R\ftotalPlayers\"\x9d\x01\n" +
+	"\x17GetAroundRankingRequest\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12!\n" +
+	"\franking_type\x18\x02 \x01(\x05R\vrankingType\x12\x16\n" +
+	"\x06period\x18\x03 \x01(\x05R\x06period\x12\x14\n" +
+	"\x05above\x18\x04 \x01(\x05R\x05above\x12\x14\n" +
+	"\x05below\x18\x05 \x01(\x05R\x05below\"\x8f\x01\n" +
+	"\x16GetGroupRankingRequest\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12!\n" +
+	"\franking_type\x18\x02 \x01(\x05R\vrankingType\x12\x16\n" +
+	"\x06period\x18\x03 \x01(\x05R\x06period\x12\x1d\n" +
+	"\n" +
+	"player_ids\x18\x04 \x03(\tR\tplayerIds\"0\n" +
+	"\x11GetBalanceRequest\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\"p\n" +
+	"\x12GetBalanceResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x12\n" +
+	"\x04coin\x18\x03 \x01(\x03R\x04coin\x12\x18\n" +
+	"\adiamond\x18\x04 \x01(\x03R\adiamond\"\xc8\x01\n" +
+	"\x13WalletChangeRequest\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12#\n" +
+	"\rcurrency_type\x18\x02 \x01(\x05R\fcurrencyType\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x03R\x06amount\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\x05R\x06reason\x12'\n" +
+	"\x0fidempotency_key\x18\x05 \x01(\tR\x0eidempotencyKey\x12\x16\n" +
+	"\x06remark\x18\x06 \x01(\tR\x06remark\"\xa5\x01\n" +
+	"\x14WalletChangeResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12%\n" +
+	"\x0etransaction_id\x18\x03 \x01(\tR\rtransactionId\x12\x18\n" +
+	"\abalance\x18\x04 \x01(\x03R\abalance\x12\x1e\n" +
+	"\n" +
+	"duplicated\x18\x05 \x01(\bR\n" +
+	"duplicated\"\x8b\x01\n" +
+	"\x16GetTransactionsRequest\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12#\n" +
+	"\rcurrency_type\x18\x02 \x01(\x05R\fcurrencyType\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\x98\x01\n" +
+	"\x17GetTransactionsResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x129\n" +
+	"\ftransactions\x18\x03 \x03(\v2\x15.pb.WalletTransactionR\ftransactions\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x05R\x05total\"\xcb\x01\n" +
+	"\x11WalletTransaction\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12#\n" +
+	"\rcurrency_type\x18\x02 \x01(\x05R\fcurrencyType\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x03R\x06amount\x12\x18\n" +
+	"\abalance\x18\x04 \x01(\x03R\abalance\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\x05R\x06reason\x12\x16\n" +
+	"\x06remark\x18\x06 \x01(\tR\x06remark\x12\x1f\n" +
+	"\vcreate_time\x18\a \x01(\x03R\n" +
+	"createTime\"\xba\x01\n" +
 	"\x18ProcessBattleDataRequest\x12\x1b\n" +
 	"\tbattle_id\x18\x01 \x01(\tR\bbattleId\x12(\n" +
 	"\x06result\x18\x02 \x01(\v2\x10.pb.BattleResultR\x06result\x124\n" +
@@ -6359,14 +8716,16 @@ const file_game_proto_rawDesc = "" +
 	"actionType\x12\x1c\n" +
 	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp\x12\x1b\n" +
 	"\ttarget_id\x18\x04 \x01(\tR\btargetId\x12\x16\n" +
-	"\x06damage\x18\x05 \x01(\x05R\x06damage2\xa9\x02\n" +
+	"\x06damage\x18\x05 \x01(\x05R\x06damage2\xe4\x02\n" +
 	"\fLoginService\x127\n" +
 	"\bRegister\x12\x13.pb.RegisterRequest\x1a\x14.pb.RegisterResponse\"\x00\x12.\n" +
 	"\x05Login\x12\x10.pb.LoginRequest\x1a\x11.pb.LoginResponse\"\x00\x126\n" +
 	"\tReconnect\x12\x14.pb.ReconnectRequest\x1a\x11.pb.LoginResponse\"\x00\x129\n" +
 	"\n" +
 	"KickPlayer\x12\x15.pb.KickPlayerRequest\x1a\x12.pb.CommonResponse\"\x00\x12=\n" +
-	"\fGetQueueInfo\x12\x14.pb.QueueInfoRequest\x1a\x15.pb.QueueInfoResponse\"\x002\xcf\x03\n" +
+	"\fGetQueueInfo\x12\x14.pb.QueueInfoRequest\x1a\x15.pb.QueueInfoResponse\"\x00\x129\n" +
+	"\n" +
+	"LeaveQueue\x12\x15.pb.LeaveQueueRequest\x1a\x12.pb.CommonResponse\"\x002\xcf\x03\n" +
 	"\rBattleService\x12=\n" +
 	"\n" +
 	"CreateRoom\x12\x15.pb.CreateRoomRequest\x1a\x16.pb.CreateRoomResponse\"\x00\x127\n" +
@@ -6382,13 +8741,17 @@ const file_game_proto_rawDesc = "" +
 	"GetBagInfo\x12\x11.pb.GetBagRequest\x1a\x0f.pb.BagResponse\"\x00\x124\n" +
 	"\aUseItem\x12\x12.pb.UseItemRequest\x1a\x13.pb.UseItemResponse\"\x00\x125\n" +
 	"\bDropItem\x12\x13.pb.DropItemRequest\x1a\x12.pb.CommonResponse\"\x00\x12C\n" +
-	"\fCombineItems\x12\x17.pb.CombineItemsRequest\x1a\x18.pb.CombineItemsResponse\"\x002\xb2\x02\n" +
+	"\fCombineItems\x12\x17.pb.CombineItemsRequest\x1a\x18.pb.CombineItemsResponse\"\x002\xe7\x04\n" +
 	"\vMailService\x12@\n" +
 	"\vGetMailList\x12\x16.pb.GetMailListRequest\x1a\x17.pb.GetMailListResponse\"\x00\x12F\n" +
 	"\rGetMailDetail\x12\x18.pb.GetMailDetailRequest\x1a\x19.pb.GetMailDetailResponse\"\x00\x12^\n" +
-	"\x15ReceiveMailAttachment\x12 .pb.ReceiveMailAttachmentRequest\x1a!.pb.ReceiveMailAttachmentResponse\"\x00\x129\n" +
+	"\x15ReceiveMailAttachment\x12 .pb.ReceiveMailAttachmentRequest\x1a!.pb.ReceiveMailAttachmentResponse\"\x00\x12j\n" +
+	"\x19ReceiveAllMailAttachments\x12$.pb.ReceiveAllMailAttachmentsRequest\x1a%.pb.ReceiveAllMailAttachmentsResponse\"\x00\x129\n" +
 	"\n" +
-	"DeleteMail\x12\x15.pb.DeleteMailRequest\x1a\x12.pb.CommonResponse\"\x002\xcc\x02\n" +
+	"DeleteMail\x12\x15.pb.DeleteMailRequest\x1a\x12.pb.CommonResponse\"\x00\x127\n" +
+	"\bSendMail\x12\x13.pb.SendMailRequest\x1a\x14.pb.SendMailResponse\"\x00\x12I\n" +
+	"\x11SendBroadcastMail\x12\x1c.pb.SendBroadcastMailRequest\x1a\x14.pb.SendMailResponse\"\x00\x12C\n" +
+	"\fGetMailBadge\x12\x17.pb.GetMailBadgeRequest\x1a\x18.pb.GetMailBadgeResponse\"\x002\xcc\x02\n" +
 	"\vTaskService\x12@\n" +
 	"\vGetTaskList\x12\x16.pb.GetTaskListRequest\x1a\x17.pb.GetTaskListResponse\"\x00\x12F\n" +
 	"\rGetTaskDetail\x12\x18.pb.GetTaskDetailRequest\x1a\x19.pb.GetTaskDetailResponse\"\x00\x129\n" +
@@ -6397,17 +8760,27 @@ const file_game_proto_rawDesc = "" +
 	"\n" +
 	"SubmitTask\x12\x15.pb.SubmitTaskRequest\x1a\x16.pb.SubmitTaskResponse\"\x00\x129\n" +
 	"\n" +
-	"GiveUpTask\x12\x15.pb.GiveUpTaskRequest\x1a\x12.pb.CommonResponse\"\x002\xd3\x01\n" +
+	"GiveUpTask\x12\x15.pb.GiveUpTaskRequest\x1a\x12.pb.CommonResponse\"\x002\xe3\x02\n" +
 	"\vShopService\x12@\n" +
 	"\vGetShopList\x12\x16.pb.GetShopListRequest\x1a\x17.pb.GetShopListResponse\"\x00\x124\n" +
 	"\aBuyItem\x12\x12.pb.BuyItemRequest\x1a\x13.pb.BuyItemResponse\"\x00\x12L\n" +
-	"\x0fGetDiscountInfo\x12\x1a.pb.GetDiscountInfoRequest\x1a\x1b.pb.GetDiscountInfoResponse\"\x002\xae\x01\n" +
+	"\x0fGetDiscountInfo\x12\x1a.pb.GetDiscountInfoRequest\x1a\x1b.pb.GetDiscountInfoResponse\"\x00\x12@\n" +
+	"\vRefreshShop\x12\x16.pb.RefreshShopRequest\x1a\x17.pb.RefreshShopResponse\"\x00\x12L\n" +
+	"\x0fRefundShopOrder\x12\x1a.pb.RefundShopOrderRequest\x1a\x1b.pb.RefundShopOrderResponse\"\x002\xae\x01\n" +
 	"\rRecordService\x12O\n" +
 	"\x10GetPlayerRecords\x12\x1b.pb.GetPlayerRecordsRequest\x1a\x1c.pb.GetPlayerRecordsResponse\"\x00\x12L\n" +
-	"\x0fGetBattleDetail\x12\x1a.pb.GetBattleDetailRequest\x1a\x1b.pb.GetBattleDetailResponse\"\x002\xa3\x01\n" +
+	"\x0fGetBattleDetail\x12\x1a.pb.GetBattleDetailRequest\x1a\x1b.pb.GetBattleDetailResponse\"\x002\xbf\x02\n" +
 	"\x0eRankingService\x12I\n" +
 	"\x0eGetRankingList\x12\x19.pb.GetRankingListRequest\x1a\x1a.pb.GetRankingListResponse\"\x00\x12F\n" +
-	"\rGetPlayerRank\x12\x18.pb.GetPlayerRankRequest\x1a\x19.pb.GetPlayerRankResponse\"\x002c\n" +
+	"\rGetPlayerRank\x12\x18.pb.GetPlayerRankRequest\x1a\x19.pb.GetPlayerRankResponse\"\x00\x12M\n" +
+	"\x10GetAroundRanking\x12\x1b.pb.GetAroundRankingRequest\x1a\x1a.pb.GetRankingListResponse\"\x00\x12K\n" +
+	"\x0fGetGroupRanking\x12\x1a.pb.GetGroupRankingRequest\x1a\x1a.pb.GetRankingListResponse\"\x002\x99\x02\n" +
+	"\rWalletService\x12=\n" +
+	"\n" +
+	"GetBalance\x12\x15.pb.GetBalanceRequest\x1a\x16.pb.GetBalanceResponse\"\x00\x12=\n" +
+	"\x06Credit\x12\x17.pb.WalletChangeRequest\x1a\x18.pb.WalletChangeResponse\"\x00\x12<\n" +
+	"\x05Debit\x12\x17.pb.WalletChangeRequest\x1a\x18.pb.WalletChangeResponse\"\x00\x12L\n" +
+	"\x0fGetTransactions\x12\x1a.pb.GetTransactionsRequest\x1a\x1b.pb.GetTransactionsResponse\"\x002c\n" +
 	"\x18BattleDataProcessService\x12G\n" +
 	"\x11ProcessBattleData\x12\x1c.pb.ProcessBattleDataRequest\x1a\x12.pb.CommonResponse\"\x00B\x06Z\x04./pbb\x06proto3"
 
@@ -6423,216 +8796,284 @@ func file_game_proto_rawDescGZIP() []byte {
 	return file_game_proto_rawDescData
 }
 
-var file_game_proto_msgTypes = make([]protoimpl.MessageInfo, 92)
+var file_game_proto_msgTypes = make([]protoimpl.MessageInfo, 122)
 var file_game_proto_goTypes = []any{
-	(*CommonResponse)(nil),                // 0: pb.CommonResponse
-	(*RegisterRequest)(nil),               // 1: pb.RegisterRequest
-	(*RegisterResponse)(nil),              // 2: pb.RegisterResponse
-	(*LoginRequest)(nil),                  // 3: pb.LoginRequest
-	(*LoginResponse)(nil),                 // 4: pb.LoginResponse
-	(*ReconnectRequest)(nil),              // 5: pb.ReconnectRequest
-	(*KickPlayerRequest)(nil),             // 6: pb.KickPlayerRequest
-	(*QueueInfoRequest)(nil),              // 7: pb.QueueInfoRequest
-	(*QueueInfoResponse)(nil),             // 8: pb.QueueInfoResponse
-	(*PlayerInfo)(nil),                    // 9: pb.PlayerInfo
-	(*CreateRoomRequest)(nil),             // 10: pb.CreateRoomRequest
-	(*CreateRoomResponse)(nil),            // 11: pb.CreateRoomResponse
-	(*JoinRoomRequest)(nil),               // 12: pb.JoinRoomRequest
-	(*JoinRoomResponse)(nil),              // 13: pb.JoinRoomResponse
-	(*LeaveRoomRequest)(nil),              // 14: pb.LeaveRoomRequest
-	(*StartBattleRequest)(nil),            // 15: pb.StartBattleRequest
-	(*StartBattleResponse)(nil),           // 16: pb.StartBattleResponse
-	(*EndBattleRequest)(nil),              // 17: pb.EndBattleRequest
-	(*EndBattleResponse)(nil),             // 18: pb.EndBattleResponse
-	(*ReconnectBattleRequest)(nil),        // 19: pb.ReconnectBattleRequest
-	(*BattleStateResponse)(nil),           // 20: pb.BattleStateResponse
-	(*SyncBattleActionRequest)(nil),       // 21: pb.SyncBattleActionRequest
-	(*RoomInfo)(nil),                      // 22: pb.RoomInfo
-	(*RoomPlayer)(nil),                    // 23: pb.RoomPlayer
-	(*BattleConfig)(nil),                  // 24: pb.BattleConfig
-	(*BattlePlayer)(nil),                  // 25: pb.BattlePlayer
-	(*Position)(nil),                      // 26: pb.Position
-	(*BattleAction)(nil),                  // 27: pb.BattleAction
-	(*BattleResult)(nil),                  // 28: pb.BattleResult
-	(*PlayerBattleStats)(nil),             // 29: pb.PlayerBattleStats
-	(*BattleState)(nil),                   // 30: pb.BattleState
-	(*LivePlayerState)(nil),               // 31: pb.LivePlayerState
-	(*Rewards)(nil),                       // 32: pb.Rewards
-	(*RewardItem)(nil),                    // 33: pb.RewardItem
-	(*GetBagRequest)(nil),                 // 34: pb.GetBagRequest
-	(*BagResponse)(nil),                   // 35: pb.BagResponse
-	(*BagItem)(nil),                       // 36: pb.BagItem
-	(*UseItemRequest)(nil),                // 37: pb.UseItemRequest
-	(*UseItemResponse)(nil),               // 38: pb.UseItemResponse
-	(*DropItemRequest)(nil),               // 39: pb.DropItemRequest
-	(*CombineItemsRequest)(nil),           // 40: pb.CombineItemsRequest
-	(*CombineItemsResponse)(nil),          // 41: pb.CombineItemsResponse
-	(*GetMailListRequest)(nil),            // 42: pb.GetMailListRequest
-	(*GetMailListResponse)(nil),           // 43: pb.GetMailListResponse
-	(*MailBrief)(nil),                     // 44: pb.MailBrief
-	(*GetMailDetailRequest)(nil),          // 45: pb.GetMailDetailRequest
-	(*GetMailDetailResponse)(nil),         // 46: pb.GetMailDetailResponse
-	(*Mail)(nil),                          // 47: pb.Mail
-	(*MailAttachment)(nil),                // 48: pb.MailAttachment
-	(*ReceiveMailAttachmentRequest)(nil),  // 49: pb.ReceiveMailAttachmentRequest
-	(*ReceiveMailAttachmentResponse)(nil), // 50: pb.ReceiveMailAttachmentResponse
-	(*DeleteMailRequest)(nil),             // 51: pb.DeleteMailRequest
-	(*GetTaskListRequest)(nil),            // 52: pb.GetTaskListRequest
-	(*GetTaskListResponse)(nil),           // 53: pb.GetTaskListResponse
-	(*TaskBrief)(nil),                     // 54: pb.TaskBrief
-	(*GetTaskDetailRequest)(nil),          // 55: pb.GetTaskDetailRequest
-	(*GetTaskDetailResponse)(nil),         // 56: pb.GetTaskDetailResponse
-	(*TaskDetail)(nil),                    // 57: pb.TaskDetail
-	(*TaskReward)(nil),                    // 58: pb.TaskReward
-	(*AcceptTaskRequest)(nil),             // 59: pb.AcceptTaskRequest
-	(*SubmitTaskRequest)(nil),             // 60: pb.SubmitTaskRequest
-	(*SubmitTaskResponse)(nil),            // 61: pb.SubmitTaskResponse
-	(*GiveUpTaskRequest)(nil),             // 62: pb.GiveUpTaskRequest
-	(*GetShopListRequest)(nil),            // 63: pb.GetShopListRequest
-	(*GetShopListResponse)(nil),           // 64: pb.GetShopListResponse
-	(*ShopItem)(nil),                      // 65: pb.ShopItem
-	(*BuyItemRequest)(nil),                // 66: pb.BuyItemRequest
-	(*BuyItemResponse)(nil),               // 67: pb.BuyItemResponse
-	(*GetDiscountInfoRequest)(nil),        // 68: pb.GetDiscountInfoRequest
-	(*GetDiscountInfoResponse)(nil),       // 69: pb.GetDiscountInfoResponse
-	(*DiscountInfo)(nil),                  // 70: pb.DiscountInfo
-	(*GetPlayerRecordsRequest)(nil),       // 71: pb.GetPlayerRecordsRequest
-	(*GetPlayerRecordsResponse)(nil),      // 72: pb.GetPlayerRecordsResponse
-	(*BattleRecord)(nil),                  // 73: pb.BattleRecord
-	(*GetBattleDetailRequest)(nil),        // 74: pb.GetBattleDetailRequest
-	(*GetBattleDetailResponse)(nil),       // 75: pb.GetBattleDetailResponse
-	(*BattleDetail)(nil),                  // 76: pb.BattleDetail
-	(*DetailedPlayerStats)(nil),           // 77: pb.DetailedPlayerStats
-	(*PlayerStats)(nil),                   // 78: pb.PlayerStats
-	(*BossStats)(nil),                     // 79: pb.BossStats
-	(*SkillUsage)(nil),                    // 80: pb.SkillUsage
-	(*GetRankingListRequest)(nil),         // 81: pb.GetRankingListRequest
-	(*GetRankingListResponse)(nil),        // 82: pb.GetRankingListResponse
-	(*RankingItem)(nil),                   // 83: pb.RankingItem
-	(*GetPlayerRankRequest)(nil),          // 84: pb.GetPlayerRankRequest
-	(*GetPlayerRankResponse)(nil),         // 85: pb.GetPlayerRankResponse
-	(*ProcessBattleDataRequest)(nil),      // 86: pb.ProcessBattleDataRequest
-	(*BattleActionLog)(nil),               // 87: pb.BattleActionLog
-	nil,                                   // 88: pb.BagItem.AttrsEntry
-	nil,                                   // 89: pb.UseItemResponse.EffectsEntry
-	nil,                                   // 90: pb.TaskDetail.TargetsEntry
-	nil,                                   // 91: pb.TaskDetail.ProgressEntry
+	(*CommonResponse)(nil),                    // 0: pb.CommonResponse
+	(*RegisterRequest)(nil),                   // 1: pb.RegisterRequest
+	(*RegisterResponse)(nil),                  // 2: pb.RegisterResponse
+	(*LoginRequest)(nil),                      // 3: pb.LoginRequest
+	(*LoginResponse)(nil),                     // 4: pb.LoginResponse
+	(*ReconnectRequest)(nil),                  // 5: pb.ReconnectRequest
+	(*KickPlayerRequest)(nil),                 // 6: pb.KickPlayerRequest
+	(*QueueInfoRequest)(nil),                  // 7: pb.QueueInfoRequest
+	(*QueueInfoResponse)(nil),                 // 8: pb.QueueInfoResponse
+	(*LeaveQueueRequest)(nil),                 // 9: pb.LeaveQueueRequest
+	(*AuthRequest)(nil),                       // 10: pb.AuthRequest
+	(*AuthResponse)(nil),                      // 11: pb.AuthResponse
+	(*QueueAdmittedNotify)(nil),               // 12: pb.QueueAdmittedNotify
+	(*QueueRejectedNotify)(nil),               // 13: pb.QueueRejectedNotify
+	(*KickNotify)(nil),                        // 14: pb.KickNotify
+	(*PlayerInfo)(nil),                        // 15: pb.PlayerInfo
+	(*CreateRoomRequest)(nil),                 // 16: pb.CreateRoomRequest
+	(*CreateRoomResponse)(nil),                // 17: pb.CreateRoomResponse
+	(*JoinRoomRequest)(nil),                   // 18: pb.JoinRoomRequest
+	(*JoinRoomResponse)(nil),                  // 19: pb.JoinRoomResponse
+	(*LeaveRoomRequest)(nil),                  // 20: pb.LeaveRoomRequest
+	(*StartBattleRequest)(nil),                // 21: pb.StartBattleRequest
+	(*StartBattleResponse)(nil),               // 22: pb.StartBattleResponse
+	(*EndBattleRequest)(nil),                  // 23: pb.EndBattleRequest
+	(*EndBattleResponse)(nil),                 // 24: pb.EndBattleResponse
+	(*ReconnectBattleRequest)(nil),            // 25: pb.ReconnectBattleRequest
+	(*BattleStateResponse)(nil),               // 26: pb.BattleStateResponse
+	(*SyncBattleActionRequest)(nil),           // 27: pb.SyncBattleActionRequest
+	(*RoomInfo)(nil),                          // 28: pb.RoomInfo
+	(*RoomPlayer)(nil),                        // 29: pb.RoomPlayer
+	(*BattleConfig)(nil),                      // 30: pb.BattleConfig
+	(*BattlePlayer)(nil),                      // 31: pb.BattlePlayer
+	(*Position)(nil),                          // 32: pb.Position
+	(*BattleAction)(nil),                      // 33: pb.BattleAction
+	(*BattleResult)(nil),                      // 34: pb.BattleResult
+	(*PlayerBattleStats)(nil),                 // 35: pb.PlayerBattleStats
+	(*BattleState)(nil),                       // 36: pb.BattleState
+	(*LivePlayerState)(nil),                   // 37: pb.LivePlayerState
+	(*Rewards)(nil),                           // 38: pb.Rewards
+	(*RewardItem)(nil),                        // 39: pb.RewardItem
+	(*GetBagRequest)(nil),                     // 40: pb.GetBagRequest
+	(*BagResponse)(nil),                       // 41: pb.BagResponse
+	(*BagItem)(nil),                           // 42: pb.BagItem
+	(*UseItemRequest)(nil),                    // 43: pb.UseItemRequest
+	(*UseItemResponse)(nil),                   // 44: pb.UseItemResponse
+	(*DropItemRequest)(nil),                   // 45: pb.DropItemRequest
+	(*CombineItemsRequest)(nil),               // 46: pb.CombineItemsRequest
+	(*CombineItemsResponse)(nil),              // 47: pb.CombineItemsResponse
+	(*GetMailListRequest)(nil),                // 48: pb.GetMailListRequest
+	(*GetMailListResponse)(nil),               // 49: pb.GetMailListResponse
+	(*MailBrief)(nil),                         // 50: pb.MailBrief
+	(*GetMailDetailRequest)(nil),              // 51: pb.GetMailDetailRequest
+	(*GetMailDetailResponse)(nil),             // 52: pb.GetMailDetailResponse
+	(*Mail)(nil),                              // 53: pb.Mail
+	(*MailAttachment)(nil),                    // 54: pb.MailAttachment
+	(*ReceiveMailAttachmentRequest)(nil),      // 55: pb.ReceiveMailAttachmentRequest
+	(*ReceiveMailAttachmentResponse)(nil),     // 56: pb.ReceiveMailAttachmentResponse
+	(*ReceiveAllMailAttachmentsRequest)(nil),  // 57: pb.ReceiveAllMailAttachmentsRequest
+	(*ReceiveAllMailAttachmentsResponse)(nil), // 58: pb.ReceiveAllMailAttachmentsResponse
+	(*DeleteMailRequest)(nil),                 // 59: pb.DeleteMailRequest
+	(*SendMailRequest)(nil),                   // 60: pb.SendMailRequest
+	(*SendMailResponse)(nil),                  // 61: pb.SendMailResponse
+	(*SendBroadcastMailRequest)(nil),          // 62: pb.SendBroadcastMailRequest
+	(*GetMailBadgeRequest)(nil),               // 63: pb.GetMailBadgeRequest
+	(*GetMailBadgeResponse)(nil),              // 64: pb.GetMailBadgeResponse
+	(*NewMailNotify)(nil),                     // 65: pb.NewMailNotify
+	(*GetTaskListRequest)(nil),                // 66: pb.GetTaskListRequest
+	(*GetTaskListResponse)(nil),               // 67: pb.GetTaskListResponse
+	(*TaskBrief)(nil),                         // 68: pb.TaskBrief
+	(*GetTaskDetailRequest)(nil),              // 69: pb.GetTaskDetailRequest
+	(*GetTaskDetailResponse)(nil),             // 70: pb.GetTaskDetailResponse
+	(*TaskDetail)(nil),                        // 71: pb.TaskDetail
+	(*TaskReward)(nil),                        // 72: pb.TaskReward
+	(*AcceptTaskRequest)(nil),                 // 73: pb.AcceptTaskRequest
+	(*SubmitTaskRequest)(nil),                 // 74: pb.SubmitTaskRequest
+	(*SubmitTaskResponse)(nil),                // 75: pb.SubmitTaskResponse
+	(*GiveUpTaskRequest)(nil),                 // 76: pb.GiveUpTaskRequest
+	(*GetShopListRequest)(nil),                // 77: pb.GetShopListRequest
+	(*GetShopListResponse)(nil),               // 78: pb.GetShopListResponse
+	(*RotationInfo)(nil),                      // 79: pb.RotationInfo
+	(*RefreshShopRequest)(nil),                // 80: pb.RefreshShopRequest
+	(*RefreshShopResponse)(nil),               // 81: pb.RefreshShopResponse
+	(*ShopItem)(nil),                          // 82: pb.ShopItem
+	(*ShopGrant)(nil),                         // 83: pb.ShopGrant
+	(*BuyItemRequest)(nil),                    // 84: pb.BuyItemRequest
+	(*BuyItemResponse)(nil),                   // 85: pb.BuyItemResponse
+	(*RefundShopOrderRequest)(nil),            // 86: pb.RefundShopOrderRequest
+	(*RefundShopOrderResponse)(nil),           // 87: pb.RefundShopOrderResponse
+	(*ShopOrder)(nil),                         // 88: pb.ShopOrder
+	(*GetDiscountInfoRequest)(nil),            // 89: pb.GetDiscountInfoRequest
+	(*GetDiscountInfoResponse)(nil),           // 90: pb.GetDiscountInfoResponse
+	(*DiscountInfo)(nil),                      // 91: pb.DiscountInfo
+	(*GetPlayerRecordsRequest)(nil),           // 92: pb.GetPlayerRecordsRequest
+	(*GetPlayerRecordsResponse)(nil),          // 93: pb.GetPlayerRecordsResponse
+	(*BattleRecord)(nil),                      // 94: pb.BattleRecord
+	(*GetBattleDetailRequest)(nil),            // 95: pb.GetBattleDetailRequest
+	(*GetBattleDetailResponse)(nil),           // 96: pb.GetBattleDetailResponse
+	(*BattleDetail)(nil),                      // 97: pb.BattleDetail
+	(*DetailedPlayerStats)(nil),               // 98: pb.DetailedPlayerStats
+	(*PlayerStats)(nil),                       // 99: pb.PlayerStats
+	(*BossStats)(nil),                         // 100: pb.BossStats
+	(*SkillUsage)(nil),                        // 101: pb.SkillUsage
+	(*GetRankingListRequest)(nil),             // 102: pb.GetRankingListRequest
+	(*GetRankingListResponse)(nil),            // 103: pb.GetRankingListResponse
+	(*RankingItem)(nil),                       // 104: pb.RankingItem
+	(*GetPlayerRankRequest)(nil),              // 105: pb.GetPlayerRankRequest
+	(*GetPlayerRankResponse)(nil),             // 106: pb.GetPlayerRankResponse
+	(*GetAroundRankingRequest)(nil),           // 107: pb.GetAroundRankingRequest
+	(*GetGroupRankingRequest)(nil),            // 108: pb.GetGroupRankingRequest
+	(*GetBalanceRequest)(nil),                 // 109: pb.GetBalanceRequest
+	(*GetBalanceResponse)(nil),                // 110: pb.GetBalanceResponse
+	(*WalletChangeRequest)(nil),               // 111: pb.WalletChangeRequest
+	(*WalletChangeResponse)(nil),              // 112: pb.WalletChangeResponse
+	(*GetTransactionsRequest)(nil),            // 113: pb.GetTransactionsRequest
+	(*GetTransactionsResponse)(nil),           // 114: pb.GetTransactionsResponse
+	(*WalletTransaction)(nil),                 // 115: pb.WalletTransaction
+	(*ProcessBattleDataRequest)(nil),          // 116: pb.ProcessBattleDataRequest
+	(*BattleActionLog)(nil),                   // 117: pb.BattleActionLog
+	nil,                                       // 118: pb.BagItem.AttrsEntry
+	nil,                                       // 119: pb.UseItemResponse.EffectsEntry
+	nil,                                       // 120: pb.TaskDetail.TargetsEntry
+	nil,                                       // 121: pb.TaskDetail.ProgressEntry
 }
 var file_game_proto_depIdxs = []int32{
-	9,  // 0: pb.LoginResponse.player:type_name -> pb.PlayerInfo
-	22, // 1: pb.JoinRoomResponse.room:type_name -> pb.RoomInfo
-	24, // 2: pb.StartBattleResponse.config:type_name -> pb.BattleConfig
-	28, // 3: pb.EndBattleRequest.result:type_name -> pb.BattleResult
-	32, // 4: pb.EndBattleResponse.rewards:type_name -> pb.Rewards
-	30, // 5: pb.BattleStateResponse.state:type_name -> pb.BattleState
-	27, // 6: pb.SyncBattleActionRequest.actions:type_name -> pb.BattleAction
-	23, // 7: pb.RoomInfo.players:type_name -> pb.RoomPlayer
-	25, // 8: pb.BattleConfig.players:type_name -> pb.BattlePlayer
-	26, // 9: pb.BattlePlayer.position:type_name -> pb.Position
-	26, // 10: pb.BattleAction.position:type_name -> pb.Position
-	29, // 11: pb.BattleResult.player_stats:type_name -> pb.PlayerBattleStats
-	31, // 12: pb.BattleState.players:type_name -> pb.LivePlayerState
-	26, // 13: pb.LivePlayerState.position:type_name -> pb.Position
-	33, // 14: pb.Rewards.items:type_name -> pb.RewardItem
-	36, // 15: pb.BagResponse.items:type_name -> pb.BagItem
-	88, // 16: pb.BagItem.attrs:type_name -> pb.BagItem.AttrsEntry
-	89, // 17: pb.UseItemResponse.effects:type_name -> pb.UseItemResponse.EffectsEntry
-	36, // 18: pb.CombineItemsResponse.result_item:type_name -> pb.BagItem
-	44, // 19: pb.GetMailListResponse.mails:type_name -> pb.MailBrief
-	47, // 20: pb.GetMailDetailResponse.mail:type_name -> pb.Mail
-	48, // 21: pb.Mail.attachments:type_name -> pb.MailAttachment
-	48, // 22: pb.ReceiveMailAttachmentResponse.attachments:type_name -> pb.MailAttachment
-	54, // 23: pb.GetTaskListResponse.tasks:type_name -> pb.TaskBrief
-	57, // 24: pb.GetTaskDetailResponse.task:type_name -> pb.TaskDetail
-	90, // 25: pb.TaskDetail.targets:type_name -> pb.TaskDetail.TargetsEntry
-	91, // 26: pb.TaskDetail.progress:type_name -> pb.TaskDetail.ProgressEntry
-	58, // 27: pb.TaskDetail.rewards:type_name -> pb.TaskReward
-	58, // 28: pb.SubmitTaskResponse.rewards:type_name -> pb.TaskReward
-	65, // 29: pb.GetShopListResponse.items:type_name -> pb.ShopItem
-	36, // 30: pb.BuyItemResponse.bought_items:type_name -> pb.BagItem
-	70, // 31: pb.GetDiscountInfoResponse.discounts:type_name -> pb.DiscountInfo
-	73, // 32: pb.GetPlayerRecordsResponse.records:type_name -> pb.BattleRecord
-	78, // 33: pb.GetPlayerRecordsResponse.stats:type_name -> pb.PlayerStats
-	76, // 34: pb.GetBattleDetailResponse.detail:type_name -> pb.BattleDetail
-	77, // 35: pb.BattleDetail.players:type_name -> pb.DetailedPlayerStats
-	79, // 36: pb.BattleDetail.boss:type_name -> pb.BossStats
-	80, // 37: pb.BossStats.skills:type_name -> pb.SkillUsage
-	83, // 38: pb.GetRankingListResponse.items:type_name -> pb.RankingItem
-	83, // 39: pb.GetRankingListResponse.player_item:type_name -> pb.RankingItem
-	83, // 40: pb.GetPlayerRankResponse.item:type_name -> pb.RankingItem
-	28, // 41: pb.ProcessBattleDataRequest.result:type_name -> pb.BattleResult
-	87, // 42: pb.ProcessBattleDataRequest.action_logs:type_name -> pb.BattleActionLog
-	1,  // 43: pb.LoginService.Register:input_type -> pb.RegisterRequest
-	3,  // 44: pb.LoginService.Login:input_type -> pb.LoginRequest
-	5,  // 45: pb.LoginService.Reconnect:input_type -> pb.ReconnectRequest
-	6,  // 46: pb.LoginService.KickPlayer:input_type -> pb.KickPlayerRequest
-	7,  // 47: pb.LoginService.GetQueueInfo:input_type -> pb.QueueInfoRequest
-	10, // 48: pb.BattleService.CreateRoom:input_type -> pb.CreateRoomRequest
-	12, // 49: pb.BattleService.JoinRoom:input_type -> pb.JoinRoomRequest
-	14, // 50: pb.BattleService.LeaveRoom:input_type -> pb.LeaveRoomRequest
-	15, // 51: pb.BattleService.StartBattle:input_type -> pb.StartBattleRequest
-	17, // 52: pb.BattleService.EndBattle:input_type -> pb.EndBattleRequest
-	19, // 53: pb.BattleService.ReconnectBattle:input_type -> pb.ReconnectBattleRequest
-	21, // 54: pb.BattleService.SyncBattleAction:input_type -> pb.SyncBattleActionRequest
-	34, // 55: pb.BagService.GetBagInfo:input_type -> pb.GetBagRequest
-	37, // 56: pb.BagService.UseItem:input_type -> pb.UseItemRequest
-	39, // 57: pb.BagService.DropItem:input_type -> pb.DropItemRequest
-	40, // 58: pb.BagService.CombineItems:input_type -> pb.CombineItemsRequest
-	42, // 59: pb.MailService.GetMailList:input_type -> pb.GetMailListRequest
-	45, // 60: pb.MailService.GetMailDetail:input_type -> pb.GetMailDetailRequest
-	49, // 61: pb.MailService.ReceiveMailAttachment:input_type -> pb.ReceiveMailAttachmentRequest
-	51, // 62: pb.MailService.DeleteMail:input_type -> pb.DeleteMailRequest
-	52, // 63: pb.TaskService.GetTaskList:input_type -> pb.GetTaskListRequest
-	55, // 64: pb.TaskService.GetTaskDetail:input_type -> pb.GetTaskDetailRequest
-	59, // 65: pb.TaskService.AcceptTask:input_type -> pb.AcceptTaskRequest
-	60, // 66: pb.TaskService.SubmitTask:input_type -> pb.SubmitTaskRequest
-	62, // 67: pb.TaskService.GiveUpTask:input_type -> pb.GiveUpTaskRequest
-	63, // 68: pb.ShopService.GetShopList:input_type -> pb.GetShopListRequest
-	66, // 69: pb.ShopService.BuyItem:input_type -> pb.BuyItemRequest
-	68, // 70: pb.ShopService.GetDiscountInfo:input_type -> pb.GetDiscountInfoRequest
-	71, // 71: pb.RecordService.GetPlayerRecords:input_type -> pb.GetPlayerRecordsRequest
-	74, // 72: pb.RecordService.GetBattleDetail:input_type -> pb.GetBattleDetailRequest
-	81, // 73: pb.RankingService.GetRankingList:input_type -> pb.GetRankingListRequest
-	84, // 74: pb.RankingService.GetPlayerRank:input_type -> pb.GetPlayerRankRequest
-	86, // 75: pb.BattleDataProcessService.ProcessBattleData:input_type -> pb.ProcessBattleDataRequest
-	2,  // 76: pb.LoginService.Register:output_type -> pb.RegisterResponse
-	4,  // 77: pb.LoginService.Login:output_type -> pb.LoginResponse
-	4,  // 78: pb.LoginService.Reconnect:output_type -> pb.LoginResponse
-	0,  // 79: pb.LoginService.KickPlayer:output_type -> pb.CommonResponse
-	8,  // 80: pb.LoginService.GetQueueInfo:output_type -> pb.QueueInfoResponse
-	11, // 81: pb.BattleService.CreateRoom:output_type -> pb.CreateRoomResponse
-	13, // 82: pb.BattleService.JoinRoom:output_type -> pb.JoinRoomResponse
-	0,  // 83: pb.BattleService.LeaveRoom:output_type -> pb.CommonResponse
-	16, // 84: pb.BattleService.StartBattle:output_type -> pb.StartBattleResponse
-	18, // 85: pb.BattleService.EndBattle:output_type -> pb.EndBattleResponse
-	20, // 86: pb.BattleService.ReconnectBattle:output_type -> pb.BattleStateResponse
-	0,  // 87: pb.BattleService.SyncBattleAction:output_type -> pb.CommonResponse
-	35, // 88: pb.BagService.GetBagInfo:output_type -> pb.BagResponse
-	38, // 89: pb.BagService.UseItem:output_type -> pb.UseItemResponse
-	0,  // 90: pb.BagService.DropItem:output_type -> pb.CommonResponse
-	41, // 91: pb.BagService.CombineItems:output_type -> pb.CombineItemsResponse
-	43, // 92: pb.MailService.GetMailList:output_type -> pb.GetMailListResponse
-	46, // 93: pb.MailService.GetMailDetail:output_type -> pb.GetMailDetailResponse
-	50, // 94: pb.MailService.ReceiveMailAttachment:output_type -> pb.ReceiveMailAttachmentResponse
-	0,  // 95: pb.MailService.DeleteMail:output_type -> pb.CommonResponse
-	53, // 96: pb.TaskService.GetTaskList:output_type -> pb.GetTaskListResponse
-	56, // 97: pb.TaskService.GetTaskDetail:output_type -> pb.GetTaskDetailResponse
-	0,  // 98: pb.TaskService.AcceptTask:output_type -> pb.CommonResponse
-	61, // 99: pb.TaskService.SubmitTask:output_type -> pb.SubmitTaskResponse
-	0,  // 100: pb.TaskService.GiveUpTask:output_type -> pb.CommonResponse
-	64, // 101: pb.ShopService.GetShopList:output_type -> pb.GetShopListResponse
-	67, // 102: pb.ShopService.BuyItem:output_type -> pb.BuyItemResponse
-	69, // 103: pb.ShopService.GetDiscountInfo:output_type -> pb.GetDiscountInfoResponse
-	72, // 104: pb.RecordService.GetPlayerRecords:output_type -> pb.GetPlayerRecordsResponse
-	75, // 105: pb.RecordService.GetBattleDetail:output_type -> pb.GetBattleDetailResponse
-	82, // 106: pb.RankingService.GetRankingList:output_type -> pb.GetRankingListResponse
-	85, // 107: pb.RankingService.GetPlayerRank:output_type -> pb.GetPlayerRankResponse
-	0,  // 108: pb.BattleDataProcessService.ProcessBattleData:output_type -> pb.CommonResponse
-	76, // [76:109] is the sub-list for method output_type
-	43, // [43:76] is the sub-list for method input_type
-	43, // [43:43] is the sub-list for extension type_name
-	43, // [43:43] is the sub-list for extension extendee
-	0,  // [0:43] is the sub-list for field type_name
+	15,  // 0: pb.LoginResponse.player:type_name -> pb.PlayerInfo
+	28,  // 1: pb.JoinRoomResponse.room:type_name -> pb.RoomInfo
+	30,  // 2: pb.StartBattleResponse.config:type_name -> pb.BattleConfig
+	34,  // 3: pb.EndBattleRequest.result:type_name -> pb.BattleResult
+	38,  // 4: pb.EndBattleResponse.rewards:type_name -> pb.Rewards
+	36,  // 5: pb.BattleStateResponse.state:type_name -> pb.BattleState
+	33,  // 6: pb.SyncBattleActionRequest.actions:type_name -> pb.BattleAction
+	29,  // 7: pb.RoomInfo.players:type_name -> pb.RoomPlayer
+	31,  // 8: pb.BattleConfig.players:type_name -> pb.BattlePlayer
+	32,  // 9: pb.BattlePlayer.position:type_name -> pb.Position
+	32,  // 10: pb.BattleAction.position:type_name -> pb.Position
+	35,  // 11: pb.BattleResult.player_stats:type_name -> pb.PlayerBattleStats
+	37,  // 12: pb.BattleState.players:type_name -> pb.LivePlayerState
+	32,  // 13: pb.LivePlayerState.position:type_name -> pb.Position
+	39,  // 14: pb.Rewards.items:type_name -> pb.RewardItem
+	42,  // 15: pb.BagResponse.items:type_name -> pb.BagItem
+	118, // 16: pb.BagItem.attrs:type_name -> pb.BagItem.AttrsEntry
+	119, // 17: pb.UseItemResponse.effects:type_name -> pb.UseItemResponse.EffectsEntry
+	42,  // 18: pb.CombineItemsResponse.result_item:type_name -> pb.BagItem
+	50,  // 19: pb.GetMailListResponse.mails:type_name -> pb.MailBrief
+	53,  // 20: pb.GetMailDetailResponse.mail:type_name -> pb.Mail
+	54,  // 21: pb.Mail.attachments:type_name -> pb.MailAttachment
+	54,  // 22: pb.ReceiveMailAttachmentResponse.attachments:type_name -> pb.MailAttachment
+	54,  // 23: pb.ReceiveAllMailAttachmentsResponse.attachments:type_name -> pb.MailAttachment
+	54,  // 24: pb.SendMailRequest.attachments:type_name -> pb.MailAttachment
+	54,  // 25: pb.SendBroadcastMailRequest.attachments:type_name -> pb.MailAttachment
+	68,  // 26: pb.GetTaskListResponse.tasks:type_name -> pb.TaskBrief
+	71,  // 27: pb.GetTaskDetailResponse.task:type_name -> pb.TaskDetail
+	120, // 28: pb.TaskDetail.targets:type_name -> pb.TaskDetail.TargetsEntry
+	121, // 29: pb.TaskDetail.progress:type_name -> pb.TaskDetail.ProgressEntry
+	72,  // 30: pb.TaskDetail.rewards:type_name -> pb.TaskReward
+	72,  // 31: pb.SubmitTaskResponse.rewards:type_name -> pb.TaskReward
+	82,  // 32: pb.GetShopListResponse.items:type_name -> pb.ShopItem
+	79,  // 33: pb.GetShopListResponse.rotation:type_name -> pb.RotationInfo
+	82,  // 34: pb.RefreshShopResponse.items:type_name -> pb.ShopItem
+	79,  // 35: pb.RefreshShopResponse.rotation:type_name -> pb.RotationInfo
+	83,  // 36: pb.ShopItem.contents:type_name -> pb.ShopGrant
+	83,  // 37: pb.ShopItem.first_bonus:type_name -> pb.ShopGrant
+	39,  // 38: pb.ShopGrant.items:type_name -> pb.RewardItem
+	42,  // 39: pb.BuyItemResponse.bought_items:type_name -> pb.BagItem
+	88,  // 40: pb.RefundShopOrderResponse.order:type_name -> pb.ShopOrder
+	83,  // 41: pb.ShopOrder.grant:type_name -> pb.ShopGrant
+	91,  // 42: pb.GetDiscountInfoResponse.discounts:type_name -> pb.DiscountInfo
+	94,  // 43: pb.GetPlayerRecordsResponse.records:type_name -> pb.BattleRecord
+	99,  // 44: pb.GetPlayerRecordsResponse.stats:type_name -> pb.PlayerStats
+	97,  // 45: pb.GetBattleDetailResponse.detail:type_name -> pb.BattleDetail
+	98,  // 46: pb.BattleDetail.players:type_name -> pb.DetailedPlayerStats
+	100, // 47: pb.BattleDetail.boss:type_name -> pb.BossStats
+	101, // 48: pb.BossStats.skills:type_name -> pb.SkillUsage
+	104, // 49: pb.GetRankingListResponse.items:type_name -> pb.RankingItem
+	104, // 50: pb.GetRankingListResponse.player_item:type_name -> pb.RankingItem
+	104, // 51: pb.GetPlayerRankResponse.item:type_name -> pb.RankingItem
+	115, // 52: pb.GetTransactionsResponse.transactions:type_name -> pb.WalletTransaction
+	34,  // 53: pb.ProcessBattleDataRequest.result:type_name -> pb.BattleResult
+	117, // 54: pb.ProcessBattleDataRequest.action_logs:type_name -> pb.BattleActionLog
+	1,   // 55: pb.LoginService.Register:input_type -> pb.RegisterRequest
+	3,   // 56: pb.LoginService.Login:input_type -> pb.LoginRequest
+	5,   // 57: pb.LoginService.Reconnect:input_type -> pb.ReconnectRequest
+	6,   // 58: pb.LoginService.KickPlayer:input_type -> pb.KickPlayerRequest
+	7,   // 59: pb.LoginService.GetQueueInfo:input_type -> pb.QueueInfoRequest
+	9,   // 60: pb.LoginService.LeaveQueue:input_type -> pb.LeaveQueueRequest
+	16,  // 61: pb.BattleService.CreateRoom:input_type -> pb.CreateRoomRequest
+	18,  // 62: pb.BattleService.JoinRoom:input_type -> pb.JoinRoomRequest
+	20,  // 63: pb.BattleService.LeaveRoom:input_type -> pb.LeaveRoomRequest
+	21,  // 64: pb.BattleService.StartBattle:input_type -> pb.StartBattleRequest
+	23,  // 65: pb.BattleService.EndBattle:input_type -> pb.EndBattleRequest
+	25,  // 66: pb.BattleService.ReconnectBattle:input_type -> pb.ReconnectBattleRequest
+	27,  // 67: pb.BattleService.SyncBattleAction:input_type -> pb.SyncBattleActionRequest
+	40,  // 68: pb.BagService.GetBagInfo:input_type -> pb.GetBagRequest
+	43,  // 69: pb.BagService.UseItem:input_type -> pb.UseItemRequest
+	45,  // 70: pb.BagService.DropItem:input_type -> pb.DropItemRequest
+	46,  // 71: pb.BagService.CombineItems:input_type -> pb.CombineItemsRequest
+	48,  // 72: pb.MailService.GetMailList:input_type -> pb.GetMailListRequest
+	51,  // 73: pb.MailService.GetMailDetail:input_type -> pb.GetMailDetailRequest
+	55,  // 74: pb.MailService.ReceiveMailAttachment:input_type -> pb.ReceiveMailAttachmentRequest
+	57,  // 75: pb.MailService.ReceiveAllMailAttachments:input_type -> pb.ReceiveAllMailAttachmentsRequest
+	59,  // 76: pb.MailService.DeleteMail:input_type -> pb.DeleteMailRequest
+	60,  // 77: pb.MailService.SendMail:input_type -> pb.SendMailRequest
+	62,  // 78: pb.MailService.SendBroadcastMail:input_type -> pb.SendBroadcastMailRequest
+	63,  // 79: pb.MailService.GetMailBadge:input_type -> pb.GetMailBadgeRequest
+	66,  // 80: pb.TaskService.GetTaskList:input_type -> pb.GetTaskListRequest
+	69,  // 81: pb.TaskService.GetTaskDetail:input_type -> pb.GetTaskDetailRequest
+	73,  // 82: pb.TaskService.AcceptTask:input_type -> pb.AcceptTaskRequest
+	74,  // 83: pb.TaskService.SubmitTask:input_type -> pb.SubmitTaskRequest
+	76,  // 84: pb.TaskService.GiveUpTask:input_type -> pb.GiveUpTaskRequest
+	77,  // 85: pb.ShopService.GetShopList:input_type -> pb.GetShopListRequest
+	84,  // 86: pb.ShopService.BuyItem:input_type -> pb.BuyItemRequest
+	89,  // 87: pb.ShopService.GetDiscountInfo:input_type -> pb.GetDiscountInfoRequest
+	80,  // 88: pb.ShopService.RefreshShop:input_type -> pb.RefreshShopRequest
+	86,  // 89: pb.ShopService.RefundShopOrder:input_type -> pb.RefundShopOrderRequest
+	92,  // 90: pb.RecordService.GetPlayerRecords:input_type -> pb.GetPlayerRecordsRequest
+	95,  // 91: pb.RecordService.GetBattleDetail:input_type -> pb.GetBattleDetailRequest
+	102, // 92: pb.RankingService.GetRankingList:input_type -> pb.GetRankingListRequest
+	105, // 93: pb.RankingService.GetPlayerRank:input_type -> pb.GetPlayerRankRequest
+	107, // 94: pb.RankingService.GetAroundRanking:input_type -> pb.GetAroundRankingRequest
+	108, // 95: pb.RankingService.GetGroupRanking:input_type -> pb.GetGroupRankingRequest
+	109, // 96: pb.WalletService.GetBalance:input_type -> pb.GetBalanceRequest
+	111, // 97: pb.WalletService.Credit:input_type -> pb.WalletChangeRequest
+	111, // 98: pb.WalletService.Debit:input_type -> pb.WalletChangeRequest
+	113, // 99: pb.WalletService.GetTransactions:input_type -> pb.GetTransactionsRequest
+	116, // 100: pb.BattleDataProcessService.ProcessBattleData:input_type -> pb.ProcessBattleDataRequest
+	2,   // 101: pb.LoginService.Register:output_type -> pb.RegisterResponse
+	4,   // 102: pb.LoginService.Login:output_type -> pb.LoginResponse
+	4,   // 103: pb.LoginService.Reconnect:output_type -> pb.LoginResponse
+	0,   // 104: pb.LoginService.KickPlayer:output_type -> pb.CommonResponse
+	8,   // 105: pb.LoginService.GetQueueInfo:output_type -> pb.QueueInfoResponse
+	0,   // 106: pb.LoginService.LeaveQueue:output_type -> pb.CommonResponse
+	17,  // 107: pb.BattleService.CreateRoom:output_type -> pb.CreateRoomResponse
+	19,  // 108: pb.BattleService.JoinRoom:output_type -> pb.JoinRoomResponse
+	0,   // 109: pb.BattleService.LeaveRoom:output_type -> pb.CommonResponse
+	22,  // 110: pb.BattleService.StartBattle:output_type -> pb.StartBattleResponse
+	24,  // 111: pb.BattleService.EndBattle:output_type -> pb.EndBattleResponse
+	26,  // 112: pb.BattleService.ReconnectBattle:output_type -> pb.BattleStateResponse
+	0,   // 113: pb.BattleService.SyncBattleAction:output_type -> pb.CommonResponse
+	41,  // 114: pb.BagService.GetBagInfo:output_type -> pb.BagResponse
+	44,  // 115: pb.BagService.UseItem:output_type -> pb.UseItemResponse
+	0,   // 116: pb.BagService.DropItem:output_type -> pb.CommonResponse
+	47,  // 117: pb.BagService.CombineItems:output_type -> pb.CombineItemsResponse
+	49,  // 118: pb.MailService.GetMailList:output_type -> pb.GetMailListResponse
+	52,  // 119: pb.MailService.GetMailDetail:output_type -> pb.GetMailDetailResponse
+	56,  // 120: pb.MailService.ReceiveMailAttachment:output_type -> pb.ReceiveMailAttachmentResponse
+	58,  // 121: pb.MailService.ReceiveAllMailAttachments:output_type -> pb.ReceiveAllMailAttachmentsResponse
+	0,   // 122: pb.MailService.DeleteMail:output_type -> pb.CommonResponse
+	61,  // 123: pb.MailService.SendMail:output_type -> pb.SendMailResponse
+	61,  // 124: pb.MailService.SendBroadcastMail:output_type -> pb.SendMailResponse
+	64,  // 125: pb.MailService.GetMailBadge:output_type -> pb.GetMailBadgeResponse
+	67,  // 126: pb.TaskService.GetTaskList:output_type -> pb.GetTaskListResponse
+	70,  // 127: pb.TaskService.GetTaskDetail:output_type -> pb.GetTaskDetailResponse
+	0,   // 128: pb.TaskService.AcceptTask:output_type -> pb.CommonResponse
+	75,  // 129: pb.TaskService.SubmitTask:output_type -> pb.SubmitTaskResponse
+	0,   // 130: pb.TaskService.GiveUpTask:output_type -> pb.CommonResponse
+	78,  // 131: pb.ShopService.GetShopList:output_type -> pb.GetShopListResponse
+	85,  // 132: pb.ShopService.BuyItem:output_type -> pb.BuyItemResponse
+	90,  // 133: pb.ShopService.GetDiscountInfo:output_type -> pb.GetDiscountInfoResponse
+	81,  // 134: pb.ShopService.RefreshShop:output_type -> pb.RefreshShopResponse
+	87,  // 135: pb.ShopService.RefundShopOrder:output_type -> pb.RefundShopOrderResponse
+	93,  // 136: pb.RecordService.GetPlayerRecords:output_type -> pb.GetPlayerRecordsResponse
+	96,  // 137: pb.RecordService.GetBattleDetail:output_type -> pb.GetBattleDetailResponse
+	103, // 138: pb.RankingService.GetRankingList:output_type -> pb.GetRankingListResponse
+	106, // 139: pb.RankingService.GetPlayerRank:output_type -> pb.GetPlayerRankResponse
+	103, // 140: pb.RankingService.GetAroundRanking:output_type -> pb.GetRankingListResponse
+	103, // 141: pb.RankingService.GetGroupRanking:output_type -> pb.GetRankingListResponse
+	110, // 142: pb.WalletService.GetBalance:output_type -> pb.GetBalanceResponse
+	112, // 143: pb.WalletService.Credit:output_type -> pb.WalletChangeResponse
+	112, // 144: pb.WalletService.Debit:output_type -> pb.WalletChangeResponse
+	114, // 145: pb.WalletService.GetTransactions:output_type -> pb.GetTransactionsResponse
+	0,   // 146: pb.BattleDataProcessService.ProcessBattleData:output_type -> pb.CommonResponse
+	101, // [101:147] is the sub-list for method output_type
+	55,  // [55:101] is the sub-list for method input_type
+	55,  // [55:55] is the sub-list for extension type_name
+	55,  // [55:55] is the sub-list for extension extendee
+	0,   // [0:55] is the sub-list for field type_name
 }
 
 func init() { file_game_proto_init() }
@@ -6646,9 +9087,9 @@ func file_game_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_game_proto_rawDesc), len(file_game_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   92,
+			NumMessages:   122,
 			NumExtensions: 0,
-			NumServices:   9,
+			NumServices:   10,
 		},
 		GoTypes:           file_game_proto_goTypes,
 		DependencyIndexes: file_game_proto_depIdxs,
@@ -24,6 +24,7 @@ const (
 	LoginService_Reconnect_FullMethodName    = "/pb.LoginService/Reconnect"
 	LoginService_KickPlayer_FullMethodName   = "/pb.LoginService/KickPlayer"
 	LoginService_GetQueueInfo_FullMethodName = "/pb.LoginService/GetQueueInfo"
+	LoginService_LeaveQueue_FullMethodName   = "/pb.LoginService/LeaveQueue"
 )
 
 // LoginServiceClient is the client API for LoginService service.
@@ -38,6 +39,7 @@ type LoginServiceClient interface {
 	Reconnect(ctx context.Context, in *ReconnectRequest, opts ...grpc.CallOption) (*LoginResponse, error)
 	KickPlayer(ctx context.Context, in *KickPlayerRequest, opts ...grpc.CallOption) (*CommonResponse, error)
 	GetQueueInfo(ctx context.Context, in *QueueInfoRequest, opts ...grpc.CallOption) (*QueueInfoResponse, error)
+	LeaveQueue(ctx context.Context, in *LeaveQueueRequest, opts ...grpc.CallOption) (*CommonResponse, error)
 }
 
 type loginServiceClient struct {
@@ -98,6 +100,16 @@ func (c *loginServiceClient) GetQueueInfo(ctx context.Context, in *QueueInfoRequ
 	return out, nil
 }
 
+func (c *loginServiceClient) LeaveQueue(ctx context.Context, in *LeaveQueueRequest, opts ...grpc.CallOption) (*CommonResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CommonResponse)
+	err := c.cc.Invoke(ctx, LoginService_LeaveQueue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LoginServiceServer is the server API for LoginService service.
 // All implementations must embed UnimplementedLoginServiceServer
 // for forward compatibility.
@@ -110,6 +122,7 @@ type LoginServiceServer interface {
 	Reconnect(context.Context, *ReconnectRequest) (*LoginResponse, error)
 	KickPlayer(context.Context, *KickPlayerRequest) (*CommonResponse, error)
 	GetQueueInfo(context.Context, *QueueInfoRequest) (*QueueInfoResponse, error)
+	LeaveQueue(context.Context, *LeaveQueueRequest) (*CommonResponse, error)
 	mustEmbedUnimplementedLoginServiceServer()
 }
 
@@ -135,6 +148,9 @@ func (UnimplementedLoginServiceServer) KickPlayer(context.Context, *KickPlayerRe
 func (UnimplementedLoginServiceServer) GetQueueInfo(context.Context, *QueueInfoRequest) (*QueueInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetQueueInfo not implemented")
 }
+func (UnimplementedLoginServiceServer) LeaveQueue(context.Context, *LeaveQueueRequest) (*CommonResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaveQueue not implemented")
+}
 func (UnimplementedLoginServiceServer) mustEmbedUnimplementedLoginServiceServer() {}
 func (UnimplementedLoginServiceServer) testEmbeddedByValue()                      {}
 
@@ -246,6 +262,24 @@ func _LoginService_GetQueueInfo_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LoginService_LeaveQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaveQueueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LoginServiceServer).LeaveQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LoginService_LeaveQueue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LoginServiceServer).LeaveQueue(ctx, req.(*LeaveQueueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // LoginService_ServiceDesc is the grpc.ServiceDesc for LoginService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -273,6 +307,10 @@ var LoginService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetQueueInfo",
 			Handler:    _LoginService_GetQueueInfo_Handler,
 		},
+		{
+			MethodName: "LeaveQueue",
+			Handler:    _LoginService_LeaveQueue_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "game.proto",
@@ -833,10 +871,14 @@ var BagService_ServiceDesc = grpc.ServiceDesc{
 }
 
 const (
-	MailService_GetMailList_FullMethodName           = "/pb.MailService/GetMailList"
-	MailService_GetMailDetail_FullMethodName         = "/pb.MailService/GetMailDetail"
-	MailService_ReceiveMailAttachment_FullMethodName = "/pb.MailService/ReceiveMailAttachment"
-	MailService_DeleteMail_FullMethodName            = "/pb.MailService/DeleteMail"
+	MailService_GetMailList_FullMethodName               = "/pb.MailService/GetMailList"
+	MailService_GetMailDetail_FullMethodName             = "/pb.MailService/GetMailDetail"
+	MailService_ReceiveMailAttachment_FullMethodName     = "/pb.MailService/ReceiveMailAttachment"
+	MailService_ReceiveAllMailAttachments_FullMethodName = "/pb.MailService/ReceiveAllMailAttachments"
+	MailService_DeleteMail_FullMethodName                = "/pb.MailService/DeleteMail"
+	MailService_SendMail_FullMethodName                  = "/pb.MailService/SendMail"
+	MailService_SendBroadcastMail_FullMethodName         = "/pb.MailService/SendBroadcastMail"
+	MailService_GetMailBadge_FullMethodName              = "/pb.MailService/GetMailBadge"
 )
 
 // MailServiceClient is the client API for MailService service.
@@ -848,7 +890,11 @@ type MailServiceClient interface {
 	GetMailList(ctx context.Context, in *GetMailListRequest, opts ...grpc.CallOption) (*GetMailListResponse, error)
 	GetMailDetail(ctx context.Context, in *GetMailDetailRequest, opts ...grpc.CallOption) (*GetMailDetailResponse, error)
 	ReceiveMailAttachment(ctx context.Context, in *ReceiveMailAttachmentRequest, opts ...grpc.CallOption) (*ReceiveMailAttachmentResponse, error)
+	ReceiveAllMailAttachments(ctx context.Context, in *ReceiveAllMailAttachmentsRequest, opts ...grpc.CallOption) (*ReceiveAllMailAttachmentsResponse, error)
 	DeleteMail(ctx context.Context, in *DeleteMailRequest, opts ...grpc.CallOption) (*CommonResponse, error)
+	SendMail(ctx context.Context, in *SendMailRequest, opts ...grpc.CallOption) (*SendMailResponse, error)
+	SendBroadcastMail(ctx context.Context, in *SendBroadcastMailRequest, opts ...grpc.CallOption) (*SendMailResponse, error)
+	GetMailBadge(ctx context.Context, in *GetMailBadgeRequest, opts ...grpc.CallOption) (*GetMailBadgeResponse, error)
 }
 
 type mailServiceClient struct {
@@ -889,6 +935,16 @@ func (c *mailServiceClient) ReceiveMailAttachment(ctx context.Context, in *Recei
 	return out, nil
 }
 
+func (c *mailServiceClient) ReceiveAllMailAttachments(ctx context.Context, in *ReceiveAllMailAttachmentsRequest, opts ...grpc.CallOption) (*ReceiveAllMailAttachmentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReceiveAllMailAttachmentsResponse)
+	err := c.cc.Invoke(ctx, MailService_ReceiveAllMailAttachments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *mailServiceClient) DeleteMail(ctx context.Context, in *DeleteMailRequest, opts ...grpc.CallOption) (*CommonResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CommonResponse)
@@ -899,6 +955,36 @@ func (c *mailServiceClient) DeleteMail(ctx context.Context, in *DeleteMailReques
 	return out, nil
 }
 
+func (c *mailServiceClient) SendMail(ctx context.Context, in *SendMailRequest, opts ...grpc.CallOption) (*SendMailResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SendMailResponse)
+	err := c.cc.Invoke(ctx, MailService_SendMail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mailServiceClient) SendBroadcastMail(ctx context.Context, in *SendBroadcastMailRequest, opts ...grpc.CallOption) (*SendMailResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SendMailResponse)
+	err := c.cc.Invoke(ctx, MailService_SendBroadcastMail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mailServiceClient) GetMailBadge(ctx context.Context, in *GetMailBadgeRequest, opts ...grpc.CallOption) (*GetMailBadgeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMailBadgeResponse)
+	err := c.cc.Invoke(ctx, MailService_GetMailBadge_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MailServiceServer is the server API for MailService service.
 // All implementations must embed UnimplementedMailServiceServer
 // for forward compatibility.
@@ -908,7 +994,11 @@ type MailServiceServer interface {
 	GetMailList(context.Context, *GetMailListRequest) (*GetMailListResponse, error)
 	GetMailDetail(context.Context, *GetMailDetailRequest) (*GetMailDetailResponse, error)
 	ReceiveMailAttachment(context.Context, *ReceiveMailAttachmentRequest) (*ReceiveMailAttachmentResponse, error)
+	ReceiveAllMailAttachments(context.Context, *ReceiveAllMailAttachmentsRequest) (*ReceiveAllMailAttachmentsResponse, error)
 	DeleteMail(context.Context, *DeleteMailRequest) (*CommonResponse, error)
+	SendMail(context.Context, *SendMailRequest) (*SendMailResponse, error)
+	SendBroadcastMail(context.Context, *SendBroadcastMailRequest) (*SendMailResponse, error)
+	GetMailBadge(context.Context, *GetMailBadgeRequest) (*GetMailBadgeResponse, error)
 	mustEmbedUnimplementedMailServiceServer()
 }
 
@@ -928,9 +1018,21 @@ func (UnimplementedMailServiceServer) GetMailDetail(context.Context, *GetMailDet
 func (UnimplementedMailServiceServer) ReceiveMailAttachment(context.Context, *ReceiveMailAttachmentRequest) (*ReceiveMailAttachmentResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReceiveMailAttachment not implemented")
 }
+func (UnimplementedMailServiceServer) ReceiveAllMailAttachments(context.Context, *ReceiveAllMailAttachmentsRequest) (*ReceiveAllMailAttachmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReceiveAllMailAttachments not implemented")
+}
 func (UnimplementedMailServiceServer) DeleteMail(context.Context, *DeleteMailRequest) (*CommonResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteMail not implemented")
 }
+func (UnimplementedMailServiceServer) SendMail(context.Context, *SendMailRequest) (*SendMailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendMail not implemented")
+}
+func (UnimplementedMailServiceServer) SendBroadcastMail(context.Context, *SendBroadcastMailRequest) (*SendMailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendBroadcastMail not implemented")
+}
+func (UnimplementedMailServiceServer) GetMailBadge(context.Context, *GetMailBadgeRequest) (*GetMailBadgeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMailBadge not implemented")
+}
 func (UnimplementedMailServiceServer) mustEmbedUnimplementedMailServiceServer() {}
 func (UnimplementedMailServiceServer) testEmbeddedByValue()                     {}
 
@@ -1006,6 +1108,24 @@ func _MailService_ReceiveMailAttachment_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MailService_ReceiveAllMailAttachments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReceiveAllMailAttachmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MailServiceServer).ReceiveAllMailAttachments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MailService_ReceiveAllMailAttachments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MailServiceServer).ReceiveAllMailAttachments(ctx, req.(*ReceiveAllMailAttachmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MailService_DeleteMail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteMailRequest)
 	if err := dec(in); err != nil {
@@ -1024,6 +1144,60 @@ func _MailService_DeleteMail_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MailService_SendMail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendMailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MailServiceServer).SendMail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MailService_SendMail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MailServiceServer).SendMail(ctx, req.(*SendMailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MailService_SendBroadcastMail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendBroadcastMailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MailServiceServer).SendBroadcastMail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MailService_SendBroadcastMail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MailServiceServer).SendBroadcastMail(ctx, req.(*SendBroadcastMailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MailService_GetMailBadge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMailBadgeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MailServiceServer).GetMailBadge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MailService_GetMailBadge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MailServiceServer).GetMailBadge(ctx, req.(*GetMailBadgeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MailService_ServiceDesc is the grpc.ServiceDesc for MailService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1043,10 +1217,26 @@ var MailService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ReceiveMailAttachment",
 			Handler:    _MailService_ReceiveMailAttachment_Handler,
 		},
+		{
+			MethodName: "ReceiveAllMailAttachments",
+			Handler:    _MailService_ReceiveAllMailAttachments_Handler,
+		},
 		{
 			MethodName: "DeleteMail",
 			Handler:    _MailService_DeleteMail_Handler,
 		},
+		{
+			MethodName: "SendMail",
+			Handler:    _MailService_SendMail_Handler,
+		},
+		{
+			MethodName: "SendBroadcastMail",
+			Handler:    _MailService_SendBroadcastMail_Handler,
+		},
+		{
+			MethodName: "GetMailBadge",
+			Handler:    _MailService_GetMailBadge_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "game.proto",
@@ -1314,6 +1504,8 @@ const (
 	ShopService_GetShopList_FullMethodName     = "/pb.ShopService/GetShopList"
 	ShopService_BuyItem_FullMethodName         = "/pb.ShopService/BuyItem"
 	ShopService_GetDiscountInfo_FullMethodName = "/pb.ShopService/GetDiscountInfo"
+	ShopService_RefreshShop_FullMethodName     = "/pb.ShopService/RefreshShop"
+	ShopService_RefundShopOrder_FullMethodName = "/pb.ShopService/RefundShopOrder"
 )
 
 // ShopServiceClient is the client API for ShopService service.
@@ -1325,6 +1517,8 @@ type ShopServiceClient interface {
 	GetShopList(ctx context.Context, in *GetShopListRequest, opts ...grpc.CallOption) (*GetShopListResponse, error)
 	BuyItem(ctx context.Context, in *BuyItemRequest, opts ...grpc.CallOption) (*BuyItemResponse, error)
 	GetDiscountInfo(ctx context.Context, in *GetDiscountInfoRequest, opts ...grpc.CallOption) (*GetDiscountInfoResponse, error)
+	RefreshShop(ctx context.Context, in *RefreshShopRequest, opts ...grpc.CallOption) (*RefreshShopResponse, error)
+	RefundShopOrder(ctx context.Context, in *RefundShopOrderRequest, opts ...grpc.CallOption) (*RefundShopOrderResponse, error)
 }
 
 type shopServiceClient struct {
@@ -1365,6 +1559,26 @@ func (c *shopServiceClient) GetDiscountInfo(ctx context.Context, in *GetDiscount
 	return out, nil
 }
 
+func (c *shopServiceClient) RefreshShop(ctx context.Context, in *RefreshShopRequest, opts ...grpc.CallOption) (*RefreshShopResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefreshShopResponse)
+	err := c.cc.Invoke(ctx, ShopService_RefreshShop_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) RefundShopOrder(ctx context.Context, in *RefundShopOrderRequest, opts ...grpc.CallOption) (*RefundShopOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefundShopOrderResponse)
+	err := c.cc.Invoke(ctx, ShopService_RefundShopOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ShopServiceServer is the server API for ShopService service.
 // All implementations must embed UnimplementedShopServiceServer
 // for forward compatibility.
@@ -1374,6 +1588,8 @@ type ShopServiceServer interface {
 	GetShopList(context.Context, *GetShopListRequest) (*GetShopListResponse, error)
 	BuyItem(context.Context, *BuyItemRequest) (*BuyItemResponse, error)
 	GetDiscountInfo(context.Context, *GetDiscountInfoRequest) (*GetDiscountInfoResponse, error)
+	RefreshShop(context.Context, *RefreshShopRequest) (*RefreshShopResponse, error)
+	RefundShopOrder(context.Context, *RefundShopOrderRequest) (*RefundShopOrderResponse, error)
 	mustEmbedUnimplementedShopServiceServer()
 }
 
@@ -1393,6 +1609,12 @@ func (UnimplementedShopServiceServer) BuyItem(context.Context, *BuyItemRequest)
 func (UnimplementedShopServiceServer) GetDiscountInfo(context.Context, *GetDiscountInfoRequest) (*GetDiscountInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetDiscountInfo not implemented")
 }
+func (UnimplementedShopServiceServer) RefreshShop(context.Context, *RefreshShopRequest) (*RefreshShopResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshShop not implemented")
+}
+func (UnimplementedShopServiceServer) RefundShopOrder(context.Context, *RefundShopOrderRequest) (*RefundShopOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefundShopOrder not implemented")
+}
 func (UnimplementedShopServiceServer) mustEmbedUnimplementedShopServiceServer() {}
 func (UnimplementedShopServiceServer) testEmbeddedByValue()                     {}
 
@@ -1468,6 +1690,42 @@ func _ShopService_GetDiscountInfo_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ShopService_RefreshShop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshShopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).RefreshShop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShopService_RefreshShop_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).RefreshShop(ctx, req.(*RefreshShopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_RefundShopOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefundShopOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).RefundShopOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShopService_RefundShopOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).RefundShopOrder(ctx, req.(*RefundShopOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ShopService_ServiceDesc is the grpc.ServiceDesc for ShopService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1487,6 +1745,14 @@ var ShopService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetDiscountInfo",
 			Handler:    _ShopService_GetDiscountInfo_Handler,
 		},
+		{
+			MethodName: "RefreshShop",
+			Handler:    _ShopService_RefreshShop_Handler,
+		},
+		{
+			MethodName: "RefundShopOrder",
+			Handler:    _ShopService_RefundShopOrder_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "game.proto",
@@ -1637,8 +1903,10 @@ var RecordService_ServiceDesc = grpc.ServiceDesc{
 }
 
 const (
-	RankingService_GetRankingList_FullMethodName = "/pb.RankingService/GetRankingList"
-	RankingService_GetPlayerRank_FullMethodName  = "/pb.RankingService/GetPlayerRank"
+	RankingService_GetRankingList_FullMethodName   = "/pb.RankingService/GetRankingList"
+	RankingService_GetPlayerRank_FullMethodName    = "/pb.RankingService/GetPlayerRank"
+	RankingService_GetAroundRanking_FullMethodName = "/pb.RankingService/GetAroundRanking"
+	RankingService_GetGroupRanking_FullMethodName  = "/pb.RankingService/GetGroupRanking"
 )
 
 // RankingServiceClient is the client API for RankingService service.
@@ -1649,6 +1917,8 @@ const (
 type RankingServiceClient interface {
 	GetRankingList(ctx context.Context, in *GetRankingListRequest, opts ...grpc.CallOption) (*GetRankingListResponse, error)
 	GetPlayerRank(ctx context.Context, in *GetPlayerRankRequest, opts ...grpc.CallOption) (*GetPlayerRankResponse, error)
+	GetAroundRanking(ctx context.Context, in *GetAroundRankingRequest, opts ...grpc.CallOption) (*GetRankingListResponse, error)
+	GetGroupRanking(ctx context.Context, in *GetGroupRankingRequest, opts ...grpc.CallOption) (*GetRankingListResponse, error)
 }
 
 type rankingServiceClient struct {
@@ -1679,6 +1949,26 @@ func (c *rankingServiceClient) GetPlayerRank(ctx context.Context, in *GetPlayerR
 	return out, nil
 }
 
+func (c *rankingServiceClient) GetAroundRanking(ctx context.Context, in *GetAroundRankingRequest, opts ...grpc.CallOption) (*GetRankingListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRankingListResponse)
+	err := c.cc.Invoke(ctx, RankingService_GetAroundRanking_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rankingServiceClient) GetGroupRanking(ctx context.Context, in *GetGroupRankingRequest, opts ...grpc.CallOption) (*GetRankingListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRankingListResponse)
+	err := c.cc.Invoke(ctx, RankingService_GetGroupRanking_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RankingServiceServer is the server API for RankingService service.
 // All implementations must embed UnimplementedRankingServiceServer
 // for forward compatibility.
@@ -1687,6 +1977,8 @@ func (c *rankingServiceClient) GetPlayerRank(ctx context.Context, in *GetPlayerR
 type RankingServiceServer interface {
 	GetRankingList(context.Context, *GetRankingListRequest) (*GetRankingListResponse, error)
 	GetPlayerRank(context.Context, *GetPlayerRankRequest) (*GetPlayerRankResponse, error)
+	GetAroundRanking(context.Context, *GetAroundRankingRequest) (*GetRankingListResponse, error)
+	GetGroupRanking(context.Context, *GetGroupRankingRequest) (*GetRankingListResponse, error)
 	mustEmbedUnimplementedRankingServiceServer()
 }
 
@@ -1703,6 +1995,12 @@ func (UnimplementedRankingServiceServer) GetRankingList(context.Context, *GetRan
 func (UnimplementedRankingServiceServer) GetPlayerRank(context.Context, *GetPlayerRankRequest) (*GetPlayerRankResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPlayerRank not implemented")
 }
+func (UnimplementedRankingServiceServer) GetAroundRanking(context.Context, *GetAroundRankingRequest) (*GetRankingListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAroundRanking not implemented")
+}
+func (UnimplementedRankingServiceServer) GetGroupRanking(context.Context, *GetGroupRankingRequest) (*GetRankingListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGroupRanking not implemented")
+}
 func (UnimplementedRankingServiceServer) mustEmbedUnimplementedRankingServiceServer() {}
 func (UnimplementedRankingServiceServer) testEmbeddedByValue()                        {}
 
@@ -1760,6 +2058,42 @@ func _RankingService_GetPlayerRank_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RankingService_GetAroundRanking_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAroundRankingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RankingServiceServer).GetAroundRanking(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RankingService_GetAroundRanking_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RankingServiceServer).GetAroundRanking(ctx, req.(*GetAroundRankingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RankingService_GetGroupRanking_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGroupRankingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RankingServiceServer).GetGroupRanking(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RankingService_GetGroupRanking_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RankingServiceServer).GetGroupRanking(ctx, req.(*GetGroupRankingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RankingService_ServiceDesc is the grpc.ServiceDesc for RankingService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1775,6 +2109,234 @@ var RankingService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPlayerRank",
 			Handler:    _RankingService_GetPlayerRank_Handler,
 		},
+		{
+			MethodName: "GetAroundRanking",
+			Handler:    _RankingService_GetAroundRanking_Handler,
+		},
+		{
+			MethodName: "GetGroupRanking",
+			Handler:    _RankingService_GetGroupRanking_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "game.proto",
+}
+
+const (
+	WalletService_GetBalance_FullMethodName      = "/pb.WalletService/GetBalance"
+	WalletService_Credit_FullMethodName          = "/pb.WalletService/Credit"
+	WalletService_Debit_FullMethodName           = "/pb.WalletService/Debit"
+	WalletService_GetTransactions_FullMethodName = "/pb.WalletService/GetTransactions"
+)
+
+// WalletServiceClient is the client API for WalletService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// 钱包相关（内部服务）
+type WalletServiceClient interface {
+	GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*GetBalanceResponse, error)
+	Credit(ctx context.Context, in *WalletChangeRequest, opts ...grpc.CallOption) (*WalletChangeResponse, error)
+	Debit(ctx context.Context, in *WalletChangeRequest, opts ...grpc.CallOption) (*WalletChangeResponse, error)
+	GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*GetTransactionsResponse, error)
+}
+
+type walletServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWalletServiceClient(cc grpc.ClientConnInterface) WalletServiceClient {
+	return &walletServiceClient{cc}
+}
+
+func (c *walletServiceClient) GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*GetBalanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBalanceResponse)
+	err := c.cc.Invoke(ctx, WalletService_GetBalance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) Credit(ctx context.Context, in *WalletChangeRequest, opts ...grpc.CallOption) (*WalletChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WalletChangeResponse)
+	err := c.cc.Invoke(ctx, WalletService_Credit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) Debit(ctx context.Context, in *WalletChangeRequest, opts ...grpc.CallOption) (*WalletChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WalletChangeResponse)
+	err := c.cc.Invoke(ctx, WalletService_Debit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*GetTransactionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTransactionsResponse)
+	err := c.cc.Invoke(ctx, WalletService_GetTransactions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WalletServiceServer is the server API for WalletService service.
+// All implementations must embed UnimplementedWalletServiceServer
+// for forward compatibility.
+//
+// 钱包相关（内部服务）
+type WalletServiceServer interface {
+	GetBalance(context.Context, *GetBalanceRequest) (*GetBalanceResponse, error)
+	Credit(context.Context, *WalletChangeRequest) (*WalletChangeResponse, error)
+	Debit(context.Context, *WalletChangeRequest) (*WalletChangeResponse, error)
+	GetTransactions(context.Context, *GetTransactionsRequest) (*GetTransactionsResponse, error)
+	mustEmbedUnimplementedWalletServiceServer()
+}
+
+// UnimplementedWalletServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedWalletServiceServer struct{}
+
+func (UnimplementedWalletServiceServer) GetBalance(context.Context, *GetBalanceRequest) (*GetBalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalance not implemented")
+}
+func (UnimplementedWalletServiceServer) Credit(context.Context, *WalletChangeRequest) (*WalletChangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Credit not implemented")
+}
+func (UnimplementedWalletServiceServer) Debit(context.Context, *WalletChangeRequest) (*WalletChangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Debit not implemented")
+}
+func (UnimplementedWalletServiceServer) GetTransactions(context.Context, *GetTransactionsRequest) (*GetTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransactions not implemented")
+}
+func (UnimplementedWalletServiceServer) mustEmbedUnimplementedWalletServiceServer() {}
+func (UnimplementedWalletServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeWalletServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WalletServiceServer will
+// result in compilation errors.
+type UnsafeWalletServiceServer interface {
+	mustEmbedUnimplementedWalletServiceServer()
+}
+
+func RegisterWalletServiceServer(s grpc.ServiceRegistrar, srv WalletServiceServer) {
+	// If the following call pancis, it indicates UnimplementedWalletServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&WalletService_ServiceDesc, srv)
+}
+
+func _WalletService_GetBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_GetBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetBalance(ctx, req.(*GetBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_Credit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WalletChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).Credit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_Credit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).Credit(ctx, req.(*WalletChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_Debit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WalletChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).Debit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_Debit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).Debit(ctx, req.(*WalletChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_GetTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetTransactions(ctx, req.(*GetTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// WalletService_ServiceDesc is the grpc.ServiceDesc for WalletService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WalletService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.WalletService",
+	HandlerType: (*WalletServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetBalance",
+			Handler:    _WalletService_GetBalance_Handler,
+		},
+		{
+			MethodName: "Credit",
+			Handler:    _WalletService_Credit_Handler,
+		},
+		{
+			MethodName: "Debit",
+			Handler:    _WalletService_Debit_Handler,
+		},
+		{
+			MethodName: "GetTransactions",
+			Handler:    _WalletService_GetTransactions_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "game.proto",
@@ -14,9 +14,10 @@ const target = "discovery://services"
 
 // ServiceType 服务类型字符串常量
 const (
-	ServiceTypeLogin = "Login"
-	ServiceTypeBag   = "Bag"
-	ServiceTypeMail  = "Mail"
+	ServiceTypeLogin  = "Login"
+	ServiceTypeBag    = "Bag"
+	ServiceTypeMail   = "Mail"
+	ServiceTypeWallet = "Wallet"
 )
 
 // NewRpcClient 根据服务类型创建对应的RPC客户端
@@ -35,6 +36,8 @@ func NewRpcClient(fn transport.NewMeshClient, serviceType string) (interface{},
 		return pb.NewBagServiceClient(conn), nil
 	case ServiceTypeMail:
 		return pb.NewMailServiceClient(conn), nil
+	case ServiceTypeWallet:
+		return pb.NewWalletServiceClient(conn), nil
 	default:
 		return nil, ErrInvalidServiceType
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"sync"
 	"time"
 
@@ -251,21 +252,26 @@ func (c *KafkaConsumer) Close() error {
 
 // GetLag 获取消费者延迟
 func (c *KafkaConsumer) GetLag(ctx context.Context) (map[int]int64, error) {
-	partitions, err := c.reader.Partitions()
+	stats := c.reader.Stats()
+	partition, err := strconv.Atoi(stats.Partition)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get partitions: %v", err)
+		return nil, fmt.Errorf("failed to parse partition %q: %v", stats.Partition, err)
 	}
 
-	lagMap := make(map[int]int64)
+	// 消费组模式下无法按分区实时查询，退回最近一次拉取时的统计值
+	if c.reader.Config().GroupID != "" {
+		return map[int]int64{partition: stats.Lag}, nil
+	}
 
-	for _, p := range partitions {
-		// 获取最新偏移量
-		latestOffset, err := c.reader.ReadLag(ctx, p)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get lag for partition %d: %v", p, err)
-		}
-		lagMap[int(p)] = latestOffset
+	lag, err := c.reader.ReadLag(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lag for partition %d: %v", partition, err)
 	}
 
-	return lagMap, nil
+	return map[int]int64{partition: lag}, nil
+}
+
+// Lag 获取最近一次拉取时尚未消费的消息数
+func (c *KafkaConsumer) Lag() int64 {
+	return c.reader.Stats().Lag
 }
@@ -0,0 +1,155 @@
+package login
+
+import (
+	"errors"
+	"time"
+
+	"ghserver/define"
+	"ghserver/utils/mongodb"
+
+	"github.com/dobyte/due/v2/utils/xconv"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	playerCollection  = "player"    // 玩家账号集合
+	counterCollection = "counter"   // 自增计数器集合
+	playerIDCounter   = "player_id" // 玩家ID计数器
+	playerIDBase      = 100000      // 玩家ID起始值
+)
+
+// AccountManager 账号管理器，玩家ID按自增数字分配，同时作为网关绑定的用户ID
+type AccountManager struct {
+	players  *mongodb.MongoDBClient
+	counters *mongodb.MongoDBClient
+}
+
+func NewAccountManager(database string) (*AccountManager, error) {
+	players, err := mongodb.NewMongoDBClient(database, playerCollection)
+	if err != nil {
+		return nil, err
+	}
+
+	counters, err := mongodb.NewMongoDBClient(database, counterCollection)
+	if err != nil {
+		return nil, err
+	}
+
+	// 账号唯一索引
+	if err = players.EnsureIndex("uk_username", bson.D{{Key: "username", Value: 1}}, true); err != nil {
+		return nil, err
+	}
+
+	return &AccountManager{
+		players:  players,
+		counters: counters,
+	}, nil
+}
+
+// GetByAccount 根据账号查询玩家，不存在时返回nil
+func (m *AccountManager) GetByAccount(account string) (*define.Player, error) {
+	return m.findOne(bson.M{"username": account})
+}
+
+// GetByID 根据玩家ID查询玩家，不存在时返回nil
+func (m *AccountManager) GetByID(playerID string) (*define.Player, error) {
+	return m.findOne(bson.M{"_id": playerID})
+}
+
+// Create 创建账号
+func (m *AccountManager) Create(account, password, nickname, clientIP string) (*define.Player, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	playerID, err := m.nextPlayerID()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	player := &define.Player{
+		ID:            playerID,
+		Username:      account,
+		Password:      xconv.String(hashed),
+		Nickname:      nickname,
+		Level:         1,
+		CreateTime:    now,
+		RegisterIP:    clientIP,
+		LastLoginTime: now,
+		LastLoginIP:   clientIP,
+	}
+
+	if _, err = m.players.InsertOne(player); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, ErrAccountExists
+		}
+		return nil, err
+	}
+
+	return player, nil
+}
+
+// Authenticate 校验账号密码，成功后记录登录时间和IP
+func (m *AccountManager) Authenticate(account, password, clientIP string) (*define.Player, error) {
+	player, err := m.GetByAccount(account)
+	if err != nil {
+		return nil, err
+	}
+
+	if player == nil {
+		return nil, ErrWrongAccountOrPassword
+	}
+
+	if err = bcrypt.CompareHashAndPassword([]byte(player.Password), []byte(password)); err != nil {
+		return nil, ErrWrongAccountOrPassword
+	}
+
+	player.LastLoginTime = time.Now()
+	player.LastLoginIP = clientIP
+
+	err = m.players.UpdateOne(bson.M{"_id": player.ID}, bson.M{"$set": bson.M{
+		"last_login_time": player.LastLoginTime,
+		"last_login_ip":   player.LastLoginIP,
+	}})
+	if err != nil {
+		return nil, err
+	}
+
+	return player, nil
+}
+
+func (m *AccountManager) findOne(filter bson.M) (*define.Player, error) {
+	player := &define.Player{}
+	if err := m.players.FindOne(filter, player); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return player, nil
+}
+
+// 分配自增的玩家ID
+func (m *AccountManager) nextPlayerID() (string, error) {
+	counter := struct {
+		Seq int64 `bson:"seq"`
+	}{}
+
+	err := m.counters.FindOneAndUpdate(
+		bson.M{"_id": playerIDCounter},
+		bson.M{"$inc": bson.M{"seq": 1}},
+		&counter,
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return xconv.String(playerIDBase + counter.Seq), nil
+}
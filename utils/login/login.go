@@ -0,0 +1,806 @@
+package login
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"ghserver/define"
+	pb "ghserver/proto/pb"
+	"ghserver/utils/queue"
+	"ghserver/utils/session"
+	"ghserver/utils/token"
+
+	"github.com/dobyte/due/v2/cluster"
+	"github.com/dobyte/due/v2/codes"
+	"github.com/dobyte/due/v2/log"
+	dsession "github.com/dobyte/due/v2/session"
+)
+
+var (
+	ErrAccountExists          = errors.New("账号已存在")
+	ErrWrongAccountOrPassword = errors.New("账号或密码错误")
+)
+
+// Proxy 集群代理，节点和网格代理均满足，用于向玩家推送消息及断开连接
+type Proxy interface {
+	LocateGate(ctx context.Context, uid int64) (string, error)
+	Push(ctx context.Context, args *cluster.PushArgs) error
+	Disconnect(ctx context.Context, args *cluster.DisconnectArgs) error
+}
+
+// Accounts 账号服务
+type Accounts interface {
+	// Create 创建账号，账号已存在时返回ErrAccountExists
+	Create(account, password, nickname, clientIP string) (*define.Player, error)
+	// Authenticate 校验账号密码，校验失败时返回ErrWrongAccountOrPassword
+	Authenticate(account, password, clientIP string) (*define.Player, error)
+	// GetByID 根据玩家ID查询玩家，不存在时返回nil
+	GetByID(playerID string) (*define.Player, error)
+}
+
+// Service 登录服务，登录节点和数据库管理节点共用同一套登录、排队及踢人流程
+type Service struct {
+	pb.UnimplementedLoginServiceServer
+	proxy    Proxy
+	accounts Accounts
+	queue    *queue.Queue
+	sessions *session.Store
+	token    *token.Token
+	mutex    sync.Mutex
+	waiters  map[string]conn // 已连接本节点网关鉴权的排队玩家
+	done     chan struct{}
+}
+
+// 客户端在网关上的连接
+type conn struct {
+	gid string
+	cid int64
+}
+
+func NewService(proxy Proxy, accounts Accounts) *Service {
+	return &Service{
+		proxy:    proxy,
+		accounts: accounts,
+		token:    token.Instance(),
+		sessions: session.Instance(),
+		queue:    queue.Instance(),
+		waiters:  make(map[string]conn),
+		done:     make(chan struct{}),
+	}
+}
+
+// Serve 启动排队放行循环及排队玩家保活循环
+func (s *Service) Serve() {
+	s.queue.Serve(s.vacancy, s.admit)
+
+	go s.keepalive()
+}
+
+// Stop 停止排队放行循环及排队玩家保活循环
+func (s *Service) Stop() {
+	s.queue.Stop()
+
+	select {
+	case <-s.done:
+	default:
+		close(s.done)
+	}
+}
+
+// Register 注册
+func (s *Service) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.RegisterResponse, error) {
+	log.Debugf("Register request: account=%s, nickname=%s, client_ip=%s", req.Account, req.Nickname, req.ClientIP)
+
+	if req.Account == "" || req.Password == "" {
+		return &pb.RegisterResponse{
+			Code:    int32(codes.InvalidArgument.Code()),
+			Message: "账号或密码不能为空",
+		}, nil
+	}
+
+	nickname := req.Nickname
+	if nickname == "" {
+		nickname = req.Account
+	}
+
+	player, err := s.accounts.Create(req.Account, req.Password, nickname, req.ClientIP)
+	if err != nil {
+		if errors.Is(err, ErrAccountExists) {
+			return &pb.RegisterResponse{
+				Code:    int32(define.AccountExists.Code()),
+				Message: err.Error(),
+			}, nil
+		}
+
+		log.Errorf("create account failed: account=%s, err=%v", req.Account, err)
+		return &pb.RegisterResponse{
+			Code:    int32(codes.InternalError.Code()),
+			Message: "注册失败",
+		}, nil
+	}
+
+	log.Infof("Player %s registered successfully, player_id=%s", req.Account, player.ID)
+
+	return &pb.RegisterResponse{
+		Code:    int32(codes.OK.Code()),
+		Message: "注册成功",
+	}, nil
+}
+
+func (s *Service) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
+	log.Debugf("Login request: account=%s, device_id=%s", req.Account, req.DeviceId)
+
+	// 验证账号密码
+	if req.Account == "" || req.Password == "" {
+		return &pb.LoginResponse{
+			Code:    int32(codes.InvalidArgument.Code()),
+			Message: "账号或密码不能为空",
+		}, nil
+	}
+
+	user, err := s.accounts.Authenticate(req.Account, req.Password, req.ClientIp)
+	if err != nil {
+		if errors.Is(err, ErrWrongAccountOrPassword) {
+			return &pb.LoginResponse{
+				Code:    int32(define.WrongAccountOrPassword.Code()),
+				Message: err.Error(),
+			}, nil
+		}
+
+		log.Errorf("authenticate account failed: account=%s, err=%v", req.Account, err)
+		return &pb.LoginResponse{
+			Code:    int32(codes.InternalError.Code()),
+			Message: "登录失败",
+		}, nil
+	}
+
+	playerInfo := toPlayerInfo(user)
+
+	// 检查是否已经在线
+	online, err := s.sessions.IsOnline(playerInfo.Id)
+	if err != nil {
+		log.Errorf("check online failed: account=%s, err=%v", req.Account, err)
+		return &pb.LoginResponse{
+			Code:    int32(codes.InternalError.Code()),
+			Message: "登录失败",
+		}, nil
+	}
+
+	// 生成token
+	token, _, err := s.token.Generate(playerInfo.Id, req.DeviceId)
+	if err != nil {
+		log.Errorf("generate token failed: account=%s, err=%v", req.Account, err)
+		return &pb.LoginResponse{
+			Code:    int32(codes.InternalError.Code()),
+			Message: "登录失败",
+		}, nil
+	}
+
+	// 检查是否需要排队，已在线的玩家顶替原会话，不占用新名额
+	if !online {
+		queued, err := s.mustQueue()
+		if err != nil {
+			log.Errorf("check queue failed: %v", err)
+			return &pb.LoginResponse{
+				Code:    int32(codes.InternalError.Code()),
+				Message: "登录失败",
+			}, nil
+		}
+
+		if queued {
+			// 需要排队，排队期间凭token查询排队信息，重复登录保持原有位置
+			position, err := s.queue.Join(&queue.Ticket{
+				PlayerID: playerInfo.Id,
+				Account:  req.Account,
+				Token:    token,
+				DeviceID: req.DeviceId,
+				JoinTime: time.Now(),
+			})
+			if err != nil {
+				log.Errorf("join queue failed: account=%s, err=%v", req.Account, err)
+				return &pb.LoginResponse{
+					Code:    int32(codes.InternalError.Code()),
+					Message: "登录失败",
+				}, nil
+			}
+
+			log.Infof("Player %s added to queue, position: %d", req.Account, position)
+			return &pb.LoginResponse{
+				Code:          int32(codes.OK.Code()),
+				Message:       "您已进入排队队列",
+				Token:         token,
+				QueuePosition: int32(position),
+			}, nil
+		}
+	}
+
+	// 绑定会话，同一账号只保留最新的会话
+	old, err := s.sessions.Bind(&session.Session{
+		PlayerID:  playerInfo.Id,
+		Account:   req.Account,
+		Token:     token,
+		DeviceID:  req.DeviceId,
+		LoginTime: time.Now(),
+	})
+	if err != nil {
+		if errors.Is(err, session.ErrAlreadyOnline) {
+			log.Infof("Player %s is already online, reject new login", req.Account)
+			return &pb.LoginResponse{
+				Code:    int32(define.AlreadyOnline.Code()),
+				Message: "账号已在其他设备登录",
+			}, nil
+		}
+
+		log.Errorf("bind session failed: account=%s, err=%v", req.Account, err)
+		return &pb.LoginResponse{
+			Code:    int32(codes.InternalError.Code()),
+			Message: "登录失败",
+		}, nil
+	}
+
+	if old != nil {
+		log.Infof("Player %s is already online, kicking old connection", req.Account)
+		s.kickSession(old, nil, define.KickReasonDuplicateLogin, "账号在其他设备登录")
+	}
+
+	log.Infof("Player %s logged in successfully", req.Account)
+
+	return &pb.LoginResponse{
+		Code:    int32(codes.OK.Code()),
+		Message: "登录成功",
+		Token:   token,
+		Player:  playerInfo,
+	}, nil
+}
+
+func (s *Service) Reconnect(ctx context.Context, req *pb.ReconnectRequest) (*pb.LoginResponse, error) {
+	log.Debugf("Reconnect request: device_id=%s", req.DeviceId)
+
+	// 验证token
+	claims, err := s.token.Verify(req.Token)
+	if err != nil {
+		log.Debugf("verify token failed: %v", err)
+		return &pb.LoginResponse{
+			Code:    int32(codes.Unauthorized.Code()),
+			Message: "token无效，请重新登录",
+		}, nil
+	}
+
+	// 验证设备ID
+	if claims.DeviceID != req.DeviceId {
+		log.Warnf("Device ID mismatch for player %s", claims.PlayerID)
+		return &pb.LoginResponse{
+			Code:    int32(codes.Unknown.Code()),
+			Message: "设备不匹配，请使用原设备登录",
+		}, nil
+	}
+
+	// 查找会话，会话被顶替或踢出后原令牌失效
+	sess, err := s.sessions.Get(claims.PlayerID)
+	if err != nil {
+		log.Errorf("get session failed: player_id=%s, err=%v", claims.PlayerID, err)
+		return &pb.LoginResponse{
+			Code:    int32(codes.InternalError.Code()),
+			Message: "重连失败",
+		}, nil
+	}
+
+	if sess == nil || sess.Token != req.Token {
+		return &pb.LoginResponse{
+			Code:    int32(codes.Unauthorized.Code()),
+			Message: "会话已失效，请重新登录",
+		}, nil
+	}
+
+	// 查询玩家信息
+	user, err := s.accounts.GetByID(claims.PlayerID)
+	if err != nil || user == nil {
+		log.Errorf("query player failed: player_id=%s, err=%v", claims.PlayerID, err)
+		return &pb.LoginResponse{
+			Code:    int32(define.NotFoundUser.Code()),
+			Message: "玩家不存在",
+		}, nil
+	}
+	playerInfo := toPlayerInfo(user)
+
+	// 重新签发token
+	token, _, err := s.token.Generate(claims.PlayerID, claims.DeviceID)
+	if err != nil {
+		log.Errorf("generate token failed: player_id=%s, err=%v", claims.PlayerID, err)
+		return &pb.LoginResponse{
+			Code:    int32(codes.InternalError.Code()),
+			Message: "重连失败",
+		}, nil
+	}
+
+	// 更新令牌和重连时间，同时续期会话
+	sess.Token = token
+	sess.ReconnectTime = time.Now()
+
+	ok, err := s.sessions.Replace(sess, req.Token)
+	if err != nil {
+		log.Errorf("replace session failed: player_id=%s, err=%v", claims.PlayerID, err)
+		return &pb.LoginResponse{
+			Code:    int32(codes.InternalError.Code()),
+			Message: "重连失败",
+		}, nil
+	}
+
+	if !ok {
+		return &pb.LoginResponse{
+			Code:    int32(codes.Unauthorized.Code()),
+			Message: "会话已失效，请重新登录",
+		}, nil
+	}
+
+	log.Infof("Player %s reconnected successfully", sess.Account)
+
+	return &pb.LoginResponse{
+		Code:    int32(codes.OK.Code()),
+		Message: "重连成功",
+		Token:   token,
+		Player:  playerInfo,
+	}, nil
+}
+
+func (s *Service) KickPlayer(ctx context.Context, req *pb.KickPlayerRequest) (*pb.CommonResponse, error) {
+	log.Debugf("Kick player request: player_id=%s, reason=%s", req.PlayerId, req.Reason)
+
+	// 移除会话，会话令牌随之失效
+	removed, err := s.sessions.Remove(req.PlayerId, "")
+	if err != nil {
+		log.Errorf("remove session failed: player_id=%s, err=%v", req.PlayerId, err)
+		return &pb.CommonResponse{
+			Code:    int32(codes.InternalError.Code()),
+			Message: "踢出失败",
+		}, nil
+	}
+
+	if !removed {
+		return &pb.CommonResponse{
+			Code:    int32(codes.NotFound.Code()),
+			Message: "玩家不在线",
+		}, nil
+	}
+
+	// 通知玩家并断开连接
+	s.kickPlayer(req.PlayerId, define.KickReasonAdmin, req.Reason)
+
+	log.Infof("Player %s kicked out, reason: %s", req.PlayerId, req.Reason)
+
+	return &pb.CommonResponse{
+		Code:    int32(codes.OK.Code()),
+		Message: "踢出成功",
+	}, nil
+}
+
+func (s *Service) GetQueueInfo(ctx context.Context, req *pb.QueueInfoRequest) (*pb.QueueInfoResponse, error) {
+	log.Debugf("Get queue info request")
+
+	// 验证token获取玩家信息
+	claims, err := s.token.Verify(req.Token)
+	if err != nil {
+		log.Debugf("verify token failed: %v", err)
+		return &pb.QueueInfoResponse{
+			Code:    int32(codes.Unauthorized.Code()),
+			Message: "token无效",
+		}, nil
+	}
+
+	// 获取排队信息
+	position, total, err := s.queue.Position(claims.PlayerID)
+	if err != nil {
+		log.Errorf("get queue position failed: player_id=%s, err=%v", claims.PlayerID, err)
+		return &pb.QueueInfoResponse{
+			Code:    int32(codes.InternalError.Code()),
+			Message: "查询失败",
+		}, nil
+	}
+
+	if position == 0 {
+		// 已放行的玩家凭排队时的令牌进入游戏
+		sess, err := s.sessions.Get(claims.PlayerID)
+		if err == nil && sess != nil && sess.Token == req.Token {
+			return &pb.QueueInfoResponse{
+				Code:    int32(codes.OK.Code()),
+				Message: "排队完成，请进入游戏",
+				Total:   int32(total),
+			}, nil
+		}
+
+		return &pb.QueueInfoResponse{
+			Code:    int32(codes.NotFound.Code()),
+			Message: "您不在排队队列中",
+		}, nil
+	}
+
+	// 按近期放行速率估算等待时间
+	estimatedTime, err := s.queue.Estimate(position)
+	if err != nil {
+		log.Errorf("estimate queue time failed: player_id=%s, err=%v", claims.PlayerID, err)
+		estimatedTime = -1
+	}
+
+	return &pb.QueueInfoResponse{
+		Code:          int32(codes.OK.Code()),
+		Message:       "查询成功",
+		Position:      int32(position),
+		Total:         int32(total),
+		EstimatedTime: int32(estimatedTime),
+	}, nil
+}
+
+func (s *Service) LeaveQueue(ctx context.Context, req *pb.LeaveQueueRequest) (*pb.CommonResponse, error) {
+	log.Debugf("Leave queue request")
+
+	// 验证token获取玩家信息
+	claims, err := s.token.Verify(req.Token)
+	if err != nil {
+		log.Debugf("verify token failed: %v", err)
+		return &pb.CommonResponse{
+			Code:    int32(codes.Unauthorized.Code()),
+			Message: "token无效",
+		}, nil
+	}
+
+	left, err := s.queue.Leave(claims.PlayerID)
+	if err != nil {
+		log.Errorf("leave queue failed: player_id=%s, err=%v", claims.PlayerID, err)
+		return &pb.CommonResponse{
+			Code:    int32(codes.InternalError.Code()),
+			Message: "取消排队失败",
+		}, nil
+	}
+
+	if !left {
+		return &pb.CommonResponse{
+			Code:    int32(codes.NotFound.Code()),
+			Message: "您不在排队队列中",
+		}, nil
+	}
+
+	log.Infof("Player %s left queue", claims.PlayerID)
+
+	return &pb.CommonResponse{
+		Code:    int32(codes.OK.Code()),
+		Message: "已取消排队",
+	}, nil
+}
+
+// Authorize 网关连接鉴权，令牌须与在线会话或排队凭证一致；
+// 鉴权通过后记录客户端所在的网关连接，已在线的玩家同时续期会话，排队的玩家在连接期间保持活跃
+func (s *Service) Authorize(token, gid string, cid int64) *pb.AuthResponse {
+	claims, err := s.token.Verify(token)
+	if err != nil {
+		log.Debugf("verify token failed: %v", err)
+		return &pb.AuthResponse{
+			Code:    int32(codes.Unauthorized.Code()),
+			Message: "token无效，请重新登录",
+		}
+	}
+
+	if _, err = UID(claims.PlayerID); err != nil {
+		log.Warnf("authorize failed: player_id=%s, err=%v", claims.PlayerID, err)
+		return &pb.AuthResponse{
+			Code:    int32(codes.Unauthorized.Code()),
+			Message: "token无效，请重新登录",
+		}
+	}
+
+	sess, err := s.sessions.Get(claims.PlayerID)
+	if err != nil {
+		log.Errorf("get session failed: player_id=%s, err=%v", claims.PlayerID, err)
+		return &pb.AuthResponse{
+			Code:    int32(codes.InternalError.Code()),
+			Message: "鉴权失败",
+		}
+	}
+
+	if sess != nil && sess.Token == token {
+		ok, err := s.sessions.Attach(claims.PlayerID, token, gid, cid)
+		if err != nil {
+			log.Errorf("attach session failed: player_id=%s, err=%v", claims.PlayerID, err)
+			return &pb.AuthResponse{
+				Code:    int32(codes.InternalError.Code()),
+				Message: "鉴权失败",
+			}
+		}
+
+		if ok {
+			return &pb.AuthResponse{
+				Code:     int32(codes.OK.Code()),
+				Message:  "鉴权成功",
+				PlayerId: claims.PlayerID,
+			}
+		}
+	}
+
+	// 排队中的玩家凭排队凭证连接网关，以便接收放行通知
+	ok, err := s.queue.Attach(claims.PlayerID, token, gid, cid)
+	if err != nil {
+		log.Errorf("attach queue ticket failed: player_id=%s, err=%v", claims.PlayerID, err)
+		return &pb.AuthResponse{
+			Code:    int32(codes.InternalError.Code()),
+			Message: "鉴权失败",
+		}
+	}
+
+	if !ok {
+		return &pb.AuthResponse{
+			Code:    int32(codes.Unauthorized.Code()),
+			Message: "会话已失效，请重新登录",
+		}
+	}
+
+	s.track(claims.PlayerID, conn{gid: gid, cid: cid})
+
+	position, _, err := s.queue.Position(claims.PlayerID)
+	if err != nil {
+		log.Errorf("get queue position failed: player_id=%s, err=%v", claims.PlayerID, err)
+		return &pb.AuthResponse{
+			Code:    int32(codes.InternalError.Code()),
+			Message: "鉴权失败",
+		}
+	}
+
+	return &pb.AuthResponse{
+		Code:          int32(codes.OK.Code()),
+		Message:       "鉴权成功，排队中",
+		PlayerId:      claims.PlayerID,
+		QueuePosition: int32(position),
+	}
+}
+
+// Disconnected 玩家断开连接，仍在排队的玩家移出队列，释放排队位置；
+// 玩家已在其他连接上排队时保留排队位置
+func (s *Service) Disconnected(playerID, gid string, cid int64) {
+	s.untrack(playerID, &conn{gid: gid, cid: cid})
+
+	left, err := s.queue.Disconnect(playerID, gid, cid)
+	if err != nil {
+		log.Warnf("leave queue failed: player_id=%s, err=%v", playerID, err)
+		return
+	}
+
+	if left {
+		log.Infof("Player %s left queue on disconnect", playerID)
+	}
+}
+
+// 转换玩家信息
+func toPlayerInfo(player *define.Player) *pb.PlayerInfo {
+	return &pb.PlayerInfo{
+		Id:       player.ID,
+		Nickname: player.Nickname,
+		Level:    int32(player.Level),
+		Exp:      int32(player.Exp),
+	}
+}
+
+// 检查新登录的玩家是否需要排队，已有玩家排队时同样需要排队，保证先到先得
+func (s *Service) mustQueue() (bool, error) {
+	waiting, err := s.queue.Len()
+	if err != nil {
+		return false, err
+	}
+
+	if waiting > 0 {
+		return true, nil
+	}
+
+	vacancy, err := s.vacancy()
+	if err != nil {
+		return false, err
+	}
+
+	return vacancy <= 0, nil
+}
+
+// 获取空余名额
+func (s *Service) vacancy() (int64, error) {
+	count, err := s.sessions.Count()
+	if err != nil {
+		return 0, err
+	}
+
+	return s.queue.Capacity() - count, nil
+}
+
+// 放行排队玩家，建立会话并通过网关通知客户端；
+// 其他设备已在线时通知客户端放行失败，其他错误按原入队时间放回队列，下一轮重新放行
+func (s *Service) admit(ticket *queue.Ticket) {
+	s.untrack(ticket.PlayerID, nil)
+
+	sess := &session.Session{
+		PlayerID:  ticket.PlayerID,
+		Account:   ticket.Account,
+		Token:     ticket.Token,
+		DeviceID:  ticket.DeviceID,
+		LoginTime: time.Now(),
+		GID:       ticket.GID,
+		CID:       ticket.CID,
+	}
+
+	old, err := s.sessions.Bind(sess)
+	if err != nil {
+		if errors.Is(err, session.ErrAlreadyOnline) {
+			log.Infof("Player %s is already online, reject queued login", ticket.Account)
+			s.push(ticket.PlayerID, define.RouteQueueRejected, &pb.QueueRejectedNotify{
+				Code:    int32(define.AlreadyOnline.Code()),
+				Message: "账号已在其他设备登录",
+			})
+			return
+		}
+
+		log.Errorf("bind session failed: player_id=%s, err=%v", ticket.PlayerID, err)
+
+		if _, err = s.queue.Requeue(ticket); err != nil {
+			log.Errorf("requeue ticket failed: player_id=%s, err=%v", ticket.PlayerID, err)
+			s.push(ticket.PlayerID, define.RouteQueueRejected, &pb.QueueRejectedNotify{
+				Code:    int32(codes.InternalError.Code()),
+				Message: "排队失败，请重新登录",
+			})
+		}
+		return
+	}
+
+	if old != nil {
+		s.kickSession(old, sess, define.KickReasonDuplicateLogin, "账号在其他设备登录")
+	}
+
+	log.Infof("Player %s admitted from queue, waited %s", ticket.Account, time.Since(ticket.JoinTime))
+
+	// 客户端未连接网关时忽略，客户端可通过查询排队信息得知已放行
+	s.push(ticket.PlayerID, define.RouteQueueAdmitted, &pb.QueueAdmittedNotify{
+		PlayerId: ticket.PlayerID,
+		Token:    ticket.Token,
+	})
+}
+
+// 通过网关向玩家推送消息，玩家未连接网关时忽略
+func (s *Service) push(playerID string, route int32, data any) {
+	uid, err := UID(playerID)
+	if err != nil {
+		log.Warnf("push message failed: player_id=%s, route=%d, err=%v", playerID, route, err)
+		return
+	}
+
+	err = s.proxy.Push(context.Background(), &cluster.PushArgs{
+		Kind:   dsession.User,
+		Target: uid,
+		Message: &cluster.Message{
+			Route: route,
+			Data:  data,
+		},
+	})
+	if err != nil {
+		log.Debugf("push message failed: player_id=%s, route=%d, err=%v", playerID, route, err)
+	}
+}
+
+// UID 玩家ID转换为网关绑定的用户ID
+func UID(playerID string) (int64, error) {
+	uid, err := strconv.ParseInt(playerID, 10, 64)
+	if err != nil || uid <= 0 {
+		return 0, fmt.Errorf("invalid player id %q", playerID)
+	}
+
+	return uid, nil
+}
+
+// 踢出被顶替的会话：按旧会话记录的网关连接断开，避免误踢已绑定同一玩家的新连接；
+// 旧会话未连接网关或与新会话为同一连接时无需断开
+func (s *Service) kickSession(old, cur *session.Session, reason int32, message string) {
+	if old.GID == "" {
+		return
+	}
+
+	if cur != nil && cur.GID == old.GID && cur.CID == old.CID {
+		return
+	}
+
+	s.kick(old.PlayerID, old.GID, dsession.Conn, old.CID, reason, message)
+}
+
+// 踢出玩家：通过定位器查找玩家所在网关，断开玩家当前绑定的连接
+func (s *Service) kickPlayer(playerID string, reason int32, message string) {
+	uid, err := UID(playerID)
+	if err != nil {
+		log.Warnf("kick player failed: player_id=%s, err=%v", playerID, err)
+		return
+	}
+
+	gid, err := s.proxy.LocateGate(context.Background(), uid)
+	if err != nil {
+		log.Debugf("locate gate failed: player_id=%s, err=%v", playerID, err)
+		return
+	}
+
+	s.kick(playerID, gid, dsession.User, uid, reason, message)
+}
+
+// 踢下线：推送踢出原因后断开连接
+func (s *Service) kick(playerID, gid string, kind dsession.Kind, target int64, reason int32, message string) {
+	ctx := context.Background()
+
+	err := s.proxy.Push(ctx, &cluster.PushArgs{
+		GID:    gid,
+		Kind:   kind,
+		Target: target,
+		Message: &cluster.Message{
+			Route: define.RouteKicked,
+			Data: &pb.KickNotify{
+				Reason:  reason,
+				Message: message,
+			},
+		},
+	})
+	if err != nil {
+		log.Warnf("push kick notify failed: player_id=%s, err=%v", playerID, err)
+	}
+
+	err = s.proxy.Disconnect(ctx, &cluster.DisconnectArgs{
+		GID:    gid,
+		Kind:   kind,
+		Target: target,
+	})
+	if err != nil {
+		log.Warnf("disconnect player failed: player_id=%s, err=%v", playerID, err)
+	}
+}
+
+// 记录已连接网关的排队玩家
+func (s *Service) track(playerID string, c conn) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.waiters[playerID] = c
+}
+
+// 取消记录排队玩家；指定连接时仅当记录的正是该连接才取消
+func (s *Service) untrack(playerID string, c *conn) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if cur, ok := s.waiters[playerID]; ok && (c == nil || cur == *c) {
+		delete(s.waiters, playerID)
+	}
+}
+
+// 排队玩家保活：连接网关期间定期刷新活跃时间，避免未主动查询排队信息的玩家超时出队
+func (s *Service) keepalive() {
+	ticker := time.NewTicker(s.queue.Timeout() / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			s.mutex.Lock()
+			waiters := make(map[string]conn, len(s.waiters))
+			playerIDs := make([]string, 0, len(s.waiters))
+			for playerID, c := range s.waiters {
+				waiters[playerID] = c
+				playerIDs = append(playerIDs, playerID)
+			}
+			s.mutex.Unlock()
+
+			missing, err := s.queue.Touch(playerIDs...)
+			if err != nil {
+				log.Warnf("touch queue failed: %v", err)
+				continue
+			}
+
+			// 已放行或已离开队列的玩家无需继续保活，期间重新鉴权的连接保留
+			for _, playerID := range missing {
+				c := waiters[playerID]
+				s.untrack(playerID, &c)
+			}
+		}
+	}
+}
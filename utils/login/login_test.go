@@ -0,0 +1,121 @@
+package login
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"ghserver/utils/session"
+
+	"github.com/dobyte/due/v2/cluster"
+	dsession "github.com/dobyte/due/v2/session"
+)
+
+func TestServiceKickSession(t *testing.T) {
+	old := &session.Session{PlayerID: "100001", GID: "gate-1", CID: 7}
+
+	cases := []struct {
+		name string
+		old  *session.Session
+		cur  *session.Session
+		want []cluster.DisconnectArgs
+	}{
+		{
+			name: "新会话未连接网关",
+			old:  old,
+			want: []cluster.DisconnectArgs{{GID: "gate-1", Kind: dsession.Conn, Target: 7}},
+		},
+		{
+			name: "新会话在其他连接上",
+			old:  old,
+			cur:  &session.Session{PlayerID: "100001", GID: "gate-2", CID: 7},
+			want: []cluster.DisconnectArgs{{GID: "gate-1", Kind: dsession.Conn, Target: 7}},
+		},
+		{
+			name: "新旧会话为同一连接",
+			old:  old,
+			cur:  &session.Session{PlayerID: "100001", GID: "gate-1", CID: 7},
+		},
+		{
+			name: "旧会话未连接网关",
+			old:  &session.Session{PlayerID: "100001"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			proxy := &fakeProxy{}
+			s := &Service{proxy: proxy}
+
+			s.kickSession(c.old, c.cur, 0, "")
+
+			if len(proxy.disconnects) != len(c.want) {
+				t.Fatalf("disconnects = %v, want %v", proxy.disconnects, c.want)
+			}
+
+			for i, args := range proxy.disconnects {
+				if args != c.want[i] {
+					t.Errorf("disconnect[%d] = %+v, want %+v", i, args, c.want[i])
+				}
+			}
+
+			if proxy.located != 0 {
+				t.Errorf("located gates = %d, want 0", proxy.located)
+			}
+		})
+	}
+}
+
+func TestServiceUntrack(t *testing.T) {
+	s := &Service{waiters: make(map[string]conn)}
+	s.track("100001", conn{gid: "gate-1", cid: 1})
+	s.track("100002", conn{gid: "gate-1", cid: 2})
+
+	// 旧连接断开时保留玩家在新连接上的记录
+	s.untrack("100001", &conn{gid: "gate-1", cid: 9})
+	if _, ok := s.waiters["100001"]; !ok {
+		t.Errorf("player tracked on another connection was untracked")
+	}
+
+	s.untrack("100001", &conn{gid: "gate-1", cid: 1})
+	if _, ok := s.waiters["100001"]; ok {
+		t.Errorf("player still tracked after its connection closed")
+	}
+
+	s.untrack("100002", nil)
+	if len(s.waiters) != 0 {
+		t.Errorf("waiters = %v, want empty", s.waiters)
+	}
+}
+
+// 测试集群代理，记录推送及断开的连接
+type fakeProxy struct {
+	mutex       sync.Mutex
+	located     int
+	pushes      []cluster.PushArgs
+	disconnects []cluster.DisconnectArgs
+}
+
+func (p *fakeProxy) LocateGate(ctx context.Context, uid int64) (string, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.located++
+	return "gate-1", nil
+}
+
+func (p *fakeProxy) Push(ctx context.Context, args *cluster.PushArgs) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.pushes = append(p.pushes, *args)
+	return nil
+}
+
+func (p *fakeProxy) Disconnect(ctx context.Context, args *cluster.DisconnectArgs) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.disconnects = append(p.disconnects, *args)
+	return nil
+}
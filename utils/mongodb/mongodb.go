@@ -2,6 +2,7 @@ package mongodb
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -15,7 +16,7 @@ import (
 )
 
 var factory = pool.NewFactory(func(name string) (*Client, error) {
-	return NewInstance("etc.mongo")
+	return NewInstance(fmt.Sprintf("etc.mongo.%s", name))
 })
 
 type (
@@ -85,7 +86,7 @@ type MongoDBClient struct {
 func NewMongoDBClient(database string, collection string) (*MongoDBClient, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	client := Instance(database)
+	client := Instance()
 	// 测试连接
 	err := client.Ping(ctx, readpref.Primary())
 	if err != nil {
@@ -131,6 +132,27 @@ func (m *MongoDBClient) InsertMany(documents []interface{}) ([]interface{}, erro
 	return result.InsertedIDs, nil
 }
 
+// InsertManyIgnoreDuplicate 插入多条数据，跳过主键已存在的数据
+func (m *MongoDBClient) InsertManyIgnoreDuplicate(documents []interface{}) error {
+	_, err := m.GetCollection().InsertMany(context.Background(), documents, options.InsertMany().SetOrdered(false))
+	if err == nil {
+		return nil
+	}
+
+	var bulkErr mongo.BulkWriteException
+	if !errors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil {
+		return err
+	}
+
+	for _, writeErr := range bulkErr.WriteErrors {
+		if !mongo.IsDuplicateKeyError(writeErr) {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // FindOne 查询单条数据
 func (m *MongoDBClient) FindOne(filter interface{}, result interface{}) error {
 	return m.GetCollection().FindOne(context.Background(), filter).Decode(result)
@@ -155,9 +177,28 @@ func (m *MongoDBClient) Find(filter interface{}, results interface{}, limit int6
 	return cursor.All(context.Background(), results)
 }
 
+// FindSort 按排序条件查询多条数据
+func (m *MongoDBClient) FindSort(filter interface{}, results interface{}, sort interface{}, limit int64, skip int64) error {
+	options := options.Find().SetSort(sort)
+	if limit > 0 {
+		options.SetLimit(limit)
+	}
+	if skip > 0 {
+		options.SetSkip(skip)
+	}
+
+	cursor, err := m.GetCollection().Find(context.Background(), filter, options)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(context.Background())
+
+	return cursor.All(context.Background(), results)
+}
+
 // UpdateOne 更新单条数据
-func (m *MongoDBClient) UpdateOne(filter interface{}, update interface{}) error {
-	_, err := m.GetCollection().UpdateOne(context.Background(), filter, update)
+func (m *MongoDBClient) UpdateOne(filter interface{}, update interface{}, opts ...*options.UpdateOptions) error {
+	_, err := m.GetCollection().UpdateOne(context.Background(), filter, update, opts...)
 	return err
 }
 
@@ -202,8 +243,8 @@ func (m *MongoDBClient) Aggregate(pipeline interface{}, results interface{}) err
 }
 
 // FindOneAndUpdate 查询并更新
-func (m *MongoDBClient) FindOneAndUpdate(filter interface{}, update interface{}, result interface{}) error {
-	return m.GetCollection().FindOneAndUpdate(context.Background(), filter, update).Decode(result)
+func (m *MongoDBClient) FindOneAndUpdate(filter interface{}, update interface{}, result interface{}, opts ...*options.FindOneAndUpdateOptions) error {
+	return m.GetCollection().FindOneAndUpdate(context.Background(), filter, update, opts...).Decode(result)
 }
 
 // FindOneAndReplace 查询并替换
@@ -231,9 +272,25 @@ func (m *MongoDBClient) EnsureIndex(indexName string, keys interface{}, unique b
 	return err
 }
 
+// EnsureTTLIndex 确保TTL索引存在，文档在field字段时间之后expireAfter自动删除
+func (m *MongoDBClient) EnsureTTLIndex(indexName string, field string, expireAfter time.Duration) error {
+	idxOptions := options.Index()
+	idxOptions.SetName(indexName)
+	idxOptions.SetExpireAfterSeconds(int32(expireAfter.Seconds()))
+
+	indexModel := mongo.IndexModel{
+		Keys:    bson.D{{Key: field, Value: 1}},
+		Options: idxOptions,
+	}
+
+	_, err := m.GetCollection().Indexes().CreateOne(context.Background(), indexModel)
+	return err
+}
+
 // DropIndex 删除索引
 func (m *MongoDBClient) DropIndex(indexName string) error {
-	return m.GetCollection().Indexes().DropOne(context.Background(), indexName)
+	_, err := m.GetCollection().Indexes().DropOne(context.Background(), indexName)
+	return err
 }
 
 // ListIndexes 列出所有索引
@@ -258,6 +315,20 @@ func (m *MongoDBClient) ListIndexes() ([]string, error) {
 	return indexes, nil
 }
 
+// WithTransaction 在事务中执行fn，fn内的操作需使用传入的ctx；遇到临时错误时自动重试fn
+func (m *MongoDBClient) WithTransaction(fn func(ctx mongo.SessionContext) error) error {
+	session, err := m.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(context.Background())
+
+	_, err = session.WithTransaction(context.Background(), func(ctx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(ctx)
+	})
+	return err
+}
+
 // GetDatabaseStats 获取数据库统计信息
 func (m *MongoDBClient) GetDatabaseStats() (bson.M, error) {
 	var stats bson.M
@@ -0,0 +1,75 @@
+package pricing
+
+import (
+	"math"
+	"math/big"
+)
+
+// BasisPoints 基点，1基点为万分之一
+const BasisPoints = 10000
+
+// Rate 折扣率，以基点表示，10000表示原价，7000表示七折
+type Rate int64
+
+// Full 不打折
+const Full Rate = BasisPoints
+
+// Rounding 取整方式
+type Rounding int
+
+const (
+	RoundDown   Rounding = iota // 向下取整
+	RoundHalfUp                 // 四舍五入
+	RoundUp                     // 向上取整
+)
+
+// FromFloat 将小数折扣率转换为基点，按最接近的基点取整，避免浮点误差
+func FromFloat(f float64) Rate {
+	return Rate(math.Round(f * BasisPoints))
+}
+
+// Float32 转换为小数折扣率，仅用于展示
+func (r Rate) Float32() float32 {
+	return float32(r) / BasisPoints
+}
+
+// Discounted 是否打折
+func (r Rate) Discounted() bool {
+	return r < Full
+}
+
+// Apply 按折扣率计算价格，多个折扣率相乘，仅在最后按取整方式取整一次
+func Apply(price int64, rounding Rounding, rates ...Rate) int64 {
+	num := big.NewInt(price)
+	den := big.NewInt(1)
+	for _, rate := range rates {
+		num.Mul(num, big.NewInt(int64(rate)))
+		den.Mul(den, big.NewInt(BasisPoints))
+	}
+
+	return divide(num, den, rounding).Int64()
+}
+
+// Multiply 计算多个折扣率相乘后的折扣率，按取整方式取整到基点
+func Multiply(rounding Rounding, rates ...Rate) Rate {
+	return Rate(Apply(BasisPoints, rounding, rates...))
+}
+
+// 非负数除法，按取整方式取整
+func divide(num, den *big.Int, rounding Rounding) *big.Int {
+	quo, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Sign() == 0 {
+		return quo
+	}
+
+	switch rounding {
+	case RoundUp:
+		quo.Add(quo, big.NewInt(1))
+	case RoundHalfUp:
+		if rem.Lsh(rem, 1).Cmp(den) >= 0 {
+			quo.Add(quo, big.NewInt(1))
+		}
+	}
+
+	return quo
+}
@@ -0,0 +1,74 @@
+package pricing
+
+import "testing"
+
+func TestFromFloat(t *testing.T) {
+	cases := []struct {
+		f    float32
+		want Rate
+	}{
+		{1, Full},
+		{0.9, 9000},
+		{0.8, 8000},
+		{0.7, 7000},
+		{0.07, 700},
+		{0.0001, 1},
+		{0, 0},
+	}
+
+	for _, c := range cases {
+		if got := FromFloat(float64(c.f)); got != c.want {
+			t.Errorf("FromFloat(%v) = %d, want %d", c.f, got, c.want)
+		}
+	}
+}
+
+func TestApplyRounding(t *testing.T) {
+	cases := []struct {
+		price int64
+		rates []Rate
+		down  int64
+		half  int64
+		up    int64
+	}{
+		{5000, []Rate{7000}, 3500, 3500, 3500},
+		{999, []Rate{7000}, 699, 699, 700},
+		{995, []Rate{7000}, 696, 697, 697},
+		{1, []Rate{5000}, 0, 1, 1},
+		{1, []Rate{4999}, 0, 0, 1},
+		{5000, []Rate{7000, 9000}, 3150, 3150, 3150},
+		{333, []Rate{9000, 9000}, 269, 270, 270},
+		{100, nil, 100, 100, 100},
+		{0, []Rate{7000}, 0, 0, 0},
+	}
+
+	for _, c := range cases {
+		for rounding, want := range map[Rounding]int64{RoundDown: c.down, RoundHalfUp: c.half, RoundUp: c.up} {
+			if got := Apply(c.price, rounding, c.rates...); got != want {
+				t.Errorf("Apply(%d, %d, %v) = %d, want %d", c.price, rounding, c.rates, got, want)
+			}
+		}
+	}
+}
+
+func TestMultiply(t *testing.T) {
+	cases := []struct {
+		rates []Rate
+		down  Rate
+		half  Rate
+		up    Rate
+	}{
+		{[]Rate{7000, 9000}, 6300, 6300, 6300},
+		{[]Rate{3333, 3333}, 1110, 1111, 1111},
+		{[]Rate{Full, 8000}, 8000, 8000, 8000},
+		{nil, Full, Full, Full},
+	}
+
+	for _, c := range cases {
+		for rounding, want := range map[Rounding]Rate{RoundDown: c.down, RoundHalfUp: c.half, RoundUp: c.up} {
+			if got := Multiply(rounding, c.rates...); got != want {
+				t.Errorf("Multiply(%d, %v) = %d, want %d", rounding, c.rates, got, want)
+			}
+		}
+	}
+}
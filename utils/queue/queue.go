@@ -0,0 +1,525 @@
+package queue
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"ghserver/utils/redis"
+
+	"github.com/dobyte/due/v2/core/pool"
+	"github.com/dobyte/due/v2/etc"
+	xlog "github.com/dobyte/due/v2/log"
+	"github.com/dobyte/due/v2/utils/xconv"
+)
+
+const (
+	defaultPrefix   = "login_queue"
+	defaultCapacity = 100
+	defaultBatch    = 50
+	defaultInterval = time.Second
+	defaultTimeout  = 5 * time.Minute
+	defaultWindow   = time.Minute
+)
+
+// 入队：已在队列中时仅刷新排队凭证，保持原有位置
+// KEYS[1] 排队集合 KEYS[2] 排队凭证 KEYS[3] 活跃时间
+// ARGV[1] 玩家ID ARGV[2] 排队时间戳(毫秒) ARGV[3] 排队凭证 ARGV[4] 当前时间戳(毫秒)
+const joinScript = `
+redis.call('ZADD', KEYS[1], 'NX', ARGV[2], ARGV[1])
+redis.call('HSET', KEYS[2], ARGV[1], ARGV[3])
+redis.call('ZADD', KEYS[3], ARGV[4], ARGV[1])
+return redis.call('ZRANK', KEYS[1], ARGV[1]) + 1
+`
+
+// 查询位置：在队列中时同时刷新活跃时间
+// KEYS[1] 排队集合 KEYS[3] 活跃时间
+// ARGV[1] 玩家ID ARGV[2] 当前时间戳(毫秒)
+const positionScript = `
+local rank = redis.call('ZRANK', KEYS[1], ARGV[1])
+local total = redis.call('ZCARD', KEYS[1])
+if not rank then
+	return {0, total}
+end
+redis.call('ZADD', KEYS[3], 'XX', ARGV[2], ARGV[1])
+return {rank + 1, total}
+`
+
+// 出队：按排队顺序弹出并记录放行时间，用于统计放行速率
+// KEYS[1] 排队集合 KEYS[2] 排队凭证 KEYS[3] 活跃时间 KEYS[4] 放行记录
+// ARGV[1] 数量 ARGV[2] 当前时间戳(毫秒)
+const popScript = `
+local ids = redis.call('ZRANGE', KEYS[1], 0, tonumber(ARGV[1]) - 1)
+local items = {}
+for _, id in ipairs(ids) do
+	local data = redis.call('HGET', KEYS[2], id)
+	if data then
+		table.insert(items, data)
+	end
+	redis.call('ZADD', KEYS[4], ARGV[2], id .. ':' .. ARGV[2])
+end
+if #ids > 0 then
+	redis.call('ZREM', KEYS[1], unpack(ids))
+	redis.call('ZREM', KEYS[3], unpack(ids))
+	redis.call('HDEL', KEYS[2], unpack(ids))
+end
+return items
+`
+
+// 移除：KEYS同出队，ARGV[1] 玩家ID
+const leaveScript = `
+local removed = redis.call('ZREM', KEYS[1], ARGV[1])
+redis.call('ZREM', KEYS[3], ARGV[1])
+redis.call('HDEL', KEYS[2], ARGV[1])
+return removed
+`
+
+// 关联网关连接：令牌与排队凭证一致时记录客户端所在的网关连接并刷新活跃时间
+// KEYS同出队，ARGV[1] 玩家ID ARGV[2] 令牌 ARGV[3] 网关ID ARGV[4] 连接ID ARGV[5] 当前时间戳(毫秒)
+const attachScript = `
+local data = redis.call('HGET', KEYS[2], ARGV[1])
+if not data then
+	return 0
+end
+local ticket = cjson.decode(data)
+if ticket['token'] ~= ARGV[2] then
+	return 0
+end
+ticket['gid'] = ARGV[3]
+ticket['cid'] = ARGV[4]
+redis.call('HSET', KEYS[2], ARGV[1], cjson.encode(ticket))
+redis.call('ZADD', KEYS[3], 'XX', ARGV[5], ARGV[1])
+return 1
+`
+
+// 刷新活跃时间：返回已不在队列中的玩家
+// KEYS同出队，ARGV[1] 当前时间戳(毫秒) ARGV[2...] 玩家ID
+const touchScript = `
+local missing = {}
+for i = 2, #ARGV do
+	if redis.call('ZSCORE', KEYS[1], ARGV[i]) then
+		redis.call('ZADD', KEYS[3], ARGV[1], ARGV[i])
+	else
+		table.insert(missing, ARGV[i])
+	end
+end
+return missing
+`
+
+// 连接断开：排队凭证未关联连接或关联的正是断开的连接时移出队列，
+// 避免旧连接断开时移除已在新连接上排队的玩家
+// KEYS同出队，ARGV[1] 玩家ID ARGV[2] 网关ID ARGV[3] 连接ID
+const disconnectScript = `
+local data = redis.call('HGET', KEYS[2], ARGV[1])
+if data then
+	local ticket = cjson.decode(data)
+	if ticket['gid'] and (ticket['gid'] ~= ARGV[2] or ticket['cid'] ~= ARGV[3]) then
+		return 0
+	end
+end
+local removed = redis.call('ZREM', KEYS[1], ARGV[1])
+redis.call('ZREM', KEYS[3], ARGV[1])
+redis.call('HDEL', KEYS[2], ARGV[1])
+return removed
+`
+
+// 超时清理：移除超过时限未活跃的排队玩家
+// KEYS同出队，ARGV[1] 活跃截止时间戳(毫秒) ARGV[2] 放行记录截止时间戳(毫秒)
+const expireScript = `
+local ids = redis.call('ZRANGEBYSCORE', KEYS[3], '-inf', ARGV[1], 'LIMIT', 0, 1000)
+if #ids > 0 then
+	redis.call('ZREM', KEYS[1], unpack(ids))
+	redis.call('ZREM', KEYS[3], unpack(ids))
+	redis.call('HDEL', KEYS[2], unpack(ids))
+end
+redis.call('ZREMRANGEBYSCORE', KEYS[4], '-inf', ARGV[2])
+return #ids
+`
+
+var factory = pool.NewFactory(func(name string) (*Queue, error) {
+	return NewQueue(fmt.Sprintf("etc.queue.%s", name))
+})
+
+var ErrInvalidTicket = errors.New("invalid queue ticket")
+
+type Config struct {
+	Redis    string        `json:"redis"`    // Redis实例名
+	Prefix   string        `json:"prefix"`   // 键前缀
+	Capacity int64         `json:"capacity"` // 在线容量，达到容量后新登录的玩家需排队
+	Batch    int64         `json:"batch"`    // 每轮最多放行人数
+	Interval time.Duration `json:"interval"` // 放行周期
+	Timeout  time.Duration `json:"timeout"`  // 排队超时时间，超时未查询排队信息且未连接网关的玩家移出队列
+	Window   time.Duration `json:"window"`   // 放行速率统计窗口
+}
+
+// Ticket 排队凭证
+type Ticket struct {
+	PlayerID string    `json:"player_id"`            // 玩家ID
+	Account  string    `json:"account"`              // 账号
+	Token    string    `json:"token"`                // 登录令牌
+	DeviceID string    `json:"device_id"`            // 设备ID
+	JoinTime time.Time `json:"join_time"`            // 入队时间
+	GID      string    `json:"gid,omitempty"`        // 客户端所在网关ID，连接网关鉴权后记录
+	CID      int64     `json:"cid,string,omitempty"` // 客户端在网关上的连接ID
+}
+
+// VacancyFunc 获取空余名额
+type VacancyFunc func() (int64, error)
+
+// AdmitFunc 放行排队玩家
+type AdmitFunc func(ticket *Ticket)
+
+// Queue 登录排队队列，集群内共享
+type Queue struct {
+	client   *redis.RedisClient
+	prefix   string
+	capacity int64
+	batch    int64
+	interval time.Duration
+	timeout  time.Duration
+	window   time.Duration
+	done     chan struct{}
+}
+
+// Instance 获取实例
+func Instance(name ...string) *Queue {
+	var (
+		err error
+		ins *Queue
+	)
+
+	if len(name) == 0 {
+		ins, err = factory.Get("default")
+	} else {
+		ins, err = factory.Get(name[0])
+	}
+
+	if err != nil {
+		log.Fatalf("create queue instance failed: %v", err)
+	}
+
+	return ins
+}
+
+// NewQueue 新建排队队列
+func NewQueue[T string | Config | *Config](config T) (*Queue, error) {
+	var (
+		conf *Config
+		v    any = config
+	)
+
+	switch c := v.(type) {
+	case string:
+		conf = &Config{
+			Redis:    etc.Get(fmt.Sprintf("%s.redis", c), "default").String(),
+			Prefix:   etc.Get(fmt.Sprintf("%s.prefix", c), defaultPrefix).String(),
+			Capacity: etc.Get(fmt.Sprintf("%s.capacity", c), defaultCapacity).Int64(),
+			Batch:    etc.Get(fmt.Sprintf("%s.batch", c), defaultBatch).Int64(),
+			Interval: etc.Get(fmt.Sprintf("%s.interval", c), defaultInterval).Duration(),
+			Timeout:  etc.Get(fmt.Sprintf("%s.timeout", c), defaultTimeout).Duration(),
+			Window:   etc.Get(fmt.Sprintf("%s.window", c), defaultWindow).Duration(),
+		}
+	case Config:
+		conf = &c
+	case *Config:
+		conf = c
+	}
+
+	q := &Queue{
+		client:   redis.Instance(conf.Redis),
+		prefix:   conf.Prefix,
+		capacity: conf.Capacity,
+		batch:    conf.Batch,
+		interval: conf.Interval,
+		timeout:  conf.Timeout,
+		window:   conf.Window,
+		done:     make(chan struct{}),
+	}
+
+	if q.prefix == "" {
+		q.prefix = defaultPrefix
+	}
+
+	if q.capacity <= 0 {
+		q.capacity = defaultCapacity
+	}
+
+	if q.batch <= 0 {
+		q.batch = defaultBatch
+	}
+
+	if q.interval <= 0 {
+		q.interval = defaultInterval
+	}
+
+	if q.timeout <= 0 {
+		q.timeout = defaultTimeout
+	}
+
+	if q.window <= 0 {
+		q.window = defaultWindow
+	}
+
+	return q, nil
+}
+
+// Capacity 在线容量
+func (q *Queue) Capacity() int64 {
+	return q.capacity
+}
+
+// Timeout 排队超时时间
+func (q *Queue) Timeout() time.Duration {
+	return q.timeout
+}
+
+// Join 加入队列并返回排队位置，重复加入时保持原有位置
+func (q *Queue) Join(ticket *Ticket) (int64, error) {
+	now := time.Now()
+
+	return q.join(ticket, now, now)
+}
+
+// Requeue 将放行失败的玩家按原入队时间放回队列，排在之后入队的玩家之前
+func (q *Queue) Requeue(ticket *Ticket) (int64, error) {
+	if ticket == nil {
+		return 0, ErrInvalidTicket
+	}
+
+	return q.join(ticket, ticket.JoinTime, time.Now())
+}
+
+// Position 查询排队位置及队列总人数，不在队列中时位置为0
+func (q *Queue) Position(playerID string) (position, total int64, err error) {
+	reply, err := q.client.Eval(positionScript, q.keys(), playerID, time.Now().UnixMilli())
+	if err != nil {
+		return 0, 0, err
+	}
+
+	values, ok := reply.([]interface{})
+	if !ok || len(values) != 2 {
+		return 0, 0, fmt.Errorf("unexpected reply: %v", reply)
+	}
+
+	return xconv.Int64(values[0]), xconv.Int64(values[1]), nil
+}
+
+// Ticket 获取排队凭证，不在队列中时返回nil
+func (q *Queue) Ticket(playerID string) (*Ticket, error) {
+	data, err := q.client.HGet(q.ticketKey(), playerID)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	ticket := &Ticket{}
+	if err = json.Unmarshal([]byte(data), ticket); err != nil {
+		return nil, err
+	}
+
+	return ticket, nil
+}
+
+// Len 队列总人数
+func (q *Queue) Len() (int64, error) {
+	return q.client.ZCard(q.waitingKey())
+}
+
+// Leave 离开队列
+func (q *Queue) Leave(playerID string) (bool, error) {
+	reply, err := q.client.Eval(leaveScript, q.keys(), playerID)
+	if err != nil {
+		return false, err
+	}
+
+	return xconv.Int64(reply) == 1, nil
+}
+
+// Attach 记录排队玩家所在的网关连接，令牌与排队凭证不一致或不在队列中时返回false
+func (q *Queue) Attach(playerID, token, gid string, cid int64) (bool, error) {
+	reply, err := q.client.Eval(attachScript, q.keys(), playerID, token, gid, cid, time.Now().UnixMilli())
+	if err != nil {
+		return false, err
+	}
+
+	return xconv.Int64(reply) == 1, nil
+}
+
+// Touch 刷新排队玩家的活跃时间，返回已不在队列中的玩家
+func (q *Queue) Touch(playerIDs ...string) ([]string, error) {
+	if len(playerIDs) == 0 {
+		return nil, nil
+	}
+
+	args := make([]interface{}, 0, len(playerIDs)+1)
+	args = append(args, time.Now().UnixMilli())
+	for _, playerID := range playerIDs {
+		args = append(args, playerID)
+	}
+
+	reply, err := q.client.Eval(touchScript, q.keys(), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	values, _ := reply.([]interface{})
+	missing := make([]string, 0, len(values))
+	for _, value := range values {
+		missing = append(missing, xconv.String(value))
+	}
+
+	return missing, nil
+}
+
+// Disconnect 网关连接断开，排队凭证关联的是其他连接时保留排队位置
+func (q *Queue) Disconnect(playerID, gid string, cid int64) (bool, error) {
+	reply, err := q.client.Eval(disconnectScript, q.keys(), playerID, gid, cid)
+	if err != nil {
+		return false, err
+	}
+
+	return xconv.Int64(reply) == 1, nil
+}
+
+// Estimate 根据统计窗口内的放行速率估算等待时间（秒），暂无放行记录时返回-1
+func (q *Queue) Estimate(position int64) (int64, error) {
+	now := time.Now()
+
+	admitted, err := q.client.ZCount(q.admittedKey(), xconv.String(now.Add(-q.window).UnixMilli()), "+inf")
+	if err != nil {
+		return 0, err
+	}
+
+	if admitted == 0 {
+		return -1, nil
+	}
+
+	rate := float64(admitted) / q.window.Seconds()
+
+	return int64(math.Ceil(float64(position) / rate)), nil
+}
+
+// Serve 启动放行循环；每个周期集群内只有一个节点执行放行
+func (q *Queue) Serve(vacancy VacancyFunc, admit AdmitFunc) {
+	go func() {
+		ticker := time.NewTicker(q.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-q.done:
+				return
+			case <-ticker.C:
+				if err := q.tick(vacancy, admit); err != nil {
+					xlog.Errorf("queue tick failed: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// Stop 停止放行循环
+func (q *Queue) Stop() {
+	select {
+	case <-q.done:
+	default:
+		close(q.done)
+	}
+}
+
+// 执行一轮超时清理及放行
+func (q *Queue) tick(vacancy VacancyFunc, admit AdmitFunc) error {
+	now := time.Now()
+
+	expired, err := q.client.Eval(expireScript, q.keys(), now.Add(-q.timeout).UnixMilli(), now.Add(-q.window).UnixMilli())
+	if err != nil {
+		return err
+	}
+
+	if n := xconv.Int64(expired); n > 0 {
+		xlog.Infof("%d players removed from queue due to timeout", n)
+	}
+
+	ok, err := q.client.SetNX(q.lockKey(), 1, q.interval)
+	if err != nil || !ok {
+		return err
+	}
+
+	free, err := vacancy()
+	if err != nil {
+		return err
+	}
+
+	if free <= 0 {
+		return nil
+	}
+
+	if free > q.batch {
+		free = q.batch
+	}
+
+	reply, err := q.client.Eval(popScript, q.keys(), free, now.UnixMilli())
+	if err != nil {
+		return err
+	}
+
+	items, _ := reply.([]interface{})
+	for _, item := range items {
+		ticket := &Ticket{}
+		if err = json.Unmarshal([]byte(xconv.String(item)), ticket); err != nil {
+			xlog.Errorf("decode queue ticket failed: %v", err)
+			continue
+		}
+
+		admit(ticket)
+	}
+
+	return nil
+}
+
+func (q *Queue) join(ticket *Ticket, joinTime, now time.Time) (int64, error) {
+	if ticket == nil || ticket.PlayerID == "" {
+		return 0, ErrInvalidTicket
+	}
+
+	data, err := json.Marshal(ticket)
+	if err != nil {
+		return 0, err
+	}
+
+	reply, err := q.client.Eval(joinScript, q.keys(), ticket.PlayerID, joinTime.UnixMilli(), string(data), now.UnixMilli())
+	if err != nil {
+		return 0, err
+	}
+
+	return xconv.Int64(reply), nil
+}
+
+func (q *Queue) keys() []string {
+	return []string{q.waitingKey(), q.ticketKey(), q.activeKey(), q.admittedKey()}
+}
+
+func (q *Queue) waitingKey() string {
+	return fmt.Sprintf("%s:waiting", q.prefix)
+}
+
+func (q *Queue) ticketKey() string {
+	return fmt.Sprintf("%s:ticket", q.prefix)
+}
+
+func (q *Queue) activeKey() string {
+	return fmt.Sprintf("%s:active", q.prefix)
+}
+
+func (q *Queue) admittedKey() string {
+	return fmt.Sprintf("%s:admitted", q.prefix)
+}
+
+func (q *Queue) lockKey() string {
+	return fmt.Sprintf("%s:lock", q.prefix)
+}
@@ -4,11 +4,48 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 	"time"
 
+	"github.com/dobyte/due/v2/core/pool"
+	"github.com/dobyte/due/v2/etc"
 	"github.com/go-redis/redis/v8"
 )
 
+// Nil 键不存在
+const Nil = redis.Nil
+
+// Z 有序集合成员及分数
+type Z = redis.Z
+
+var factory = pool.NewFactory(func(name string) (*RedisClient, error) {
+	return NewRedisClient(
+		etc.Get(fmt.Sprintf("etc.redis.%s.addr", name), "127.0.0.1:6379").String(),
+		etc.Get(fmt.Sprintf("etc.redis.%s.password", name)).String(),
+		etc.Get(fmt.Sprintf("etc.redis.%s.db", name)).Int(),
+	)
+})
+
+// Instance 获取实例
+func Instance(name ...string) *RedisClient {
+	var (
+		err error
+		ins *RedisClient
+	)
+
+	if len(name) == 0 {
+		ins, err = factory.Get("default")
+	} else {
+		ins, err = factory.Get(name[0])
+	}
+
+	if err != nil {
+		log.Fatalf("create redis instance failed: %v", err)
+	}
+
+	return ins
+}
+
 // RedisClient Redis客户端
 type RedisClient struct {
 	client *redis.Client
@@ -56,6 +93,11 @@ func (r *RedisClient) Set(key string, value interface{}, expiration time.Duratio
 	return r.client.Set(r.ctx, key, jsonData, expiration).Err()
 }
 
+// SetNX 键不存在时设置键值对
+func (r *RedisClient) SetNX(key string, value interface{}, expiration time.Duration) (bool, error) {
+	return r.client.SetNX(r.ctx, key, value, expiration).Result()
+}
+
 // Get 获取值
 func (r *RedisClient) Get(key string) (string, error) {
 	return r.client.Get(r.ctx, key).Result()
@@ -140,6 +182,11 @@ func (r *RedisClient) HGet(key string, field string) (string, error) {
 	return r.client.HGet(r.ctx, key, field).Result()
 }
 
+// HMGet 批量获取哈希字段，字段不存在时对应值为nil
+func (r *RedisClient) HMGet(key string, fields ...string) ([]interface{}, error) {
+	return r.client.HMGet(r.ctx, key, fields...).Result()
+}
+
 // HGetAll 获取所有哈希字段
 func (r *RedisClient) HGetAll(key string) (map[string]string, error) {
 	return r.client.HGetAll(r.ctx, key).Result()
@@ -155,9 +202,13 @@ func (r *RedisClient) ZAdd(key string, score float64, member string) error {
 	return r.client.ZAdd(r.ctx, key, &redis.Z{Score: score, Member: member}).Err()
 }
 
-// ZRem 移除有序集合元素
-func (r *RedisClient) ZRem(key string, members ...string) error {
-	return r.client.ZRem(r.ctx, key, members...).Err()
+// ZRem 移除有序集合元素，返回实际移除的数量
+func (r *RedisClient) ZRem(key string, members ...string) (int64, error) {
+	values := make([]interface{}, len(members))
+	for i, member := range members {
+		values[i] = member
+	}
+	return r.client.ZRem(r.ctx, key, values...).Result()
 }
 
 // ZRange 获取有序集合范围内的元素
@@ -170,6 +221,11 @@ func (r *RedisClient) ZRevRange(key string, start, stop int64) ([]string, error)
 	return r.client.ZRevRange(r.ctx, key, start, stop).Result()
 }
 
+// ZRevRangeWithScores 获取有序集合范围内的元素及分数（降序）
+func (r *RedisClient) ZRevRangeWithScores(key string, start, stop int64) ([]Z, error) {
+	return r.client.ZRevRangeWithScores(r.ctx, key, start, stop).Result()
+}
+
 // ZScore 获取有序集合元素的分数
 func (r *RedisClient) ZScore(key string, member string) (float64, error) {
 	return r.client.ZScore(r.ctx, key, member).Result()
@@ -184,3 +240,23 @@ func (r *RedisClient) ZRank(key string, member string) (int64, error) {
 func (r *RedisClient) ZRevRank(key string, member string) (int64, error) {
 	return r.client.ZRevRank(r.ctx, key, member).Result()
 }
+
+// ZCard 获取有序集合元素数量
+func (r *RedisClient) ZCard(key string) (int64, error) {
+	return r.client.ZCard(r.ctx, key).Result()
+}
+
+// ZCount 统计有序集合分数区间内的元素数量
+func (r *RedisClient) ZCount(key string, min, max string) (int64, error) {
+	return r.client.ZCount(r.ctx, key, min, max).Result()
+}
+
+// ZRemRangeByScore 移除有序集合分数区间内的元素
+func (r *RedisClient) ZRemRangeByScore(key string, min, max string) (int64, error) {
+	return r.client.ZRemRangeByScore(r.ctx, key, min, max).Result()
+}
+
+// Eval 执行Lua脚本
+func (r *RedisClient) Eval(script string, keys []string, args ...interface{}) (interface{}, error) {
+	return r.client.Eval(r.ctx, script, keys, args...).Result()
+}
@@ -0,0 +1,353 @@
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"ghserver/utils/redis"
+
+	"github.com/dobyte/due/v2/core/pool"
+	"github.com/dobyte/due/v2/etc"
+	"github.com/dobyte/due/v2/utils/xconv"
+)
+
+const (
+	defaultPrefix = "session"
+	defaultTTL    = 30 * time.Minute
+)
+
+// 重复登录策略
+const (
+	PolicyKickOld   = "kick_old"   // 顶替旧会话
+	PolicyRejectNew = "reject_new" // 拒绝新登录，同一设备重新登录时仍顶替旧会话
+)
+
+// 绑定会话：原子替换玩家会话并返回旧会话，保证同一账号只有一个会话
+// KEYS[1] 会话键 KEYS[2] 在线集合
+// ARGV[1] 会话数据 ARGV[2] 有效期(毫秒) ARGV[3] 过期时间戳(毫秒) ARGV[4] 玩家ID
+// ARGV[5] 是否拒绝其他设备的新登录 ARGV[6] 设备ID
+const bindScript = `
+local old = redis.call('GET', KEYS[1])
+if old and ARGV[5] == '1' and cjson.decode(old)['device_id'] ~= ARGV[6] then
+	return {0, old}
+end
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+redis.call('ZADD', KEYS[2], ARGV[3], ARGV[4])
+return {1, old or ''}
+`
+
+// 替换会话：仅当当前会话令牌一致时替换，被顶替或踢出的令牌无法再使用
+// KEYS[1] 会话键 KEYS[2] 在线集合
+// ARGV[1] 会话数据 ARGV[2] 有效期(毫秒) ARGV[3] 过期时间戳(毫秒) ARGV[4] 玩家ID ARGV[5] 原令牌
+const replaceScript = `
+local cur = redis.call('GET', KEYS[1])
+if not cur or cjson.decode(cur)['token'] ~= ARGV[5] then
+	return 0
+end
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+redis.call('ZADD', KEYS[2], ARGV[3], ARGV[4])
+return 1
+`
+
+// 续期会话：会话存在时刷新有效期及在线集合中的过期时间
+// KEYS[1] 会话键 KEYS[2] 在线集合
+// ARGV[1] 有效期(毫秒) ARGV[2] 过期时间戳(毫秒) ARGV[3] 玩家ID
+const touchScript = `
+if redis.call('PEXPIRE', KEYS[1], ARGV[1]) == 1 then
+	redis.call('ZADD', KEYS[2], ARGV[2], ARGV[3])
+	return 1
+end
+redis.call('ZREM', KEYS[2], ARGV[3])
+return 0
+`
+
+// 关联网关连接：令牌一致时记录客户端所在的网关连接并续期会话
+// KEYS[1] 会话键 KEYS[2] 在线集合
+// ARGV[1] 有效期(毫秒) ARGV[2] 过期时间戳(毫秒) ARGV[3] 玩家ID ARGV[4] 令牌 ARGV[5] 网关ID ARGV[6] 连接ID
+const attachScript = `
+local cur = redis.call('GET', KEYS[1])
+if not cur then
+	return 0
+end
+local session = cjson.decode(cur)
+if session['token'] ~= ARGV[4] then
+	return 0
+end
+session['gid'] = ARGV[5]
+session['cid'] = ARGV[6]
+redis.call('SET', KEYS[1], cjson.encode(session), 'PX', ARGV[1])
+redis.call('ZADD', KEYS[2], ARGV[2], ARGV[3])
+return 1
+`
+
+// 移除会话：指定令牌时仅当会话令牌一致才移除，避免误删新会话
+// KEYS[1] 会话键 KEYS[2] 在线集合
+// ARGV[1] 玩家ID ARGV[2] 令牌
+const removeScript = `
+local cur = redis.call('GET', KEYS[1])
+if not cur then
+	redis.call('ZREM', KEYS[2], ARGV[1])
+	return 0
+end
+if ARGV[2] ~= '' and cjson.decode(cur)['token'] ~= ARGV[2] then
+	return 0
+end
+redis.call('DEL', KEYS[1])
+redis.call('ZREM', KEYS[2], ARGV[1])
+return 1
+`
+
+var factory = pool.NewFactory(func(name string) (*Store, error) {
+	return NewStore(fmt.Sprintf("etc.session.%s", name))
+})
+
+var (
+	ErrInvalidSession = errors.New("invalid session")
+	ErrAlreadyOnline  = errors.New("already online")
+)
+
+type Config struct {
+	Redis  string        `json:"redis"`  // Redis实例名
+	Prefix string        `json:"prefix"` // 键前缀
+	TTL    time.Duration `json:"ttl"`    // 会话有效期，期间未续期则视为离线
+	Policy string        `json:"policy"` // 重复登录策略
+}
+
+// Session 玩家会话
+type Session struct {
+	PlayerID      string    `json:"player_id"`            // 玩家ID
+	Account       string    `json:"account"`              // 账号
+	Token         string    `json:"token"`                // 当前令牌
+	DeviceID      string    `json:"device_id"`            // 设备ID
+	LoginTime     time.Time `json:"login_time"`           // 登录时间
+	ReconnectTime time.Time `json:"reconnect_time"`       // 最近重连时间
+	GID           string    `json:"gid,omitempty"`        // 客户端所在网关ID，连接网关鉴权后记录
+	CID           int64     `json:"cid,string,omitempty"` // 客户端在网关上的连接ID
+}
+
+// Store Redis会话存储，集群内共享
+type Store struct {
+	client *redis.RedisClient
+	prefix string
+	ttl    time.Duration
+	policy string
+}
+
+// Instance 获取实例
+func Instance(name ...string) *Store {
+	var (
+		err error
+		ins *Store
+	)
+
+	if len(name) == 0 {
+		ins, err = factory.Get("default")
+	} else {
+		ins, err = factory.Get(name[0])
+	}
+
+	if err != nil {
+		log.Fatalf("create session store instance failed: %v", err)
+	}
+
+	return ins
+}
+
+// NewStore 新建会话存储
+func NewStore[T string | Config | *Config](config T) (*Store, error) {
+	var (
+		conf *Config
+		v    any = config
+	)
+
+	switch c := v.(type) {
+	case string:
+		conf = &Config{
+			Redis:  etc.Get(fmt.Sprintf("%s.redis", c), "default").String(),
+			Prefix: etc.Get(fmt.Sprintf("%s.prefix", c), defaultPrefix).String(),
+			TTL:    etc.Get(fmt.Sprintf("%s.ttl", c), defaultTTL).Duration(),
+			Policy: etc.Get(fmt.Sprintf("%s.policy", c), PolicyKickOld).String(),
+		}
+	case Config:
+		conf = &c
+	case *Config:
+		conf = c
+	}
+
+	s := &Store{
+		client: redis.Instance(conf.Redis),
+		prefix: conf.Prefix,
+		ttl:    conf.TTL,
+		policy: conf.Policy,
+	}
+
+	if s.prefix == "" {
+		s.prefix = defaultPrefix
+	}
+
+	if s.ttl <= 0 {
+		s.ttl = defaultTTL
+	}
+
+	if s.policy != PolicyRejectNew {
+		s.policy = PolicyKickOld
+	}
+
+	return s, nil
+}
+
+// TTL 会话有效期
+func (s *Store) TTL() time.Duration {
+	return s.ttl
+}
+
+// Bind 绑定会话，已有会话时原子替换并返回旧会话；
+// 拒绝新登录策略下其他设备已在线时返回ErrAlreadyOnline及在线会话
+func (s *Store) Bind(session *Session) (*Session, error) {
+	if session == nil || session.PlayerID == "" {
+		return nil, ErrInvalidSession
+	}
+
+	data, err := json.Marshal(session)
+	if err != nil {
+		return nil, err
+	}
+
+	expireAt := time.Now().Add(s.ttl).UnixMilli()
+
+	reject := 0
+	if s.policy == PolicyRejectNew {
+		reject = 1
+	}
+
+	reply, err := s.client.Eval(bindScript, []string{s.sessionKey(session.PlayerID), s.onlineKey()},
+		string(data), s.ttl.Milliseconds(), expireAt, session.PlayerID, reject, session.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	values, ok := reply.([]interface{})
+	if !ok || len(values) != 2 {
+		return nil, fmt.Errorf("unexpected reply: %v", reply)
+	}
+
+	var old *Session
+	if raw := xconv.String(values[1]); raw != "" {
+		if old, err = s.decode(raw); err != nil {
+			return nil, err
+		}
+	}
+
+	if xconv.Int(values[0]) == 0 {
+		return old, ErrAlreadyOnline
+	}
+
+	return old, nil
+}
+
+// Replace 替换会话，仅当当前会话令牌与token一致时生效
+func (s *Store) Replace(session *Session, token string) (bool, error) {
+	if session == nil || session.PlayerID == "" {
+		return false, ErrInvalidSession
+	}
+
+	data, err := json.Marshal(session)
+	if err != nil {
+		return false, err
+	}
+
+	expireAt := time.Now().Add(s.ttl).UnixMilli()
+
+	reply, err := s.client.Eval(replaceScript, []string{s.sessionKey(session.PlayerID), s.onlineKey()},
+		string(data), s.ttl.Milliseconds(), expireAt, session.PlayerID, token)
+	if err != nil {
+		return false, err
+	}
+
+	return xconv.Int(reply) == 1, nil
+}
+
+// Get 获取会话，不存在时返回nil
+func (s *Store) Get(playerID string) (*Session, error) {
+	data, err := s.client.Get(s.sessionKey(playerID))
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return s.decode(data)
+}
+
+// IsOnline 检查玩家是否在线
+func (s *Store) IsOnline(playerID string) (bool, error) {
+	return s.client.Exists(s.sessionKey(playerID))
+}
+
+// Touch 续期会话，会话不存在时返回false
+func (s *Store) Touch(playerID string) (bool, error) {
+	expireAt := time.Now().Add(s.ttl).UnixMilli()
+
+	reply, err := s.client.Eval(touchScript, []string{s.sessionKey(playerID), s.onlineKey()},
+		s.ttl.Milliseconds(), expireAt, playerID)
+	if err != nil {
+		return false, err
+	}
+
+	return xconv.Int(reply) == 1, nil
+}
+
+// Attach 记录客户端所在的网关连接并续期会话，会话不存在或令牌不一致时返回false
+func (s *Store) Attach(playerID, token, gid string, cid int64) (bool, error) {
+	expireAt := time.Now().Add(s.ttl).UnixMilli()
+
+	reply, err := s.client.Eval(attachScript, []string{s.sessionKey(playerID), s.onlineKey()},
+		s.ttl.Milliseconds(), expireAt, playerID, token, gid, cid)
+	if err != nil {
+		return false, err
+	}
+
+	return xconv.Int(reply) == 1, nil
+}
+
+// Remove 移除会话；token不为空时仅移除令牌一致的会话
+func (s *Store) Remove(playerID string, token string) (bool, error) {
+	reply, err := s.client.Eval(removeScript, []string{s.sessionKey(playerID), s.onlineKey()}, playerID, token)
+	if err != nil {
+		return false, err
+	}
+
+	return xconv.Int(reply) == 1, nil
+}
+
+// Count 统计集群在线人数，顺带清理已过期的在线记录
+func (s *Store) Count() (int64, error) {
+	now := xconv.String(time.Now().UnixMilli())
+
+	if _, err := s.client.ZRemRangeByScore(s.onlineKey(), "-inf", now); err != nil {
+		return 0, err
+	}
+
+	return s.client.ZCount(s.onlineKey(), "("+now, "+inf")
+}
+
+func (s *Store) decode(data string) (*Session, error) {
+	session := &Session{}
+	if err := json.Unmarshal([]byte(data), session); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func (s *Store) sessionKey(playerID string) string {
+	return fmt.Sprintf("%s:player:%s", s.prefix, playerID)
+}
+
+func (s *Store) onlineKey() string {
+	return fmt.Sprintf("%s:online", s.prefix)
+}
@@ -0,0 +1,62 @@
+package shard
+
+import (
+	"hash/fnv"
+	"sync"
+)
+
+const defaultShards = 64
+
+// Map 分片并发映射，按键分片加锁：同一键的操作串行执行，不同分片的键互不阻塞
+type Map[V any] struct {
+	shards []*bucket[V]
+}
+
+type bucket[V any] struct {
+	mutex sync.Mutex
+	items map[string]V
+}
+
+// NewMap 新建分片并发映射，shards为分片数量
+func NewMap[V any](shards ...int) *Map[V] {
+	n := defaultShards
+	if len(shards) > 0 && shards[0] > 0 {
+		n = shards[0]
+	}
+
+	m := &Map[V]{shards: make([]*bucket[V], n)}
+	for i := range m.shards {
+		m.shards[i] = &bucket[V]{items: make(map[string]V)}
+	}
+
+	return m
+}
+
+// Do 锁定键所在分片后执行fn；键不存在时先调用create创建并保存，create为nil时fn接收零值
+func (m *Map[V]) Do(key string, create func() V, fn func(value V)) {
+	b := m.bucket(key)
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	value, ok := b.items[key]
+	if !ok && create != nil {
+		value = create()
+		b.items[key] = value
+	}
+
+	fn(value)
+}
+
+// Delete 删除键
+func (m *Map[V]) Delete(key string) {
+	b := m.bucket(key)
+	b.mutex.Lock()
+	delete(b.items, key)
+	b.mutex.Unlock()
+}
+
+func (m *Map[V]) bucket(key string) *bucket[V] {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return m.shards[h.Sum32()%uint32(len(m.shards))]
+}
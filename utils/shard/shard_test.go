@@ -0,0 +1,102 @@
+package shard
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestMapDo(t *testing.T) {
+	m := NewMap[*int]()
+	created := 0
+	create := func() *int {
+		created++
+		return new(int)
+	}
+
+	for i := 0; i < 3; i++ {
+		m.Do("a", create, func(value *int) { *value++ })
+	}
+
+	if created != 1 {
+		t.Errorf("created = %d, want 1", created)
+	}
+
+	m.Do("a", nil, func(value *int) {
+		if value == nil || *value != 3 {
+			t.Errorf("value = %v, want 3", value)
+		}
+	})
+
+	// create为nil时不创建键
+	m.Do("b", nil, func(value *int) {
+		if value != nil {
+			t.Errorf("value = %v, want nil", *value)
+		}
+	})
+}
+
+func TestMapDelete(t *testing.T) {
+	m := NewMap[int](1)
+	m.Do("a", func() int { return 1 }, func(int) {})
+	m.Delete("a")
+	m.Delete("missing")
+
+	m.Do("a", nil, func(value int) {
+		if value != 0 {
+			t.Errorf("value after delete = %d, want 0", value)
+		}
+	})
+}
+
+func TestNewMapShards(t *testing.T) {
+	cases := []struct {
+		shards []int
+		want   int
+	}{
+		{nil, defaultShards},
+		{[]int{0}, defaultShards},
+		{[]int{-1}, defaultShards},
+		{[]int{8}, 8},
+	}
+
+	for _, c := range cases {
+		if got := len(NewMap[int](c.shards...).shards); got != c.want {
+			t.Errorf("NewMap(%v) shards = %d, want %d", c.shards, got, c.want)
+		}
+	}
+}
+
+func TestMapConcurrentDo(t *testing.T) {
+	const (
+		keys    = 32
+		workers = 16
+		rounds  = 100
+	)
+
+	m := NewMap[*int](4)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < rounds; j++ {
+				for k := 0; k < keys; k++ {
+					m.Do(fmt.Sprintf("key-%d", k), func() *int { return new(int) }, func(value *int) { *value++ })
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	for k := 0; k < keys; k++ {
+		m.Do(fmt.Sprintf("key-%d", k), nil, func(value *int) {
+			if value == nil || *value != workers*rounds {
+				t.Errorf("key-%d = %v, want %d", k, value, workers*rounds)
+			}
+		})
+	}
+}
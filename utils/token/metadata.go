@@ -0,0 +1,25 @@
+package token
+
+import (
+	"context"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// 携带令牌的RPC元数据键
+const metadataKey = "authorization"
+
+// NewOutgoingContext 将玩家令牌附加到RPC调用的元数据中
+func NewOutgoingContext(ctx context.Context, token string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, metadataKey, token)
+}
+
+// FromIncomingContext 从RPC调用的元数据中获取玩家令牌，未携带时返回空字符串
+func FromIncomingContext(ctx context.Context) string {
+	values := metadata.ValueFromIncomingContext(ctx, metadataKey)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
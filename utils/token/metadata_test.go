@@ -0,0 +1,21 @@
+package token
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+)
+
+func TestMetadataContext(t *testing.T) {
+	ctx := NewOutgoingContext(context.Background(), "token-1")
+
+	md, _ := metadata.FromOutgoingContext(ctx)
+	if got := FromIncomingContext(metadata.NewIncomingContext(context.Background(), md)); got != "token-1" {
+		t.Errorf("token = %q, want %q", got, "token-1")
+	}
+
+	if got := FromIncomingContext(context.Background()); got != "" {
+		t.Errorf("token without metadata = %q, want empty", got)
+	}
+}
@@ -0,0 +1,179 @@
+package token
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/dobyte/due/v2/core/pool"
+	"github.com/dobyte/due/v2/etc"
+	"github.com/dobyte/due/v2/utils/xconv"
+)
+
+const defaultExpire = 24 * time.Hour
+
+var factory = pool.NewFactory(func(name string) (*Token, error) {
+	return NewToken(fmt.Sprintf("etc.token.%s", name))
+})
+
+var (
+	ErrInvalidToken     = errors.New("invalid token")
+	ErrInvalidSignature = errors.New("invalid token signature")
+	ErrTokenExpired     = errors.New("token expired")
+	ErrUnknownKey       = errors.New("unknown token key")
+	ErrMissingKey       = errors.New("missing token sign key")
+)
+
+type Config struct {
+	KeyID  string            `json:"keyID"`  // 当前签名密钥ID
+	Keys   map[string]string `json:"keys"`   // 密钥ID -> 密钥，轮换期间旧密钥仍可用于校验
+	Expire time.Duration     `json:"expire"` // 有效期
+}
+
+// Claims 令牌声明
+type Claims struct {
+	PlayerID string `json:"pid"` // 玩家ID
+	DeviceID string `json:"did"` // 设备ID
+	IssuedAt int64  `json:"iat"` // 签发时间
+	ExpireAt int64  `json:"exp"` // 过期时间
+	KeyID    string `json:"kid"` // 签名密钥ID
+}
+
+// Token 令牌签发与校验器
+type Token struct {
+	keyID  string
+	keys   map[string][]byte
+	expire time.Duration
+}
+
+// Instance 获取实例
+func Instance(name ...string) *Token {
+	var (
+		err error
+		ins *Token
+	)
+
+	if len(name) == 0 {
+		ins, err = factory.Get("default")
+	} else {
+		ins, err = factory.Get(name[0])
+	}
+
+	if err != nil {
+		log.Fatalf("create token instance failed: %v", err)
+	}
+
+	return ins
+}
+
+// NewToken 新建令牌签发与校验器
+func NewToken[T string | Config | *Config](config T) (*Token, error) {
+	var (
+		conf *Config
+		v    any = config
+	)
+
+	switch c := v.(type) {
+	case string:
+		conf = &Config{
+			KeyID:  etc.Get(fmt.Sprintf("%s.keyID", c)).String(),
+			Keys:   make(map[string]string),
+			Expire: etc.Get(fmt.Sprintf("%s.expire", c), defaultExpire).Duration(),
+		}
+		for kid, key := range etc.Get(fmt.Sprintf("%s.keys", c)).Map() {
+			conf.Keys[kid] = xconv.String(key)
+		}
+	case Config:
+		conf = &c
+	case *Config:
+		conf = c
+	}
+
+	if _, ok := conf.Keys[conf.KeyID]; !ok || conf.KeyID == "" {
+		return nil, ErrMissingKey
+	}
+
+	t := &Token{
+		keyID:  conf.KeyID,
+		keys:   make(map[string][]byte, len(conf.Keys)),
+		expire: conf.Expire,
+	}
+
+	if t.expire <= 0 {
+		t.expire = defaultExpire
+	}
+
+	for kid, key := range conf.Keys {
+		t.keys[kid] = []byte(key)
+	}
+
+	return t, nil
+}
+
+// Generate 签发令牌
+func (t *Token) Generate(playerID, deviceID string) (string, *Claims, error) {
+	now := time.Now()
+	claims := &Claims{
+		PlayerID: playerID,
+		DeviceID: deviceID,
+		IssuedAt: now.Unix(),
+		ExpireAt: now.Add(t.expire).Unix(),
+		KeyID:    t.keyID,
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", nil, err
+	}
+
+	body := base64.RawURLEncoding.EncodeToString(payload)
+	signature := t.sign(t.keys[t.keyID], body)
+
+	return body + "." + signature, claims, nil
+}
+
+// Verify 校验令牌并返回声明
+func (t *Token) Verify(token string) (*Claims, error) {
+	body, signature, ok := strings.Cut(token, ".")
+	if !ok || body == "" || signature == "" {
+		return nil, ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(body)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	claims := &Claims{}
+	if err = json.Unmarshal(payload, claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	key, ok := t.keys[claims.KeyID]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(t.sign(key, body))) {
+		return nil, ErrInvalidSignature
+	}
+
+	if time.Now().Unix() >= claims.ExpireAt {
+		return nil, ErrTokenExpired
+	}
+
+	return claims, nil
+}
+
+// 计算签名
+func (t *Token) sign(key []byte, body string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(body))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,138 @@
+package token
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTokenVerify(t *testing.T) {
+	issuer := mustNewToken(t, Config{KeyID: "k1", Keys: map[string]string{"k1": "secret-1"}})
+	// 轮换后使用k2签发，k1仍可用于校验
+	rotated := mustNewToken(t, Config{KeyID: "k2", Keys: map[string]string{"k1": "secret-1", "k2": "secret-2"}})
+	// k1下线后不再接受其签发的令牌
+	retired := mustNewToken(t, Config{KeyID: "k2", Keys: map[string]string{"k2": "secret-2"}})
+	// 同一密钥ID使用不同密钥
+	forged := mustNewToken(t, Config{KeyID: "k1", Keys: map[string]string{"k1": "secret-x"}})
+
+	valid, _, err := issuer.Generate("100001", "device-1")
+	if err != nil {
+		t.Fatalf("generate token failed: %v", err)
+	}
+
+	body, signature, _ := strings.Cut(valid, ".")
+	now := time.Now()
+
+	cases := []struct {
+		name     string
+		verifier *Token
+		token    string
+		want     error
+	}{
+		{name: "有效令牌", verifier: issuer, token: valid},
+		{name: "轮换后旧密钥令牌", verifier: rotated, token: valid},
+		{name: "轮换后新密钥令牌", verifier: issuer, token: mustGenerate(t, rotated), want: ErrUnknownKey},
+		{name: "旧密钥下线", verifier: retired, token: valid, want: ErrUnknownKey},
+		{name: "密钥不一致", verifier: forged, token: valid, want: ErrInvalidSignature},
+		{name: "空令牌", verifier: issuer, token: "", want: ErrInvalidToken},
+		{name: "缺少签名", verifier: issuer, token: body, want: ErrInvalidToken},
+		{name: "声明非法", verifier: issuer, token: "!!." + signature, want: ErrInvalidToken},
+		{name: "篡改签名", verifier: issuer, token: body + "." + tamper(signature), want: ErrInvalidSignature},
+		{
+			name:     "篡改玩家ID",
+			verifier: issuer,
+			token:    sign(t, issuer, "k1", &Claims{PlayerID: "100002", DeviceID: "device-1", ExpireAt: now.Add(time.Hour).Unix(), KeyID: "k1"}, signature),
+			want:     ErrInvalidSignature,
+		},
+		{
+			name:     "已过期",
+			verifier: issuer,
+			token:    sign(t, issuer, "k1", &Claims{PlayerID: "100001", IssuedAt: now.Add(-2 * time.Hour).Unix(), ExpireAt: now.Add(-time.Hour).Unix(), KeyID: "k1"}, ""),
+			want:     ErrTokenExpired,
+		},
+		{
+			name:     "过期时间篡改",
+			verifier: issuer,
+			token:    sign(t, issuer, "k1", &Claims{PlayerID: "100001", DeviceID: "device-1", ExpireAt: now.Add(365 * 24 * time.Hour).Unix(), KeyID: "k1"}, signature),
+			want:     ErrInvalidSignature,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			claims, err := c.verifier.Verify(c.token)
+			if !errors.Is(err, c.want) {
+				t.Fatalf("verify error = %v, want %v", err, c.want)
+			}
+
+			if c.want == nil && claims.PlayerID != "100001" {
+				t.Errorf("player id = %q, want %q", claims.PlayerID, "100001")
+			}
+		})
+	}
+}
+
+func TestNewTokenMissingKey(t *testing.T) {
+	cases := []Config{
+		{},
+		{KeyID: "k1"},
+		{KeyID: "k2", Keys: map[string]string{"k1": "secret-1"}},
+	}
+
+	for _, c := range cases {
+		if _, err := NewToken(c); !errors.Is(err, ErrMissingKey) {
+			t.Errorf("NewToken(%+v) error = %v, want %v", c, err, ErrMissingKey)
+		}
+	}
+}
+
+func mustNewToken(t *testing.T, config Config) *Token {
+	t.Helper()
+
+	tk, err := NewToken(config)
+	if err != nil {
+		t.Fatalf("new token failed: %v", err)
+	}
+
+	return tk
+}
+
+func mustGenerate(t *testing.T, tk *Token) string {
+	t.Helper()
+
+	token, _, err := tk.Generate("100001", "device-1")
+	if err != nil {
+		t.Fatalf("generate token failed: %v", err)
+	}
+
+	return token
+}
+
+// 按指定声明构造令牌；signature为空时使用密钥签名，否则沿用给定签名以模拟篡改
+func sign(t *testing.T, tk *Token, kid string, claims *Claims, signature string) string {
+	t.Helper()
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatalf("marshal claims failed: %v", err)
+	}
+
+	body := base64.RawURLEncoding.EncodeToString(payload)
+	if signature == "" {
+		signature = tk.sign(tk.keys[kid], body)
+	}
+
+	return body + "." + signature
+}
+
+// 修改签名的首个字符
+func tamper(signature string) string {
+	if signature[0] == 'A' {
+		return "B" + signature[1:]
+	}
+
+	return "A" + signature[1:]
+}